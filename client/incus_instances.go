@@ -804,12 +804,30 @@ func (r *ProtocolIncus) CopyInstance(source InstanceServer, instance api.Instanc
 			return nil, errors.New("The source server is missing the required \"custom_volume_refresh_exclude_older_snapshots\" API extension")
 		}
 
+		if args.RefreshConfigOnly && !r.HasExtension("instance_refresh_config_only") {
+			return nil, errors.New("The target server is missing the required \"instance_refresh_config_only\" API extension")
+		}
+
 		if args.AllowInconsistent {
 			if !r.HasExtension("instance_allow_inconsistent_copy") {
 				return nil, errors.New("The source server is missing the required \"instance_allow_inconsistent_copy\" API extension")
 			}
 		}
 
+		if args.VerifyOnly {
+			if !r.HasExtension("instance_migration_verify_only") {
+				return nil, errors.New("The target server is missing the required \"instance_migration_verify_only\" API extension")
+			}
+
+			if !source.HasExtension("instance_migration_verify_only") {
+				return nil, errors.New("The source server is missing the required \"instance_migration_verify_only\" API extension")
+			}
+
+			if args.Live {
+				return nil, errors.New("Verify only copies cannot be live")
+			}
+		}
+
 		// Allow overriding the target name
 		if args.Name != "" {
 			req.Name = args.Name
@@ -819,7 +837,9 @@ func (r *ProtocolIncus) CopyInstance(source InstanceServer, instance api.Instanc
 		req.Source.InstanceOnly = args.InstanceOnly
 		req.Source.Refresh = args.Refresh
 		req.Source.RefreshExcludeOlder = args.RefreshExcludeOlder
+		req.Source.RefreshConfigOnly = args.RefreshConfigOnly
 		req.Source.AllowInconsistent = args.AllowInconsistent
+		req.Source.VerifyOnly = args.VerifyOnly
 	}
 
 	if req.Source.Live {
@@ -838,6 +858,10 @@ func (r *ProtocolIncus) CopyInstance(source InstanceServer, instance api.Instanc
 
 	// Optimization for the local copy case
 	if destInfo.URL == sourceInfo.URL && destInfo.SocketPath == sourceInfo.SocketPath && (!r.IsClustered() || instance.Location == r.clusterTarget || r.HasExtension("cluster_internal_copy")) {
+		if req.Source.VerifyOnly {
+			return nil, errors.New("Verify only copies are only supported between different servers")
+		}
+
 		// Project handling
 		if destInfo.Project != sourceInfo.Project {
 			if !r.HasExtension("container_copy_project") {
@@ -871,6 +895,10 @@ func (r *ProtocolIncus) CopyInstance(source InstanceServer, instance api.Instanc
 		return &rop, nil
 	}
 
+	if req.Source.RefreshConfigOnly {
+		return nil, errors.New("Config only refreshes are only supported within the same server")
+	}
+
 	// Source request
 	sourceReq := api.InstancePost{
 		Migration:         true,
@@ -878,6 +906,7 @@ func (r *ProtocolIncus) CopyInstance(source InstanceServer, instance api.Instanc
 		InstanceOnly:      req.Source.InstanceOnly,
 		AllowInconsistent: req.Source.AllowInconsistent,
 		Devices:           req.Devices,
+		VerifyOnly:        req.Source.VerifyOnly,
 	}
 
 	// Push mode migration
@@ -1851,6 +1880,14 @@ func (r *ProtocolIncus) CreateInstanceSnapshot(instanceName string, snapshot api
 		return nil, errors.New("The server is missing the required \"snapshot_expiry_creation\" API extension")
 	}
 
+	if len(snapshot.VolumeConfig) > 0 && !r.HasExtension("instance_snapshot_volume_config") {
+		return nil, errors.New("The server is missing the required \"instance_snapshot_volume_config\" API extension")
+	}
+
+	if snapshot.NoWait && !r.HasExtension("instance_snapshot_nowait") {
+		return nil, errors.New("The server is missing the required \"instance_snapshot_nowait\" API extension")
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/snapshots", path, url.PathEscape(instanceName)), snapshot, "")
 	if err != nil {
@@ -3193,6 +3230,29 @@ func (r *ProtocolIncus) proxyMigration(targetOp *operation, targetSecrets map[st
 	return nil
 }
 
+// GetInstanceDebugSnapshots compares the snapshot records of an instance with the snapshots found on storage.
+func (r *ProtocolIncus) GetInstanceDebugSnapshots(name string) (*api.SnapshotConsistencyReport, error) {
+	err := r.CheckExtension("instance_debug_snapshots")
+	if err != nil {
+		return nil, err
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	report := api.SnapshotConsistencyReport{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct("GET", fmt.Sprintf("%s/%s/debug/snapshots", path, url.PathEscape(name)), nil, "", &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
 // GetInstanceDebugMemory retrieves memory debug information for a given instance and saves it to the specified file path.
 func (r *ProtocolIncus) GetInstanceDebugMemory(name string, format string) (io.ReadCloser, error) {
 	path, v, err := r.instanceTypeToPath(api.InstanceTypeVM)
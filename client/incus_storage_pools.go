@@ -143,3 +143,39 @@ func (r *ProtocolIncus) GetStoragePoolResources(name string) (*api.ResourcesStor
 
 	return &res, nil
 }
+
+// GetStoragePoolManifest gets the logical contents of a given storage pool, without any volume data.
+func (r *ProtocolIncus) GetStoragePoolManifest(name string) (*api.StoragePoolManifest, error) {
+	err := r.CheckExtension("storage_pool_manifest")
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := api.StoragePoolManifest{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct("GET", fmt.Sprintf("/storage-pools/%s/manifest", url.PathEscape(name)), nil, "", &manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// PreviewStoragePoolUpdate reports what updating a storage pool with the given configuration would do, without applying it.
+func (r *ProtocolIncus) PreviewStoragePoolUpdate(name string, pool api.StoragePoolPut) (*api.StoragePoolUpdatePreview, error) {
+	err := r.CheckExtension("storage_pool_update_preview")
+	if err != nil {
+		return nil, err
+	}
+
+	preview := api.StoragePoolUpdatePreview{}
+
+	// Send the request
+	_, err = r.queryStruct("POST", fmt.Sprintf("/storage-pools/%s/preview", url.PathEscape(name)), pool, "", &preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preview, nil
+}
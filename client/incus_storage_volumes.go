@@ -318,6 +318,10 @@ func (r *ProtocolIncus) CreateStoragePoolVolume(pool string, volume api.StorageV
 		return errors.New("The server is missing the required \"storage\" API extension")
 	}
 
+	if volume.Template != "" && !r.HasExtension("storage_volume_templates") {
+		return errors.New("The server is missing the required \"storage_volume_templates\" API extension")
+	}
+
 	// Send the request
 	path := fmt.Sprintf("/storage-pools/%s/volumes/%s", url.PathEscape(pool), url.PathEscape(volume.Type))
 	_, _, err := r.query("POST", path, volume, "")
@@ -334,6 +338,10 @@ func (r *ProtocolIncus) CreateStoragePoolVolumeSnapshot(pool string, volumeType
 		return nil, errors.New("The server is missing the required \"storage_api_volume_snapshots\" API extension")
 	}
 
+	if snapshot.Quiesce && !r.HasExtension("storage_volume_snapshot_quiesce") {
+		return nil, errors.New("The server is missing the required \"storage_volume_snapshot_quiesce\" API extension")
+	}
+
 	// Send the request
 	path := fmt.Sprintf("/storage-pools/%s/volumes/%s/%s/snapshots",
 		url.PathEscape(pool),
@@ -642,6 +650,10 @@ func (r *ProtocolIncus) CopyStoragePoolVolume(pool string, source InstanceServer
 		return nil, errors.New("The target server is missing the required \"custom_volume_refresh_exclude_older_snapshots\" API extension")
 	}
 
+	if args != nil && args.Verify && !r.HasExtension("storage_volume_copy_verify") {
+		return nil, errors.New("The target server is missing the required \"storage_volume_copy_verify\" API extension")
+	}
+
 	req := api.StorageVolumesPost{
 		Name: args.Name,
 		Type: volume.Type,
@@ -652,6 +664,7 @@ func (r *ProtocolIncus) CopyStoragePoolVolume(pool string, source InstanceServer
 			VolumeOnly:          args.VolumeOnly,
 			Refresh:             args.Refresh,
 			RefreshExcludeOlder: args.RefreshExcludeOlder,
+			Verify:              args.Verify,
 		},
 	}
 
@@ -920,6 +933,22 @@ func (r *ProtocolIncus) DeleteStoragePoolVolume(pool string, volType string, nam
 	return nil
 }
 
+// DeleteStoragePoolVolumeForce deletes a storage pool volume, including image volumes still having volumes cloned from them.
+func (r *ProtocolIncus) DeleteStoragePoolVolumeForce(pool string, volType string, name string) error {
+	if !r.HasExtension("storage_volume_image_delete_force") {
+		return errors.New("The server is missing the required \"storage_volume_image_delete_force\" API extension")
+	}
+
+	// Send the request
+	path := fmt.Sprintf("/storage-pools/%s/volumes/%s/%s?force=1", url.PathEscape(pool), url.PathEscape(volType), url.PathEscape(name))
+	_, _, err := r.query("DELETE", path, nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // RebuildStoragePoolVolume rebuilds an existing custom storage volume as empty.
 func (r *ProtocolIncus) RebuildStoragePoolVolume(pool string, volType string, name string, volume api.StorageVolumeRebuildPost) (Operation, error) {
 	err := r.CheckExtension("storage_volumes_rebuild")
@@ -176,6 +176,7 @@ type InstanceServer interface {
 	DeleteInstanceTemplateFile(name string, templateName string) (err error)
 
 	GetInstanceDebugMemory(name string, format string) (rc io.ReadCloser, err error)
+	GetInstanceDebugSnapshots(name string) (report *api.SnapshotConsistencyReport, err error)
 
 	// Event handling functions
 	GetEvents() (listener *EventListener, err error)
@@ -329,6 +330,8 @@ type InstanceServer interface {
 	GetStoragePoolsWithFilter(filters []string) ([]api.StoragePool, error)
 	GetStoragePool(name string) (pool *api.StoragePool, ETag string, err error)
 	GetStoragePoolResources(name string) (resources *api.ResourcesStoragePool, err error)
+	GetStoragePoolManifest(name string) (manifest *api.StoragePoolManifest, err error)
+	PreviewStoragePoolUpdate(name string, pool api.StoragePoolPut) (preview *api.StoragePoolUpdatePreview, err error)
 	CreateStoragePool(pool api.StoragePoolsPost) (err error)
 	UpdateStoragePool(name string, pool api.StoragePoolPut, ETag string) (err error)
 	DeleteStoragePool(name string) (err error)
@@ -379,6 +382,7 @@ type InstanceServer interface {
 	CreateStoragePoolVolume(pool string, volume api.StorageVolumesPost) (err error)
 	UpdateStoragePoolVolume(pool string, volType string, name string, volume api.StorageVolumePut, ETag string) (err error)
 	DeleteStoragePoolVolume(pool string, volType string, name string) (err error)
+	DeleteStoragePoolVolumeForce(pool string, volType string, name string) (err error)
 	RenameStoragePoolVolume(pool string, volType string, name string, volume api.StorageVolumePost) (err error)
 	CopyStoragePoolVolume(pool string, source InstanceServer, sourcePool string, volume api.StorageVolume, args *StoragePoolVolumeCopyArgs) (op RemoteOperation, err error)
 	MoveStoragePoolVolume(pool string, source InstanceServer, sourcePool string, volume api.StorageVolume, args *StoragePoolVolumeMoveArgs) (op RemoteOperation, err error)
@@ -590,6 +594,9 @@ type StoragePoolVolumeCopyArgs struct {
 
 	// API extension: custom_volume_refresh_exclude_older_snapshots
 	RefreshExcludeOlder bool
+
+	// API extension: storage_volume_copy_verify
+	Verify bool
 }
 
 // The StoragePoolVolumeMoveArgs struct is used to pass additional options
@@ -650,8 +657,16 @@ type InstanceCopyArgs struct {
 	// API extension: custom_volume_refresh_exclude_older_snapshots
 	RefreshExcludeOlder bool
 
+	// API extension: instance_refresh_config_only
+	// Only refresh the config volume of a virtual machine
+	RefreshConfigOnly bool
+
 	// API extension: instance_allow_inconsistent_copy
 	AllowInconsistent bool
+
+	// API extension: instance_migration_verify_only
+	// Only check that the target can accept the instance, without copying it
+	VerifyOnly bool
 }
 
 // The InstanceSnapshotCopyArgs struct is used to pass additional options during instance copy.
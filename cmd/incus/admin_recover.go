@@ -20,6 +20,8 @@ import (
 
 type cmdAdminRecover struct {
 	global *cmdGlobal
+
+	flagBestEffort bool
 }
 
 var cmdAdminRecoverUsage = u.Usage{u.RemoteColonOpt}
@@ -33,7 +35,9 @@ func (c *cmdAdminRecover) command() *cobra.Command {
   This command is mostly used for disaster recovery. It will ask you about unknown storage pools and attempt to
   access them, along with existing storage pools, and identify any missing instances and volumes that exist on the
   pools but are not in the database. It will then offer to recreate these database records.`))
+
 	cmd.RunE = c.run
+	cli.AddBoolFlag(cmd.Flags(), &c.flagBestEffort, "best-effort", i18n.G("Also recover virtual machine disks that have no backup file"))
 
 	return cmd
 }
@@ -168,7 +172,8 @@ func (c *cmdAdminRecover) run(cmd *cobra.Command, args []string) error {
 
 	// Send /internal/recover/validate request to the daemon.
 	reqValidate := recover.ValidatePost{
-		Pools: make([]api.StoragePoolsPost, 0, len(existingPools)+len(unknownPools)),
+		Pools:      make([]api.StoragePoolsPost, 0, len(existingPools)+len(unknownPools)),
+		BestEffort: c.flagBestEffort,
 	}
 
 	// Add existing pools to request.
@@ -205,6 +210,9 @@ func (c *cmdAdminRecover) run(cmd *cobra.Command, args []string) error {
 			fmt.Println(i18n.G("The following unknown volumes have been found:"))
 			for _, unknownVol := range res.UnknownVolumes {
 				fmt.Printf(" - "+i18n.G("%s %q on pool %q in project %q (includes %d snapshots)")+"\n", cases.Title(language.English).String(unknownVol.Type), unknownVol.Name, unknownVol.Pool, unknownVol.Project, unknownVol.SnapshotCount)
+				if unknownVol.Unconfirmed {
+					fmt.Println("   " + i18n.G("Configuration was reconstructed from the disk and must be reviewed after recovery"))
+				}
 			}
 		}
 
@@ -240,7 +248,8 @@ func (c *cmdAdminRecover) run(cmd *cobra.Command, args []string) error {
 	// Don't lint next line with staticcheck. It says we should convert reqValidate directly to an RecoverImportPost
 	// because their types are identical. This is less clear and will not work if either type changes in the future.
 	reqImport := recover.ImportPost{ //nolint:staticcheck
-		Pools: reqValidate.Pools,
+		Pools:      reqValidate.Pools,
+		BestEffort: reqValidate.BestEffort,
 	}
 
 	_, _, err = d.RawQuery("POST", "/internal/recover/import", reqImport, "")
@@ -33,7 +33,9 @@ type cmdCopy struct {
 	flagTargetProject       string
 	flagRefresh             bool
 	flagRefreshExcludeOlder bool
+	flagRefreshConfigOnly   bool
 	flagAllowInconsistent   bool
+	flagVerifyOnly          bool
 }
 
 var cmdCopyUsage = u.Usage{u.MakePath(u.Instance, u.Snapshot.Optional()).Remote(), u.NewName(u.Instance).Optional().Remote()}
@@ -69,7 +71,9 @@ The pull transfer mode is the default as it is compatible with all server versio
 	cli.AddBoolFlag(cmd.Flags(), &c.flagNoProfiles, "no-profiles", i18n.G("Create the instance with no profiles applied"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagRefresh, "refresh", i18n.G("Perform an incremental copy"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagRefreshExcludeOlder, "refresh-exclude-older", i18n.G("During incremental copy, exclude source snapshots earlier than latest target snapshot"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagRefreshConfigOnly, "refresh-config-only", i18n.G("During incremental copy, only refresh the config volume of a virtual machine"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagAllowInconsistent, "allow-inconsistent", i18n.G("Ignore copy errors for volatile files"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagVerifyOnly, "verify-only", i18n.G("Only check that the target can accept the instance, without copying it"))
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -110,6 +114,16 @@ func (c *cmdCopy) copyOrMove(cmd *cobra.Command, src *u.Parsed, dst *u.Parsed, k
 		return errors.New(i18n.G("--no-profiles cannot be used with --refresh"))
 	}
 
+	// Refreshing only the config volume requires a refresh of an instance.
+	if c.flagRefreshConfigOnly && (!c.flagRefresh || srcIsSnapshot) {
+		return errors.New(i18n.G("--refresh-config-only can only be used with --refresh on instances"))
+	}
+
+	// Verifying the target is only supported for instances.
+	if c.flagVerifyOnly && srcIsSnapshot {
+		return errors.New(i18n.G("--verify-only cannot be used with snapshots"))
+	}
+
 	// If the instance is being copied to a different remote and no destination name is
 	// specified, use the source name.
 	if !hasDstInstance {
@@ -249,7 +263,9 @@ func (c *cmdCopy) copyOrMove(cmd *cobra.Command, src *u.Parsed, dst *u.Parsed, k
 			Mode:                mode,
 			Refresh:             c.flagRefresh,
 			RefreshExcludeOlder: c.flagRefreshExcludeOlder,
+			RefreshConfigOnly:   c.flagRefreshConfigOnly,
 			AllowInconsistent:   c.flagAllowInconsistent,
+			VerifyOnly:          c.flagVerifyOnly,
 		}
 
 		// Copy of an instance into a new instance
@@ -357,6 +373,11 @@ func (c *cmdCopy) copyOrMove(cmd *cobra.Command, src *u.Parsed, dst *u.Parsed, k
 
 	progress.Done("")
 
+	// Nothing was copied.
+	if c.flagVerifyOnly {
+		return nil
+	}
+
 	if c.flagRefresh {
 		inst, etag, err := dstServer.GetInstance(dstInstanceName)
 		if err != nil {
@@ -440,7 +461,7 @@ func (c *cmdCopy) run(cmd *cobra.Command, args []string) error {
 		mode = c.flagMode
 	}
 
-	stateful := !c.flagStateless && !c.flagRefresh
+	stateful := !c.flagStateless && !c.flagRefresh && !c.flagVerifyOnly
 	keepVolatile := c.flagRefresh
 	instanceOnly := c.flagInstanceOnly
 
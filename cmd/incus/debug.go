@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
 
 	incus "github.com/lxc/incus/v7/client"
 	"github.com/lxc/incus/v7/cmd/incus/color"
@@ -33,6 +34,9 @@ func (c *cmdDebug) command() *cobra.Command {
 	debugNBDCmd := cmdDebugNBD{global: c.global, debug: c}
 	cmd.AddCommand(debugNBDCmd.command())
 
+	debugSnapshotsCmd := cmdDebugSnapshots{global: c.global, debug: c}
+	cmd.AddCommand(debugSnapshotsCmd.command())
+
 	return cmd
 }
 
@@ -92,6 +96,62 @@ func (c *cmdDebugMemory) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+type cmdDebugSnapshots struct {
+	global *cmdGlobal
+	debug  *cmdDebug
+}
+
+var cmdDebugSnapshotsUsage = u.Usage{u.Instance.Remote()}
+
+func (c *cmdDebugSnapshots) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("snapshots", cmdDebugSnapshotsUsage...)
+	cmd.Short = i18n.G("Check the consistency of an instance's snapshots")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`Check the consistency of an instance's snapshots
+
+This compares the instance snapshot records, the storage volume snapshot
+records and the snapshots found on storage.`,
+	))
+
+	cmd.RunE = c.run
+
+	// completion for instance.
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdDebugSnapshots) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdDebugSnapshotsUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	instanceName := parsed[0].RemoteObject.String
+
+	report, err := d.GetInstanceDebugSnapshots(instanceName)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(report, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
 type cmdDebugNBD struct {
 	global *cmdGlobal
 	debug  *cmdDebug
@@ -70,10 +70,12 @@ type cmdSnapshotCreate struct {
 	global   *cmdGlobal
 	snapshot *cmdSnapshot
 
-	flagStateful bool
-	flagNoExpiry bool
-	flagExpiry   string
-	flagReuse    bool
+	flagStateful     bool
+	flagNoExpiry     bool
+	flagExpiry       string
+	flagReuse        bool
+	flagVolumeConfig []string
+	flagNoWait       bool
 }
 
 var cmdSnapshotCreateUsage = u.Usage{u.Instance.Remote(), u.NewName(u.Snapshot).Optional()}
@@ -99,6 +101,8 @@ incus snapshot create u1 snap0 < config.yaml
 	cli.AddStringFlag(cmd.Flags(), &c.flagExpiry, "expiry", "", "", i18n.G("Expiry for the new snapshot (either a time span like `1d 3H` or a date in `2006/01/02 15:04 MST` format)"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagNoExpiry, "no-expiry", i18n.G("Ignore any configured auto-expiry for the instance"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagReuse, "reuse", i18n.G("If the snapshot name already exists, delete and create a new one"))
+	cli.AddStringArrayFlag(cmd.Flags(), &c.flagVolumeConfig, "volume-config", i18n.G("Storage volume config key/value to set on the snapshot's volume"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagNoWait, "no-wait", i18n.G("Fail if another snapshot of the instance is in progress instead of waiting for it"))
 
 	cmd.RunE = c.run
 
@@ -160,6 +164,20 @@ func (c *cmdSnapshotCreate) run(cmd *cobra.Command, args []string) error {
 	req := api.InstanceSnapshotsPost{
 		Name:     snapName,
 		Stateful: c.flagStateful,
+		NoWait:   c.flagNoWait,
+	}
+
+	if len(c.flagVolumeConfig) > 0 {
+		req.VolumeConfig = map[string]string{}
+
+		for _, entry := range c.flagVolumeConfig {
+			key, value, found := strings.Cut(entry, "=")
+			if !found {
+				return fmt.Errorf(i18n.G("Bad key=value pair: %q"), entry)
+			}
+
+			req.VolumeConfig[key] = value
+		}
 	}
 
 	if c.flagNoExpiry {
@@ -514,8 +532,9 @@ type cmdSnapshotRestore struct {
 	global   *cmdGlobal
 	snapshot *cmdSnapshot
 
-	flagStateful bool
-	flagDiskOnly bool
+	flagStateful       bool
+	flagDiskOnly       bool
+	flagSafetySnapshot bool
 }
 
 var cmdSnapshotRestoreUsage = u.Usage{u.Instance.Remote(), u.Snapshot}
@@ -528,7 +547,8 @@ func (c *cmdSnapshotRestore) command() *cobra.Command {
 		`Restore instance from snapshots
 
 If --stateful is passed, then the running state will be restored too.
-If --diskonly is passed, then only the disk will be restored.`,
+If --diskonly is passed, then only the disk will be restored.
+If --safety-snapshot is passed, then a snapshot of the current state is taken first.`,
 	))
 	cmd.Example = cli.FormatSection("", i18n.G(
 		`incus snapshot restore u1 snap0
@@ -537,6 +557,7 @@ If --diskonly is passed, then only the disk will be restored.`,
 
 	cli.AddBoolFlag(cmd.Flags(), &c.flagStateful, "stateful", i18n.G("Whether or not to restore the instance's running state from snapshot (if available)"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagDiskOnly, "diskonly", i18n.G("Whether or not to restore the instance's disk only"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagSafetySnapshot, "safety-snapshot", i18n.G("Snapshot the instance's current state before restoring"))
 
 	cmd.RunE = c.run
 
@@ -566,9 +587,14 @@ func (c *cmdSnapshotRestore) run(cmd *cobra.Command, args []string) error {
 	snapName := parsed[1].String
 
 	req := api.InstancePut{
-		Restore:  instanceName + "/" + snapName,
-		Stateful: c.flagStateful,
-		DiskOnly: c.flagDiskOnly,
+		Restore:               instanceName + "/" + snapName,
+		Stateful:              c.flagStateful,
+		DiskOnly:              c.flagDiskOnly,
+		RestoreSafetySnapshot: c.flagSafetySnapshot,
+	}
+
+	if c.flagSafetySnapshot && !d.HasExtension("snapshot_restore_safety_snapshot") {
+		return errors.New(i18n.G("The server doesn't support pre-restore snapshots"))
 	}
 
 	// Restore the snapshot
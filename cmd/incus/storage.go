@@ -788,6 +788,7 @@ type cmdStorageSet struct {
 	storage *cmdStorage
 
 	flagIsProperty bool
+	flagDryRun     bool
 }
 
 var cmdStorageSetUsage = u.Usage{u.Pool.Remote(), u.LegacyKV.List(1)}
@@ -805,6 +806,7 @@ For backward compatibility, a single configuration key may still be set with:
 
 	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagIsProperty, "property|p", i18n.G("Set the key as a storage property"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagDryRun, "dry-run", i18n.G("Show what the change would do without applying it"))
 	cmd.RunE = c.run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -861,6 +863,22 @@ func (c *cmdStorageSet) set(cmd *cobra.Command, parsed []*u.Parsed) error {
 		maps.Copy(writable.Config, keys)
 	}
 
+	if c.flagDryRun {
+		preview, err := d.PreviewStoragePoolUpdate(poolName, writable)
+		if err != nil {
+			return err
+		}
+
+		data, err := yaml.Dump(preview, yaml.WithV2Defaults())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s", data)
+
+		return nil
+	}
+
 	err = d.UpdateStoragePool(poolName, writable, etag)
 	if err != nil {
 		return err
@@ -884,6 +902,7 @@ type cmdStorageShow struct {
 	storage *cmdStorage
 
 	flagResources bool
+	flagManifest  bool
 }
 
 var cmdStorageShowUsage = u.Usage{u.Pool.Remote()}
@@ -897,6 +916,7 @@ func (c *cmdStorageShow) command() *cobra.Command {
 	))
 
 	cli.AddBoolFlag(cmd.Flags(), &c.flagResources, "resources", i18n.G("Show the resources available to the storage pool"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagManifest, "manifest", i18n.G("Show the instances, volumes, images and buckets stored on the storage pool"))
 	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
 	cmd.RunE = c.run
 
@@ -941,6 +961,22 @@ func (c *cmdStorageShow) run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if c.flagManifest {
+		manifest, err := d.GetStoragePoolManifest(poolName)
+		if err != nil {
+			return err
+		}
+
+		data, err := yaml.Dump(manifest, yaml.WithV2Defaults())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s", data)
+
+		return nil
+	}
+
 	pool, _, err := d.GetStoragePool(poolName)
 	if err != nil {
 		return err
@@ -967,6 +967,7 @@ type cmdStorageBucketKeyCreate struct {
 	flagAccessKey    string
 	flagSecretKey    string
 	flagDescription  string
+	flagPathPrefix   string
 }
 
 var cmdStorageBucketKeyCreateUsage = u.Usage{u.Pool.Remote(), u.Bucket, u.NewName(u.Key)}
@@ -990,6 +991,7 @@ incus storage bucket key create p1 b01 k1 < config.yaml
 	cli.AddStringFlag(cmd.Flags(), &c.flagAccessKey, "access-key", "", "", i18n.G("Access key (auto-generated if empty)"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagSecretKey, "secret-key", "", "", i18n.G("Secret key (auto-generated if empty)"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Key description"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagPathPrefix, "path-prefix", "", "", i18n.G("Restrict the key to objects under this prefix"))
 
 	return cmd
 }
@@ -1045,6 +1047,10 @@ func (c *cmdStorageBucketKeyCreate) runAdd(cmd *cobra.Command, args []string) er
 		req.Description = c.flagDescription
 	}
 
+	if c.flagPathPrefix != "" {
+		req.PathPrefix = c.flagPathPrefix
+	}
+
 	key, err := d.CreateStoragePoolBucketKey(poolName, bucketName, req)
 	if err != nil {
 		return err
@@ -377,6 +377,7 @@ type cmdStorageVolumeCopy struct {
 	flagTargetProject       string
 	flagRefresh             bool
 	flagRefreshExcludeOlder bool
+	flagVerify              bool
 }
 
 var cmdStorageVolumeCopyUsage = u.Usage{u.MakePath(u.Pool, u.Volume, u.Snapshot.Optional()).Remote(), u.MakePath(u.Pool, u.NewName(u.Volume)).Remote()}
@@ -395,6 +396,7 @@ func (c *cmdStorageVolumeCopy) command() *cobra.Command {
 	cli.AddStringFlag(cmd.Flags(), &c.flagTargetProject, "target-project", "", "", i18n.G("Copy to a project different from the source"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagRefresh, "refresh", i18n.G("Refresh and update the existing storage volume copies"))
 	cli.AddBoolFlag(cmd.Flags(), &c.flagRefreshExcludeOlder, "refresh-exclude-older", i18n.G("During refresh, exclude source snapshots earlier than latest target snapshot"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagVerify, "verify", i18n.G("Verify the content of the copy against the source (copies between pools only)"))
 	cmd.RunE = c.run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -521,6 +523,7 @@ func (c *cmdStorageVolumeCopy) copyOrMove(cmd *cobra.Command, parsed []*u.Parsed
 		args.VolumeOnly = c.flagVolumeOnly
 		args.Refresh = c.flagRefresh
 		args.RefreshExcludeOlder = c.flagRefreshExcludeOlder
+		args.Verify = c.flagVerify
 
 		if c.flagTargetProject != "" {
 			dstServer = dstServer.UseProject(c.flagTargetProject)
@@ -580,6 +583,7 @@ type cmdStorageVolumeCreate struct {
 	storageVolume   *cmdStorageVolume
 	flagContentType string
 	flagDescription string
+	flagTemplate    string
 }
 
 var cmdStorageVolumeCreateUsage = u.Usage{u.Pool.Remote(), u.NewName(u.Volume), u.KV.List(0)}
@@ -594,11 +598,15 @@ func (c *cmdStorageVolumeCreate) command() *cobra.Command {
     Create custom storage volume "foo" in pool "default"
 
 incus storage volume create default foo < config.yaml
-    Create custom storage volume "foo" in pool "default" with configuration from config.yaml`))
+    Create custom storage volume "foo" in pool "default" with configuration from config.yaml
+
+incus storage volume create default foo --template database
+    Create custom storage volume "foo" in pool "default" using the pool's "database" volume template`))
 
 	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagContentType, "type|t", "filesystem", "", i18n.G("Content type, block or filesystem"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Volume description"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagTemplate, "template", "", "", i18n.G("Storage pool volume template to use as the base config"))
 
 	cmd.RunE = c.run
 
@@ -645,6 +653,7 @@ func (c *cmdStorageVolumeCreate) run(cmd *cobra.Command, args []string) error {
 		Name:             volName,
 		Type:             "custom",
 		ContentType:      c.flagContentType,
+		Template:         c.flagTemplate,
 		StorageVolumePut: volumePut,
 	}
 
@@ -680,6 +689,8 @@ type cmdStorageVolumeDelete struct {
 	global        *cmdGlobal
 	storage       *cmdStorage
 	storageVolume *cmdStorageVolume
+
+	flagForce bool
 }
 
 var cmdStorageVolumeDeleteUsage = u.Usage{u.Pool.Remote(), u.MakePath(u.StorageVolumeType.Optional(), u.Volume)}
@@ -692,6 +703,7 @@ func (c *cmdStorageVolumeDelete) command() *cobra.Command {
 	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Delete custom storage volumes`))
 
 	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagForce, "force|f", i18n.G("Force delete image volumes that still have volumes cloned from them"))
 	cmd.RunE = c.run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -726,7 +738,12 @@ func (c *cmdStorageVolumeDelete) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Delete the volume
-	err = d.DeleteStoragePoolVolume(poolName, volType, volName)
+	if c.flagForce {
+		err = d.DeleteStoragePoolVolumeForce(poolName, volType, volName)
+	} else {
+		err = d.DeleteStoragePoolVolume(poolName, volType, volName)
+	}
+
 	if err != nil {
 		return err
 	}
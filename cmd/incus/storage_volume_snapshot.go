@@ -77,6 +77,7 @@ type cmdStorageVolumeSnapshotCreate struct {
 	flagExpiry      string
 	flagReuse       bool
 	flagDescription string
+	flagQuiesce     bool
 }
 
 var cmdStorageVolumeSnapshotCreateUsage = u.Usage{u.Pool.Remote(), u.Volume, u.NewName(u.Snapshot).Optional()}
@@ -98,6 +99,7 @@ incus storage volume snapshot create default vol1 snap0 < config.yaml
 	cli.AddBoolFlag(cmd.Flags(), &c.flagReuse, "reuse", i18n.G("If the snapshot name already exists, delete and create a new one"))
 	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Snapshot description"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagQuiesce, "quiesce", i18n.G("Sync the volume and freeze the instance using it if needed before the snapshot"))
 
 	cmd.RunE = c.run
 
@@ -158,7 +160,8 @@ func (c *cmdStorageVolumeSnapshotCreate) run(cmd *cobra.Command, args []string)
 	}
 
 	req := api.StorageVolumeSnapshotsPost{
-		Name: snapName,
+		Name:    snapName,
+		Quiesce: c.flagQuiesce,
 	}
 
 	if c.flagNoExpiry {
@@ -541,6 +544,8 @@ type cmdStorageVolumeSnapshotRestore struct {
 	storage               *cmdStorage
 	storageVolume         *cmdStorageVolume
 	storageVolumeSnapshot *cmdStorageVolumeSnapshot
+
+	flagSafetySnapshot bool
 }
 
 var cmdStorageVolumeSnapshotRestoreUsage = u.Usage{u.Pool.Remote(), u.Volume, u.Snapshot}
@@ -551,6 +556,7 @@ func (c *cmdStorageVolumeSnapshotRestore) command() *cobra.Command {
 	cmd.Short = i18n.G("Restore storage volume snapshots")
 	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Restore storage volume snapshots`))
 	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagSafetySnapshot, "safety-snapshot", i18n.G("Snapshot the volume's current state before restoring"))
 
 	cmd.RunE = c.run
 
@@ -595,7 +601,11 @@ func (c *cmdStorageVolumeSnapshotRestore) run(cmd *cobra.Command, args []string)
 		return err
 	}
 
-	return d.UpdateStoragePoolVolume(poolName, "custom", volName, api.StorageVolumePut{Restore: snapName}, etag)
+	if c.flagSafetySnapshot && !d.HasExtension("snapshot_restore_safety_snapshot") {
+		return errors.New(i18n.G("The server doesn't support pre-restore snapshots"))
+	}
+
+	return d.UpdateStoragePoolVolume(poolName, "custom", volName, api.StorageVolumePut{Restore: snapName, RestoreSafetySnapshot: c.flagSafetySnapshot}, etag)
 }
 
 // Snapshot show.
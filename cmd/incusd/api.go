@@ -364,9 +364,10 @@ func serveLocalBucket(d *Daemon, w http.ResponseWriter, r *http.Request, bucket
 	creds := make([]local.Credential, 0, len(keys))
 	for _, k := range keys {
 		creds = append(creds, local.Credential{
-			AccessKey: k.AccessKey,
-			SecretKey: k.SecretKey,
-			Role:      local.Role(k.Role),
+			AccessKey:  k.AccessKey,
+			SecretKey:  k.SecretKey,
+			Role:       local.Role(k.Role),
+			PathPrefix: k.PathPrefix,
 		})
 	}
 
@@ -76,6 +76,7 @@ var api10 = []APIEndpoint{
 	instanceAccessCmd,
 	instanceDebugMemoryCmd,
 	instanceDebugRepairCmd,
+	instanceDebugSnapshotsCmd,
 	eventsCmd,
 	imageAliasCmd,
 	imageAliasesCmd,
@@ -116,6 +117,8 @@ var api10 = []APIEndpoint{
 	projectStateCmd,
 	projectAccessCmd,
 	storagePoolCmd,
+	storagePoolManifestCmd,
+	storagePoolPreviewCmd,
 	storagePoolResourcesCmd,
 	storagePoolsCmd,
 	storagePoolBucketsCmd,
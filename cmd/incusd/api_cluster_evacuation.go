@@ -889,7 +889,7 @@ func healClusterMember(d *Daemon, op *operations.Operation, name string) error {
 		}
 
 		// Ignore anything using a local storage pool.
-		if !pool.Driver().Info().Remote {
+		if !pool.Capabilities().Remote {
 			return nil
 		}
 
@@ -774,7 +774,7 @@ func internalImportFromBackup(ctx context.Context, s *state.State, projectName s
 	for _, volType := range []storageDrivers.VolumeType{storageDrivers.VolumeTypeVM, storageDrivers.VolumeTypeContainer} {
 		for _, poolName := range storagePoolNames {
 			volStorageName := project.Instance(projectName, instName)
-			instanceMntPoint := storageDrivers.GetVolumeMountPath(poolName, volType, volStorageName)
+			instanceMntPoint := storageDrivers.GetVolumeMountPath(poolName, nil, volType, volStorageName)
 
 			if util.PathExists(instanceMntPoint) {
 				instanceMountPoints = append(instanceMountPoints, instanceMntPoint)
@@ -1073,9 +1073,9 @@ func internalImportFromBackup(ctx context.Context, s *state.State, projectName s
 
 		// Recreate missing mountpoints and symlinks.
 		volStorageName := project.Instance(projectName, snapInstName)
-		snapshotMountPoint := storageDrivers.GetVolumeMountPath(instancePoolName, instanceVolType, volStorageName)
+		snapshotMountPoint := storageDrivers.GetVolumeMountPath(instancePoolName, pool.Driver().Config(), instanceVolType, volStorageName)
 		snapshotPath := storagePools.InstancePath(instanceType, projectName, backupConf.Container.Name, true)
-		snapshotTargetPath := storageDrivers.GetVolumeSnapshotDir(instancePoolName, instanceVolType, volStorageName)
+		snapshotTargetPath := storageDrivers.GetVolumeSnapshotDir(instancePoolName, pool.Driver().Config(), instanceVolType, volStorageName)
 
 		err = storagePools.CreateSnapshotMountpoint(snapshotMountPoint, snapshotTargetPath, snapshotPath)
 		if err != nil {
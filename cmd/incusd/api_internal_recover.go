@@ -27,6 +27,7 @@ import (
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/osarch"
 	"github.com/lxc/incus/v7/shared/revert"
+	"github.com/lxc/incus/v7/shared/util"
 )
 
 // Define API endpoints for recover actions.
@@ -48,7 +49,7 @@ func init() {
 }
 
 // internalRecoverScan provides the discovery and import functionality for both recovery validate and import steps.
-func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.StoragePoolsPost, validateOnly bool) response.Response {
+func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.StoragePoolsPost, validateOnly bool, bestEffort bool) response.Response {
 	var err error
 	var projects map[string]*api.Project
 	var projectProfiles map[string][]*api.Profile
@@ -209,7 +210,7 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 		}
 
 		// Get list of unknown volumes on pool.
-		poolProjectVols, err := pool.ListUnknownVolumes(nil)
+		poolProjectVols, err := pool.ListUnknownVolumes(bestEffort, nil)
 		if err != nil {
 			if errors.Is(err, storageDrivers.ErrNotSupported) {
 				continue // Ignore unsupported storage drivers.
@@ -313,6 +314,7 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 						Type:          displayType,
 						Name:          displayName,
 						SnapshotCount: displaySnapshotCount,
+						Unconfirmed:   poolVol.Container != nil && util.IsTrue(poolVol.Container.Config["user.recovery.unconfirmed"]),
 					})
 				}
 			}
@@ -391,6 +393,14 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 
 	// Recover the storage volumes and buckets.
 	for _, pool := range pools {
+		// Batch the volume records of the pool, as nothing else uses the recovered volumes yet.
+		err = pool.BeginMaintenanceSession(true)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		reverter.Add(pool.AbortMaintenanceSession)
+
 		for projectName, poolVols := range poolsProjectVols[pool.Name()] {
 			projectInfo := projects[projectName]
 
@@ -435,6 +445,11 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 				reverter.Add(cleanup)
 			}
 		}
+
+		err = pool.CommitMaintenanceSession()
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed recovering volumes of storage pool %q: %w", pool.Name(), err))
+		}
 	}
 
 	// Finally restore the instances.
@@ -614,7 +629,7 @@ func internalRecoverValidate(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	return internalRecoverScan(r.Context(), d.State(), req.Pools, true)
+	return internalRecoverScan(r.Context(), d.State(), req.Pools, true, req.BestEffort)
 }
 
 // internalRecoverImport performs the pool volume recovery.
@@ -626,5 +641,5 @@ func internalRecoverImport(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	return internalRecoverScan(r.Context(), d.State(), req.Pools, false)
+	return internalRecoverScan(r.Context(), d.State(), req.Pools, false, req.BestEffort)
 }
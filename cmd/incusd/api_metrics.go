@@ -25,6 +25,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/request"
 	"github.com/lxc/incus/v7/internal/server/response"
 	"github.com/lxc/incus/v7/internal/server/state"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
 	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/logger"
 )
@@ -447,6 +448,16 @@ func internalMetrics(ctx context.Context, s *state.State, tx *db.ClusterTx) *met
 		}
 	}
 
+	// Image volume creation outcomes.
+	for poolName, outcomes := range storagePools.ImageCreateCounts() {
+		for outcome, count := range outcomes {
+			out.AddSamples(metrics.StorageImageCreatesTotal, metrics.Sample{
+				Labels: map[string]string{"pool": poolName, "outcome": outcome},
+				Value:  float64(count),
+			})
+		}
+	}
+
 	// Daemon uptime
 	out.AddSamples(metrics.UptimeSeconds, metrics.Sample{Value: time.Since(s.StartTime).Seconds()})
 
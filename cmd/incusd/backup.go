@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"time"
 
@@ -50,8 +51,12 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Failed loading instance storage pool: %w", err)
 	}
 
-	// Ignore requests for optimized backups when pool driver doesn't support it.
+	// Ignore requests for optimized backups when pool driver doesn't support it, unless strictly requested.
 	if args.OptimizedStorage && !pool.Driver().Info().OptimizedBackups {
+		if args.OptimizedStorageStrict {
+			return api.StatusErrorf(http.StatusBadRequest, "Storage driver %q of pool %q doesn't support optimized backups", pool.Driver().Info().Name, pool.Name())
+		}
+
 		args.OptimizedStorage = false
 	}
 
@@ -207,7 +212,7 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Error writing backup index file: %w", err)
 	}
 
-	err = pool.BackupInstance(sourceInst, tarWriter, b.OptimizedStorage(), !b.InstanceOnly(), !b.RootOnly(), nil)
+	err = pool.BackupInstance(sourceInst, tarWriter, b.OptimizedStorage(), args.OptimizedStorageStrict, !b.InstanceOnly(), !b.RootOnly(), false, nil)
 	if err != nil {
 		return fmt.Errorf("Backup create: %w", err)
 	}
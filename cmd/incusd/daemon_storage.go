@@ -248,7 +248,7 @@ func daemonStorageValidate(s *state.State, storageType string, target string) er
 
 	// Validate volume is empty (ignore lost+found).
 	volStorageName := project.StorageVolume(api.ProjectDefaultName, volumeName)
-	mountpoint := storageDrivers.GetVolumeMountPath(poolName, storageDrivers.VolumeTypeCustom, volStorageName)
+	mountpoint := storageDrivers.GetVolumeMountPath(poolName, nil, storageDrivers.VolumeTypeCustom, volStorageName)
 
 	entries, err := os.ReadDir(mountpoint)
 	if err != nil {
@@ -422,7 +422,7 @@ func daemonStorageMove(s *state.State, storageType string, target string) error
 
 	// Set ownership & mode.
 	volStorageName := project.StorageVolume(api.ProjectDefaultName, volumeName)
-	mountpoint := storageDrivers.GetVolumeMountPath(poolName, storageDrivers.VolumeTypeCustom, volStorageName)
+	mountpoint := storageDrivers.GetVolumeMountPath(poolName, nil, storageDrivers.VolumeTypeCustom, volStorageName)
 	destPath = mountpoint
 
 	err = os.Chmod(mountpoint, 0o700)
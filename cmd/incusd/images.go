@@ -1028,7 +1028,7 @@ func imageCreateInPool(s *state.State, info *api.Image, storagePool string) erro
 		return err
 	}
 
-	err = pool.EnsureImage(info.Fingerprint, nil)
+	_, err = pool.EnsureImage(info.Fingerprint, nil)
 	if err != nil {
 		return err
 	}
@@ -2395,7 +2395,7 @@ func autoUpdateImage(ctx context.Context, s *state.State, op *operations.Operati
 				continue
 			}
 
-			err = pool.DeleteImage(fingerprint, op)
+			err = pool.DeleteImage(fingerprint, true, op)
 			if err != nil {
 				logger.Error("Error deleting image from storage pool", logger.Ctx{"err": err, "pool": pool.Name(), "fingerprint": fingerprint})
 				continue
@@ -2731,7 +2731,7 @@ func pruneExpiredImages(ctx context.Context, s *state.State, op *operations.Oper
 				return fmt.Errorf("Error loading storage pool %q to delete image volume %q: %w", poolName, fingerprint, err)
 			}
 
-			err = pool.DeleteImage(fingerprint, op)
+			err = pool.DeleteImage(fingerprint, true, op)
 			if err != nil {
 				return fmt.Errorf("Error deleting image volume %q from storage pool %q: %w", fingerprint, pool.Name(), err)
 			}
@@ -2937,8 +2937,8 @@ func imageDelete(d *Daemon, r *http.Request) response.Response {
 			}
 
 			// Only perform the deletion of remote volumes on the server handling the request.
-			if !isClusterNotification(r) || !pool.Driver().Info().Remote {
-				err = pool.DeleteImage(imgInfo.Fingerprint, op)
+			if !isClusterNotification(r) || !pool.Capabilities().Remote {
+				err = pool.DeleteImage(imgInfo.Fingerprint, true, op)
 				if err != nil {
 					return fmt.Errorf("Error deleting image %q from storage pool %q: %w", imgInfo.Fingerprint, pool.Name(), err)
 				}
@@ -193,7 +193,7 @@ func instanceCreateFromImage(ctx context.Context, s *state.State, img *api.Image
 
 	defer unlock()
 
-	err = pool.CreateInstanceFromImage(inst, img.Fingerprint, op)
+	err = pool.CreateInstanceFromImage(inst, img.Fingerprint, false, op)
 	if err != nil {
 		return fmt.Errorf("Failed creating instance from image: %w", err)
 	}
@@ -319,6 +319,7 @@ type instanceCreateAsCopyOpts struct {
 	instanceOnly         bool              // Only copy the instance and not it's snapshots.
 	refresh              bool              // Refresh an existing target instance.
 	refreshExcludeOlder  bool              // During refresh, exclude source snapshots earlier than latest target snapshot
+	refreshConfigOnly    bool              // During refresh, only refresh the config volume of a VM.
 	applyTemplateTrigger bool              // Apply deferred TemplateTriggerCopy.
 	allowInconsistent    bool              // Ignore some copy errors
 }
@@ -368,7 +369,7 @@ func instanceCreateAsCopy(s *state.State, opts instanceCreateAsCopyOpts, op *ope
 
 	var snapshots []instance.Instance
 
-	if !opts.instanceOnly {
+	if !opts.instanceOnly && !(opts.refresh && opts.refreshConfigOnly) {
 		if opts.refresh {
 			// Compare snapshots.
 			sourceSnaps, err := opts.sourceInstance.Snapshots()
@@ -503,7 +504,7 @@ func instanceCreateAsCopy(s *state.State, opts instanceCreateAsCopyOpts, op *ope
 	}
 
 	if opts.refresh {
-		err = pool.RefreshInstance(inst, opts.sourceInstance, snapshots, opts.allowInconsistent, op)
+		err = pool.RefreshInstance(inst, opts.sourceInstance, snapshots, opts.allowInconsistent, opts.refreshConfigOnly, op)
 		if err != nil {
 			return nil, fmt.Errorf("Refresh instance: %w", err)
 		}
@@ -584,7 +585,7 @@ func autoCreateInstanceSnapshots(ctx context.Context, s *state.State, instances
 			return err
 		}
 
-		err = inst.Snapshot(snapshotName, expiry, false)
+		err = inst.Snapshot(snapshotName, expiry, false, instance.SnapshotArgs{})
 		if err != nil {
 			l.Error("Error creating snapshot", logger.Ctx{"snapshot": snapshotName, "err": err})
 			return err
@@ -358,13 +358,14 @@ func instanceBackupsPost(d *Daemon, r *http.Request) response.Response {
 
 	backup := func(op *operations.Operation) error {
 		args := db.InstanceBackup{
-			Name:                 fullName,
-			InstanceID:           inst.ID(),
-			CreationDate:         time.Now(),
-			InstanceOnly:         req.InstanceOnly,
-			RootOnly:             req.RootOnly,
-			OptimizedStorage:     req.OptimizedStorage,
-			CompressionAlgorithm: req.CompressionAlgorithm,
+			Name:                   fullName,
+			InstanceID:             inst.ID(),
+			CreationDate:           time.Now(),
+			InstanceOnly:           req.InstanceOnly,
+			RootOnly:               req.RootOnly,
+			OptimizedStorage:       req.OptimizedStorage,
+			OptimizedStorageStrict: req.OptimizedStorageStrict,
+			CompressionAlgorithm:   req.CompressionAlgorithm,
 		}
 
 		if !direct && req.Target == nil {
@@ -267,6 +267,11 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		req.Live = false
 	}
 
+	// Verifying the target is only possible when sending the instance to another server.
+	if req.VerifyOnly && (req.Live || req.Pool != "" || req.Project != "" || target != "") {
+		return response.BadRequest(errors.New("Verify only is only supported for non-live migrations to another server"))
+	}
+
 	// Check for offline sources.
 	if sourceMemberInfo != nil && sourceMemberInfo.IsOffline(s.GlobalConfig.OfflineThreshold()) && (req.Pool != "" || req.Project != "" || req.Name != "") {
 		return response.BadRequest(errors.New("Instance server is currently offline"))
@@ -512,6 +517,8 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
+	ws.verifyOnly = req.VerifyOnly
+
 	resources := map[string][]api.URL{}
 	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
 	run := func(op *operations.Operation) error {
@@ -573,7 +580,7 @@ func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance
 	}
 
 	// Handle migration of an instance away from an offline server (on shared storage).
-	if targetMemberInfo != nil && sourceMemberInfo != nil && sourceMemberInfo.IsOffline(s.GlobalConfig.OfflineThreshold()) && sourcePool.Driver().Info().Remote {
+	if targetMemberInfo != nil && sourceMemberInfo != nil && sourceMemberInfo.IsOffline(s.GlobalConfig.OfflineThreshold()) && sourcePool.Capabilities().Remote {
 		// Update the database records.
 		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 			err := tx.UpdateInstanceNode(ctx, inst.Project().Name, inst.Name(), inst.Name(), targetMemberInfo.Name, sourcePool.ID(), volDBType)
@@ -1043,7 +1050,7 @@ func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance
 
 		// Cleanup instance paths on source member if using remote shared storage
 		// and there was no storage pool change.
-		if sourcePool.Driver().Info().Remote && req.Pool == "" {
+		if sourcePool.Capabilities().Remote && req.Pool == "" {
 			err = sourcePool.CleanupInstancePaths(inst, nil)
 			if err != nil {
 				return fmt.Errorf("Failed cleaning up instance paths on source member: %w", err)
@@ -1063,7 +1070,7 @@ func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance
 				}
 			}
 
-			err = sourcePool.DeleteInstance(inst, nil)
+			err = sourcePool.DeleteInstance(inst, false, nil)
 			if err != nil {
 				return fmt.Errorf("Failed deleting instance on source member: %w", err)
 			}
@@ -1095,7 +1102,7 @@ func cleanupDependentDisks(s *state.State, inst instance.Instance, deviceOverrid
 		}
 
 		// Volumes on remote pools cannot be removed.
-		if diskPool.Driver().Info().Remote {
+		if diskPool.Capabilities().Remote {
 			return nil
 		}
 
@@ -194,7 +194,7 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 		do = func(op *operations.Operation) error {
 			defer unlock()
 
-			return instanceSnapRestore(s, projectName, name, configRaw.Restore, configRaw.Stateful, configRaw.DiskOnly, op)
+			return instanceSnapRestore(s, projectName, name, configRaw.Restore, configRaw.Stateful, configRaw.DiskOnly, configRaw.RestoreSafetySnapshot, op)
 		}
 
 		opType = operationtype.SnapshotRestore
@@ -212,7 +212,7 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 	return operations.OperationResponse(op)
 }
 
-func instanceSnapRestore(s *state.State, projectName string, name string, snap string, stateful bool, diskOnly bool, op *operations.Operation) error {
+func instanceSnapRestore(s *state.State, projectName string, name string, snap string, stateful bool, diskOnly bool, safetySnapshot bool, op *operations.Operation) error {
 	// normalize snapshot name
 	if !internalInstance.IsSnapshot(snap) {
 		snap = name + internalInstance.SnapshotDelimiter + snap
@@ -240,7 +240,7 @@ func instanceSnapRestore(s *state.State, projectName string, name string, snap s
 	// Generate a new `volatile.uuid.generation` to differentiate this instance restored from a snapshot from the original instance.
 	source.LocalConfig()["volatile.uuid.generation"] = uuid.New().String()
 
-	err = inst.Restore(source, stateful, diskOnly)
+	err = inst.Restore(source, stateful, diskOnly, safetySnapshot)
 	if err != nil {
 		return err
 	}
@@ -338,7 +338,7 @@ func instanceSnapshotsPost(d *Daemon, r *http.Request) response.Response {
 
 	snapshot := func(op *operations.Operation) error {
 		inst.SetOperation(op)
-		return inst.Snapshot(req.Name, expiry, req.Stateful)
+		return inst.Snapshot(req.Name, expiry, req.Stateful, instance.SnapshotArgs{Force: req.Force, ConfigOverrides: req.VolumeConfig, NoWait: req.NoWait})
 	}
 
 	resources := map[string][]api.URL{}
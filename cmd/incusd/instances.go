@@ -188,6 +188,13 @@ var instanceDebugMemoryCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: instanceDebugMemoryGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
 }
 
+var instanceDebugSnapshotsCmd = APIEndpoint{
+	Name: "instanceDebugSnapshots",
+	Path: "instances/{name}/debug/snapshots",
+
+	Get: APIEndpointAction{Handler: instanceDebugSnapshotsGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+}
+
 var instanceDebugRepairCmd = APIEndpoint{
 	Name: "instanceDebugRepair",
 	Path: "instances/{name}/debug/repair",
@@ -149,7 +149,12 @@ func createFromImage(s *state.State, r *http.Request, p api.Project, profiles []
 	resources := map[string][]api.URL{}
 	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", req.Name)}
 
-	op, err := operations.OperationCreate(s, p.Name, operations.OperationClassTask, operationtype.InstanceCreate, resources, nil, run, nil, nil, r)
+	// Cancelling the operation cancels its context, which aborts the image unpack.
+	onCancel := func(op *operations.Operation) error {
+		return nil
+	}
+
+	op, err := operations.OperationCreate(s, p.Name, operations.OperationClassTask, operationtype.InstanceCreate, resources, nil, run, onCancel, nil, r)
 	if err != nil {
 		return response.InternalError(err)
 	}
@@ -313,6 +318,10 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 		}
 	}
 
+	if req.Source.VerifyOnly && (clusterMoveSourceName != "" || req.Source.Live) {
+		return response.BadRequest(errors.New("Verify only is only supported for non-live migrations from another server"))
+	}
+
 	// Refuse to migrate onto an existing instance of a different type.
 	if inst != nil && inst.Type() != dbType {
 		return response.Conflict(fmt.Errorf("Instance %q already exists with a different type", req.Name))
@@ -396,6 +405,7 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 		ClusterMoveSourceName: clusterMoveSourceName,
 		Refresh:               req.Source.Refresh,
 		RefreshExcludeOlder:   req.Source.RefreshExcludeOlder,
+		VerifyOnly:            req.Source.VerifyOnly,
 		StoragePool:           storagePool,
 	}
 
@@ -431,6 +441,11 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 
 		instOp.Done(nil) // Complete operation that was created earlier, to release lock.
 
+		// Nothing was received, so remove the instance record created for the verification.
+		if req.Source.VerifyOnly {
+			return nil
+		}
+
 		if migrationArgs.StoragePool != "" || req.Devices != nil {
 			// Update root device for the instance if needed.
 			updateNeeded := false
@@ -605,6 +620,10 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 		return response.BadRequest(errors.New("Must specify a source instance"))
 	}
 
+	if req.Source.RefreshConfigOnly && !req.Source.Refresh {
+		return response.BadRequest(errors.New("Config only refreshes require refresh to be set"))
+	}
+
 	sourceProject := req.Source.Project
 	if sourceProject == "" {
 		sourceProject = projectName
@@ -632,6 +651,11 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 		serverName := s.ServerName
 
 		if serverName != source.Location() {
+			// Config only refreshes are done locally by the storage pool.
+			if req.Source.RefreshConfigOnly {
+				return response.BadRequest(errors.New("Config only refreshes require the source instance to be on the same cluster member"))
+			}
+
 			// Check if we are copying from a remote storage instance.
 			_, rootDevice, _ := internalInstance.GetRootDiskDevice(source.ExpandedDevices().CloneNative())
 			sourcePoolName := rootDevice["pool"]
@@ -749,6 +773,7 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 			instanceOnly:         req.Source.InstanceOnly,
 			refresh:              req.Source.Refresh,
 			refreshExcludeOlder:  req.Source.RefreshExcludeOlder,
+			refreshConfigOnly:    req.Source.RefreshConfigOnly,
 			applyTemplateTrigger: true,
 			allowInconsistent:    req.Source.AllowInconsistent,
 		}, op)
@@ -978,9 +1003,10 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 			return err
 		}
 
-		// Check if the backup is optimized that the source pool driver matches the target pool driver.
-		if *bInfo.OptimizedStorage && pool.Driver().Info().Name != bInfo.Backend {
-			return fmt.Errorf("Optimized backup storage driver %q differs from the target storage pool driver %q", bInfo.Backend, pool.Driver().Info().Name)
+		// Fall back to the generic data of optimized backups made by a different driver.
+		err = bInfo.AdaptToDriver(pool.Driver().Info().Name, backupFile, s.OS, backupFile.Name(), backup.DefaultBackupPrefix)
+		if err != nil {
+			return err
 		}
 
 		// Dump tarball to storage. Because the backup file is unpacked and restored onto the storage
@@ -1502,6 +1528,10 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		return operations.ForwardedOperationResponse(&opAPI)
 	}
 
+	if req.Source.VerifyOnly && req.Source.Type != "migration" {
+		return response.BadRequest(errors.New("Verify only is only supported for migrations"))
+	}
+
 	switch req.Source.Type {
 	case "image":
 		return createFromImage(s, r, *targetProject, profiles, sourceImage, sourceImageRef, &req)
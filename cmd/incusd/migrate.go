@@ -33,6 +33,7 @@ type migrationFields struct {
 	// container specific fields
 	live         bool
 	instanceOnly bool
+	verifyOnly   bool
 	instance     instance.Instance
 
 	// storage specific fields
@@ -235,6 +236,7 @@ type migrationSinkArgs struct {
 	Live                  bool
 	Refresh               bool
 	RefreshExcludeOlder   bool
+	VerifyOnly            bool
 	ClusterMoveSourceName string
 	Snapshots             []*migration.Snapshot
 
@@ -147,6 +147,7 @@ func (s *migrationSourceWs) do(migrateOp *operations.Operation) error {
 			},
 			ClusterMoveSourceName: s.clusterMoveSourceName,
 			StoragePool:           s.storagePool,
+			VerifyOnly:            s.verifyOnly,
 		},
 		AllowInconsistent: s.allowInconsistent,
 		Devices:           s.devices,
@@ -168,6 +169,7 @@ func newMigrationSink(args *migrationSinkArgs) (*migrationSink, error) {
 			instance:     args.Instance,
 			instanceOnly: args.InstanceOnly,
 			live:         args.Live,
+			verifyOnly:   args.VerifyOnly,
 			storagePool:  args.StoragePool,
 		},
 		url:                   args.URL,
@@ -281,6 +283,7 @@ func (c *migrationSink) do(instOp *operationlock.InstanceOperation) error {
 			},
 			ClusterMoveSourceName: c.clusterMoveSourceName,
 			StoragePool:           c.storagePool,
+			VerifyOnly:            c.verifyOnly,
 		},
 		InstanceOperation:   instOp,
 		Refresh:             c.refresh,
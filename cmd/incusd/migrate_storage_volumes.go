@@ -151,6 +151,10 @@ func (s *migrationSourceWs) DoStorage(st *state.State, projectName string, poolN
 	offerHeader.IndexHeaderVersion = &indexHeaderVersion
 	offerHeader.VolumeSize = &volSize
 
+	// Offer to skip the zero regions of block volumes.
+	sparseBlocks := true
+	offerHeader.SparseBlocks = &sparseBlocks
+
 	// Only send snapshots when requested.
 	if !s.volumeOnly {
 		offerHeader.Snapshots = make([]*migration.Snapshot, 0, len(srcConfig.VolumeSnapshots))
@@ -203,6 +207,7 @@ func (s *migrationSourceWs) DoStorage(st *state.State, projectName string, poolN
 		ContentType:        srcConfig.Volume.ContentType,
 		Info:               &localMigration.Info{Config: srcConfig},
 		VolumeOnly:         s.volumeOnly,
+		SparseBlocks:       respHeader.GetSparseBlocks(),
 	}
 
 	// Only send the snapshots that the target requests when refreshing.
@@ -362,6 +367,10 @@ func (c *migrationSink) DoStorage(st *state.State, projectName string, poolName
 	respHeader.Refresh = &c.refresh
 	respHeader.VolumeSize = offerHeader.VolumeSize
 
+	// Accept skipping the zero regions of block volumes if offered.
+	sparseBlocks := offerHeader.GetSparseBlocks()
+	respHeader.SparseBlocks = &sparseBlocks
+
 	// Translate the legacy MigrationSinkArgs to a VolumeTargetArgs suitable for use
 	// with the new storage layer.
 	myTarget = func(conn io.ReadWriteCloser, op *operations.Operation, args migrationSinkArgs) error {
@@ -377,6 +386,7 @@ func (c *migrationSink) DoStorage(st *state.State, projectName string, poolName
 			RefreshExcludeOlder: args.RefreshExcludeOlder,
 			VolumeSize:          args.VolumeSize,
 			VolumeOnly:          args.VolumeOnly,
+			SparseBlocks:        sparseBlocks,
 		}
 
 		// A zero length Snapshots slice indicates volume only migration in
@@ -865,7 +865,7 @@ func patchStorageRenameCustomISOBlockVolumes(_ string, d *Daemon) error {
 		}
 
 		// Ensure the renaming is done only on the cluster leader for remote storage pools.
-		if p.Driver().Info().Remote && !isLeader {
+		if p.Capabilities().Remote && !isLeader {
 			continue
 		}
 
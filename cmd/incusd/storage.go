@@ -97,6 +97,12 @@ func storageStartup(s *state.State) error {
 			return false
 		}
 
+		// Nothing can be using a writable snapshot clone yet, so remove any left behind.
+		err = pool.CleanupSnapshotWritableClones()
+		if err != nil {
+			logger.Warn("Failed cleaning up writable snapshot clones", logger.Ctx{"pool": poolName, "err": err})
+		}
+
 		logger.Info("Initialized storage pool", logger.Ctx{"pool": poolName})
 		_ = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningtype.StoragePoolUnvailable, cluster.TypeStoragePool, int(pool.ID()))
 
@@ -317,7 +317,7 @@ func storagePoolBucketsGet(d *Daemon, r *http.Request) response.Response {
 
 	for _, bucket := range dbBuckets {
 		var location string
-		if s.ServerClustered && !pool.Driver().Info().Remote {
+		if s.ServerClustered && !pool.Capabilities().Remote {
 			location = bucket.Location
 		}
 
@@ -515,7 +515,7 @@ func storagePoolBucketGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
 	}
 
-	if !pool.Driver().Info().Buckets {
+	if !pool.Capabilities().Buckets {
 		return response.BadRequest(errors.New("Storage pool does not support buckets"))
 	}
 
@@ -677,6 +677,11 @@ func storagePoolBucketsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Invalid storage bucket name: %w", err))
 	}
 
+	// Local buckets are only reachable through the storage buckets listener.
+	if !pool.Capabilities().Remote && s.Endpoints.StorageBucketsAddress() == "" {
+		return response.BadRequest(errors.New("Storage buckets listener isn't configured, set core.storage_buckets_address first"))
+	}
+
 	reverter := revert.New()
 	defer reverter.Fail()
 
@@ -702,7 +707,7 @@ func storagePoolBucketsPost(d *Daemon, r *http.Request) response.Response {
 	}
 
 	var location string
-	if s.ServerClustered && !pool.Driver().Info().Remote {
+	if s.ServerClustered && !pool.Capabilities().Remote {
 		location = s.ServerName
 	}
 
@@ -1403,7 +1408,7 @@ func storagePoolBucketKeyGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
 	}
 
-	if !pool.Driver().Info().Buckets {
+	if !pool.Capabilities().Buckets {
 		return response.BadRequest(errors.New("Storage pool does not support buckets"))
 	}
 
@@ -325,7 +325,7 @@ func storagePoolBucketBackupsPost(d *Daemon, r *http.Request) response.Response
 		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
 	}
 
-	if !pool.Driver().Info().Buckets {
+	if !pool.Capabilities().Buckets {
 		return response.BadRequest(errors.New("Storage pool does not support buckets"))
 	}
 
@@ -574,7 +574,7 @@ func storagePoolBucketBackupGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
 	}
 
-	if !pool.Driver().Info().Buckets {
+	if !pool.Capabilities().Buckets {
 		return response.BadRequest(errors.New("Storage pool does not support buckets"))
 	}
 
@@ -673,7 +673,7 @@ func storagePoolBucketBackupPost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
 	}
 
-	if !pool.Driver().Info().Buckets {
+	if !pool.Capabilities().Buckets {
 		return response.BadRequest(errors.New("Storage pool does not support buckets"))
 	}
 
@@ -800,7 +800,7 @@ func storagePoolBucketBackupDelete(d *Daemon, r *http.Request) response.Response
 		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
 	}
 
-	if !pool.Driver().Info().Buckets {
+	if !pool.Capabilities().Buckets {
 		return response.BadRequest(errors.New("Storage pool does not support buckets"))
 	}
 
@@ -909,7 +909,7 @@ func storagePoolBucketBackupExportGet(d *Daemon, r *http.Request) response.Respo
 		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
 	}
 
-	if !pool.Driver().Info().Buckets {
+	if !pool.Capabilities().Buckets {
 		return response.BadRequest(errors.New("Storage pool does not support buckets"))
 	}
 
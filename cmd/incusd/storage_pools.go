@@ -889,26 +889,9 @@ func storagePoolPut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	// In clustered mode, we differentiate between node specific and non-node specific config keys based on
-	// whether the user has specified a target to apply the config to.
-	if s.ServerClustered {
-		if targetNode == "" {
-			// If no target is specified, then ensure only non-node-specific config keys are changed.
-			for k := range req.Config {
-				if slices.Contains(nodeSpecificConfig, k) {
-					return response.BadRequest(fmt.Errorf("Config key %q is cluster member specific", k))
-				}
-			}
-		} else {
-			curConfig := pool.Driver().Config()
-
-			// If a target is specified, then ensure only node-specific config keys are changed.
-			for k, v := range req.Config {
-				if !slices.Contains(nodeSpecificConfig, k) && curConfig[k] != v {
-					return response.BadRequest(fmt.Errorf("Config key %q may not be used as cluster member specific key", k))
-				}
-			}
-		}
+	err = storagePoolCheckTargetConfig(pool, req.Config, targetNode, s.ServerClustered)
+	if err != nil {
+		return response.BadRequest(err)
 	}
 
 	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
@@ -978,11 +961,42 @@ func storagePoolPatch(d *Daemon, r *http.Request) response.Response {
 	return storagePoolPut(d, r)
 }
 
-// doStoragePoolUpdate takes the current local storage pool config, merges with the requested storage pool config,
-// validates and applies the changes. Will also notify other cluster nodes of non-node specific config if needed.
-func doStoragePoolUpdate(s *state.State, pool storagePools.Pool, req api.StoragePoolPut, targetNode string, clientType clusterRequest.ClientType, httpMethod string, clustered bool) response.Response {
-	if req.Config == nil {
-		req.Config = map[string]string{}
+// storagePoolCheckTargetConfig checks that the requested storage pool config only changes keys that can be set
+// for the given target. In clustered mode, we differentiate between node specific and non-node specific config
+// keys based on whether the user has specified a target to apply the config to.
+func storagePoolCheckTargetConfig(pool storagePools.Pool, config map[string]string, targetNode string, clustered bool) error {
+	if !clustered {
+		return nil
+	}
+
+	nodeSpecificConfig := db.NodeSpecificStorageConfig(pool.Driver().Info().Name)
+
+	if targetNode == "" {
+		// If no target is specified, then ensure only non-node-specific config keys are changed.
+		for k := range config {
+			if slices.Contains(nodeSpecificConfig, k) {
+				return fmt.Errorf("Config key %q is cluster member specific", k)
+			}
+		}
+	} else {
+		curConfig := pool.Driver().Config()
+
+		// If a target is specified, then ensure only node-specific config keys are changed.
+		for k, v := range config {
+			if !slices.Contains(nodeSpecificConfig, k) && curConfig[k] != v {
+				return fmt.Errorf("Config key %q may not be used as cluster member specific key", k)
+			}
+		}
+	}
+
+	return nil
+}
+
+// storagePoolMergeConfig returns the requested storage pool config merged with the current local config
+// according to the request method.
+func storagePoolMergeConfig(pool storagePools.Pool, config map[string]string, targetNode string, httpMethod string, clustered bool) map[string]string {
+	if config == nil {
+		config = map[string]string{}
 	}
 
 	// Normally a "put" request will replace all existing config, however when clustered, we need to account
@@ -994,20 +1008,28 @@ func doStoragePoolUpdate(s *state.State, pool storagePools.Pool, req api.Storage
 		nodeSpecificConfig := db.NodeSpecificStorageConfig(pool.Driver().Info().Name)
 		for k, v := range pool.Driver().Config() {
 			if slices.Contains(nodeSpecificConfig, k) {
-				req.Config[k] = v
+				config[k] = v
 			}
 		}
 	} else if httpMethod == http.MethodPatch {
 		// If config being updated via "patch" method, then merge all existing config with the keys that
 		// are present in the request config.
 		for k, v := range pool.Driver().Config() {
-			_, ok := req.Config[k]
+			_, ok := config[k]
 			if !ok {
-				req.Config[k] = v
+				config[k] = v
 			}
 		}
 	}
 
+	return config
+}
+
+// doStoragePoolUpdate takes the current local storage pool config, merges with the requested storage pool config,
+// validates and applies the changes. Will also notify other cluster nodes of non-node specific config if needed.
+func doStoragePoolUpdate(s *state.State, pool storagePools.Pool, req api.StoragePoolPut, targetNode string, clientType clusterRequest.ClientType, httpMethod string, clustered bool) response.Response {
+	req.Config = storagePoolMergeConfig(pool, req.Config, targetNode, httpMethod, clustered)
+
 	// Validate the configuration.
 	err := pool.Validate(req.Config)
 	if err != nil {
@@ -1117,7 +1139,7 @@ func storagePoolDelete(d *Daemon, r *http.Request) response.Response {
 
 	// Only perform the deletion of remote image volumes on the server handling the request.
 	// Otherwise delete local image volumes on each server.
-	if !clusterNotification || !pool.Driver().Info().Remote {
+	if !clusterNotification || !pool.Capabilities().Remote {
 		var removeImgFingerprints []string
 
 		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -1143,7 +1165,7 @@ func storagePoolDelete(d *Daemon, r *http.Request) response.Response {
 		}
 
 		for _, removeImgFingerprint := range removeImgFingerprints {
-			err = pool.DeleteImage(removeImgFingerprint, nil)
+			err = pool.DeleteImage(removeImgFingerprint, true, nil)
 			if err != nil {
 				return response.InternalError(fmt.Errorf("Error deleting image %q from storage pool %q: %w", removeImgFingerprint, pool.Name(), err))
 			}
@@ -1166,7 +1188,7 @@ func storagePoolDelete(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if pool.LocalStatus() != api.StoragePoolStatusPending {
-		err = pool.Delete(clientType, nil)
+		err = pool.Delete(clientType, false, nil)
 		if err != nil {
 			return response.InternalError(err)
 		}
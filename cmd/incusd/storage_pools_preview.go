@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+var storagePoolPreviewCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/preview",
+
+	Post: APIEndpointAction{Handler: storagePoolPreviewPost, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanEdit, "poolName")},
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/preview storage storage_pool_preview_post
+//
+//	Preview a storage pool update
+//
+//	Reports what updating the entire storage pool configuration would do, without applying anything.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: poolName
+//	    description: Storage pool name
+//	    type: string
+//	    required: true
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: storage pool
+//	    description: Storage pool configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/StoragePoolPut"
+//	responses:
+//	  "200":
+//	    description: Storage pool update preview
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/StoragePoolUpdatePreview"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolPreviewPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := pathVar(r, "poolName")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	targetNode := request.QueryParam(r, "target")
+
+	if targetNode == "" && pool.Status() != api.StoragePoolStatusCreated {
+		return response.BadRequest(errors.New("Cannot update storage pool global config when not in created state"))
+	}
+
+	// Decode the request.
+	req := api.StoragePoolPut{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = storagePoolCheckTargetConfig(pool, req.Config, targetNode, s.ServerClustered)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Merge the config the same way a full update would.
+	config := storagePoolMergeConfig(pool, req.Config, targetNode, http.MethodPut, s.ServerClustered)
+
+	preview, err := pool.PreviewUpdate(req.Description, config)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, preview)
+}
@@ -116,7 +116,7 @@ func storagePoolCreateLocal(ctx context.Context, s *state.State, poolID int64, r
 		return nil, err
 	}
 
-	reverter.Add(func() { _ = pool.Delete(clientType, nil) })
+	reverter.Add(func() { _ = pool.Delete(clientType, false, nil) })
 
 	// Mount the pool.
 	_, err = pool.Mount()
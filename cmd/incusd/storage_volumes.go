@@ -581,7 +581,7 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 			vol := &dbVol.StorageVolume
 
 			var location string
-			if s.ServerClustered && !pool.Driver().Info().Remote {
+			if s.ServerClustered && !pool.Capabilities().Remote {
 				location = vol.Location
 			}
 
@@ -611,7 +611,7 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 			volumesFull := make([]*api.StorageVolumeFull, 0, len(volumes))
 
 			for _, vol := range volumes {
-				if s.ServerClustered && !pool.Driver().Info().Remote && vol.Location != "" && vol.Location != s.ServerName {
+				if s.ServerClustered && !pool.Capabilities().Remote && vol.Location != "" && vol.Location != s.ServerName {
 					// Get the remote address.
 					var volNode db.NodeInfo
 
@@ -660,7 +660,7 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 		}
 
 		var location string
-		if s.ServerClustered && !pool.Driver().Info().Remote {
+		if s.ServerClustered && !pool.Capabilities().Remote {
 			location = dbVol.Location
 		}
 
@@ -680,7 +680,8 @@ func filterVolumes(volumes []*db.StorageVolume, clauses *filter.ClauseSet, allPr
 	// It allows to filter snapshots by using default filter mechanism.
 	type FilterStorageVolume struct {
 		api.StorageVolume `yaml:",inline"`
-		Snapshot          string `yaml:"snapshot"`
+		Snapshot          string            `yaml:"snapshot"`
+		Tags              map[string]string `yaml:"tags"`
 	}
 
 	filtered := []*db.StorageVolume{}
@@ -693,6 +694,7 @@ func filterVolumes(volumes []*db.StorageVolume, clauses *filter.ClauseSet, allPr
 		tmpVolume := FilterStorageVolume{
 			StorageVolume: volume.StorageVolume,
 			Snapshot:      strconv.FormatBool(strings.Contains(volume.Name, internalInstance.SnapshotDelimiter)),
+			Tags:          storagePools.VolumeTags(volume.Config),
 		}
 
 		match, err := filter.Match(tmpVolume, *clauses)
@@ -871,6 +873,20 @@ func storagePoolVolumesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Currently not allowed to create storage volumes of type %q", req.Type))
 	}
 
+	// Check the project limits against the config the volume will be created with, including its template.
+	limitsReq := req
+	if req.Template != "" {
+		pool, err := storagePools.LoadByName(s, poolName)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		limitsReq.Config, err = storagePools.VolumeTemplateConfig(pool, req.Template, req.Config)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	var poolID int64
 	var dbVolume *db.StorageVolume
 
@@ -886,7 +902,7 @@ func storagePoolVolumesPost(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
-		err = project.AllowVolumeCreation(tx, projectName, poolName, req)
+		err = project.AllowVolumeCreation(tx, projectName, poolName, limitsReq)
 		if err != nil {
 			return err
 		}
@@ -1075,15 +1091,19 @@ func doVolumeCreateOrCopy(s *state.State, r *http.Request, requestProjectName st
 		return response.SmartError(err)
 	}
 
+	if req.Template != "" && req.Source.Name != "" {
+		return response.BadRequest(errors.New("Volume templates can only be used when creating an empty volume"))
+	}
+
 	run := func(op *operations.Operation) error {
 		if req.Source.Name == "" {
 			// Use an empty operation for this sync response to pass the requestor
 			op := &operations.Operation{}
 			op.SetRequestor(r)
-			return pool.CreateCustomVolume(projectName, req.Name, req.Description, req.Config, contentType, op)
+			return pool.CreateCustomVolume(projectName, req.Name, req.Description, req.Config, contentType, req.Template, op)
 		}
 
-		return pool.CreateCustomVolumeFromCopy(projectName, srcProjectName, req.Name, req.Description, req.Config, req.Source.Pool, req.Source.Name, !req.Source.VolumeOnly, op)
+		return pool.CreateCustomVolumeFromCopy(projectName, srcProjectName, req.Name, req.Description, req.Config, req.Source.Pool, req.Source.Name, !req.Source.VolumeOnly, nil, "", req.Source.Verify, op)
 	}
 
 	// If no source name supplied then this a volume create operation.
@@ -1379,7 +1399,7 @@ func storagePoolVolumePost(d *Daemon, r *http.Request) response.Response {
 				return response.SmartError(err)
 			}
 
-			if srcPool.Driver().Info().Remote {
+			if srcPool.Capabilities().Remote {
 				var dbVolume *db.StorageVolume
 				var volumeNotFound bool
 				var targetIsSet bool
@@ -1833,7 +1853,7 @@ func storagePoolVolumeTypePostMove(s *state.State, r *http.Request, poolName str
 
 		// Provide empty description and nil config to instruct CreateCustomVolumeFromCopy to copy it
 		// from source volume.
-		err = newPool.CreateCustomVolumeFromCopy(projectName, requestProjectName, newVol.Name, "", nil, pool.Name(), vol.Name, true, op)
+		err = newPool.CreateCustomVolumeFromCopy(projectName, requestProjectName, newVol.Name, "", nil, pool.Name(), vol.Name, true, nil, "", false, op)
 		if err != nil {
 			return err
 		}
@@ -2312,7 +2332,7 @@ func storagePoolVolumePut(d *Daemon, r *http.Request) response.Response {
 		// before applying config changes so that changes are applied to the
 		// restored volume.
 		if req.Restore != "" {
-			err = pool.RestoreCustomVolume(projectName, dbVolume.Name, req.Restore, op)
+			_, err = pool.RestoreCustomVolume(projectName, dbVolume.Name, req.Restore, req.RestoreSafetySnapshot, op)
 			if err != nil {
 				return response.SmartError(err)
 			}
@@ -2553,6 +2573,11 @@ func storagePoolVolumePatch(d *Daemon, r *http.Request) response.Response {
 //	    description: Cluster member name
 //	    type: string
 //	    example: server01
+//	  - in: query
+//	    name: force
+//	    description: Delete image volumes even if volumes are still cloned from them
+//	    type: string
+//	    example: "1"
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
@@ -2663,7 +2688,7 @@ func storagePoolVolumeDelete(d *Daemon, r *http.Request) response.Response {
 	case db.StoragePoolVolumeTypeCustom:
 		err = pool.DeleteCustomVolume(volumeProjectName, volumeName, op)
 	case db.StoragePoolVolumeTypeImage:
-		err = pool.DeleteImage(volumeName, op)
+		err = pool.DeleteImage(volumeName, util.IsTrue(r.FormValue("force")), op)
 	default:
 		return response.BadRequest(fmt.Errorf(`Storage volumes of type %q cannot be deleted with the storage API`, volumeTypeName))
 	}
@@ -2909,9 +2934,10 @@ func createStoragePoolVolumeFromBackup(s *state.State, r *http.Request, requestP
 			return err
 		}
 
-		// Check if the backup is optimized that the source pool driver matches the target pool driver.
-		if *bInfo.OptimizedStorage && pool.Driver().Info().Name != bInfo.Backend {
-			return fmt.Errorf("Optimized backup storage driver %q differs from the target storage pool driver %q", bInfo.Backend, pool.Driver().Info().Name)
+		// Fall back to the generic data of optimized backups made by a different driver.
+		err = bInfo.AdaptToDriver(pool.Driver().Info().Name, backupFile, s.OS, backupFile.Name(), backup.DefaultBackupPrefix)
+		if err != nil {
+			return err
 		}
 
 		// Dump tarball to storage.
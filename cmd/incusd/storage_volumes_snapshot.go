@@ -26,6 +26,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/response"
 	"github.com/lxc/incus/v7/internal/server/state"
 	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v7/internal/server/storage/drivers"
 	"github.com/lxc/incus/v7/internal/server/task"
 	localUtil "github.com/lxc/incus/v7/internal/server/util"
 	internalUtil "github.com/lxc/incus/v7/internal/util"
@@ -287,7 +288,7 @@ func storagePoolVolumeSnapshotsTypePost(d *Daemon, r *http.Request) response.Res
 
 	// Create the snapshot.
 	snapshot := func(op *operations.Operation) error {
-		return pool.CreateCustomVolumeSnapshot(projectName, volumeName, req.Name, expiry, false, op)
+		return pool.CreateCustomVolumeSnapshot(projectName, volumeName, req.Name, expiry, false, req.Force, false, req.Quiesce, op)
 	}
 
 	resources := map[string][]api.URL{}
@@ -1365,7 +1366,7 @@ func storagePoolVolumeSnapshotTypeDelete(d *Daemon, r *http.Request) response.Re
 func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, task.Schedule) {
 	f := func(ctx context.Context) {
 		s := d.State()
-		var volumes, remoteVolumes, expiredSnapshots, expiredRemoteSnapshots []db.StorageVolumeArgs
+		var volumes, remoteVolumes, expiredSnapshots, expiredRemoteSnapshots, retainVolumes, retainRemoteVolumes []db.StorageVolumeArgs
 		var memberCount int
 		var onlineMemberIDs []int64
 
@@ -1407,6 +1408,15 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 			}
 
 			for _, v := range allVolumes {
+				// Volumes with a snapshot retention count are checked on every run.
+				if v.Config["snapshots.retain"] != "" {
+					if v.NodeID < 0 {
+						retainRemoteVolumes = append(retainRemoteVolumes, v)
+					} else {
+						retainVolumes = append(retainVolumes, v)
+					}
+				}
+
 				err = project.AllowSnapshotCreation(projects[v.ProjectName])
 				if err != nil {
 					continue
@@ -1432,7 +1442,7 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 				}
 			}
 
-			if len(remoteVolumes) > 0 || len(expiredRemoteSnapshots) > 0 {
+			if len(remoteVolumes) > 0 || len(expiredRemoteSnapshots) > 0 || len(retainRemoteVolumes) > 0 {
 				// Get list of cluster members.
 				members, err := tx.GetNodes(ctx)
 				if err != nil {
@@ -1492,6 +1502,30 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 			}
 		}
 
+		if len(retainRemoteVolumes) > 0 {
+			// Skip applying the retention of remote custom volumes if there are no online members, for the
+			// same reason as for the snapshot expiry.
+			if memberCount > 1 && len(onlineMemberIDs) <= 0 {
+				logger.Error("Skipping remote volumes for custom volume snapshot retention task due to no online members")
+			} else {
+				for _, v := range retainRemoteVolumes {
+					if memberCount > 1 {
+						selectedMemberID, err := localUtil.GetStableRandomInt64FromList(int64(v.ID), onlineMemberIDs)
+						if err != nil {
+							logger.Error("Failed scheduling remote custom volume snapshot retention task", logger.Ctx{"volName": v.Name, "project": v.ProjectName, "pool": v.PoolName, "err": err})
+							continue
+						}
+
+						if localMemberID != selectedMemberID {
+							continue
+						}
+					}
+
+					retainVolumes = append(retainVolumes, v)
+				}
+			}
+		}
+
 		if len(remoteVolumes) > 0 {
 			// Skip snapshotting remote custom volumes if there are no online members, as we can't be
 			// sure that the cluster isn't partitioned and we may end up attempting the snapshot on
@@ -1525,9 +1559,14 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 
 		// Handle snapshot expiry first before creating new ones to reduce the chances of running out of
 		// disk space.
-		if len(expiredSnapshots) > 0 {
+		if len(expiredSnapshots) > 0 || len(retainVolumes) > 0 {
 			opRun := func(op *operations.Operation) error {
-				return pruneExpiredCustomVolumeSnapshots(ctx, s, expiredSnapshots)
+				err := pruneExpiredCustomVolumeSnapshots(ctx, s, expiredSnapshots)
+				if err != nil {
+					return err
+				}
+
+				return pruneRetainedCustomVolumeSnapshots(ctx, s, retainVolumes)
 			}
 
 			op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.CustomVolumeSnapshotsExpire, nil, nil, opRun, nil, nil, nil)
@@ -1593,27 +1632,92 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 var customVolSnapshotsPruneRunning = sync.Map{}
 
 func pruneExpiredCustomVolumeSnapshots(ctx context.Context, s *state.State, expiredSnapshots []db.StorageVolumeArgs) error {
+	type snapshotGroup struct {
+		poolName    string
+		projectName string
+	}
+
+	// Group the snapshots by pool and project so that each pool can delete them in bulk.
+	var groups []snapshotGroup
+	groupSnapshots := make(map[snapshotGroup][]db.StorageVolumeArgs)
 	for _, v := range expiredSnapshots {
+		group := snapshotGroup{poolName: v.PoolName, projectName: v.ProjectName}
+
+		_, found := groupSnapshots[group]
+		if !found {
+			groups = append(groups, group)
+		}
+
+		groupSnapshots[group] = append(groupSnapshots[group], v)
+	}
+
+	var errs []error
+	for _, group := range groups {
 		err := ctx.Err()
 		if err != nil {
 			return err // Stop if context is cancelled.
 		}
 
-		_, loaded := customVolSnapshotsPruneRunning.LoadOrStore(v.ID, struct{}{})
-		if loaded {
-			continue // Deletion of this snapshot is already running, skip.
+		var snapshotIDs []int64
+		var snapshotNames []string
+		for _, v := range groupSnapshots[group] {
+			_, loaded := customVolSnapshotsPruneRunning.LoadOrStore(v.ID, struct{}{})
+			if loaded {
+				continue // Deletion of this snapshot is already running, skip.
+			}
+
+			snapshotIDs = append(snapshotIDs, v.ID)
+			snapshotNames = append(snapshotNames, v.Name)
+		}
+
+		if len(snapshotNames) == 0 {
+			continue
+		}
+
+		err = pruneCustomVolumeSnapshotGroup(s, group.poolName, group.projectName, snapshotNames)
+		for _, id := range snapshotIDs {
+			customVolSnapshotsPruneRunning.Delete(id)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// pruneCustomVolumeSnapshotGroup deletes the given snapshots from a project on a pool.
+func pruneCustomVolumeSnapshotGroup(s *state.State, poolName string, projectName string, snapshotNames []string) error {
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return fmt.Errorf("Error loading pool %q for volume snapshots (project %q): %w", poolName, projectName, err)
+	}
+
+	err = pool.DeleteCustomVolumeSnapshots(projectName, snapshotNames, 0, nil)
+	if err != nil {
+		return fmt.Errorf("Error deleting custom volume snapshots (project %q, pool %q): %w", projectName, poolName, err)
+	}
+
+	return nil
+}
+
+// pruneRetainedCustomVolumeSnapshots deletes the snapshots of the volumes beyond their snapshots.retain count.
+func pruneRetainedCustomVolumeSnapshots(ctx context.Context, s *state.State, volumes []db.StorageVolumeArgs) error {
+	for _, v := range volumes {
+		err := ctx.Err()
+		if err != nil {
+			return err // Stop if context is cancelled.
 		}
 
 		pool, err := storagePools.LoadByName(s, v.PoolName)
 		if err != nil {
-			customVolSnapshotsPruneRunning.Delete(v.ID)
-			return fmt.Errorf("Error loading pool for volume snapshot %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
+			return fmt.Errorf("Error loading pool for volume %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
 		}
 
-		err = pool.DeleteCustomVolumeSnapshot(v.ProjectName, v.Name, nil)
-		customVolSnapshotsPruneRunning.Delete(v.ID)
+		_, err = pool.ApplyRetentionPolicy(v.ProjectName, v.Name, storageDrivers.VolumeTypeCustom, nil)
 		if err != nil {
-			return fmt.Errorf("Error deleting custom volume snapshot %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
+			return fmt.Errorf("Error applying snapshot retention for volume %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
 		}
 	}
 
@@ -1643,7 +1747,7 @@ func autoCreateCustomVolumeSnapshots(ctx context.Context, s *state.State, volume
 			return fmt.Errorf("Error loading pool for volume %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
 		}
 
-		err = pool.CreateCustomVolumeSnapshot(v.ProjectName, v.Name, snapshotName, expiry, false, nil)
+		err = pool.CreateCustomVolumeSnapshot(v.ProjectName, v.Name, snapshotName, expiry, false, false, true, false, nil)
 		if err != nil {
 			return fmt.Errorf("Error creating snapshot for volume %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
 		}
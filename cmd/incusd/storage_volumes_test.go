@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	clusterRequest "github.com/lxc/incus/v7/internal/server/cluster/request"
+	"github.com/lxc/incus/v7/internal/server/db"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v7/internal/server/storage/drivers"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+type storageVolumesTestSuite struct {
+	daemonTestSuite
+}
+
+func (s *storageVolumesTestSuite) TestUpdateCustomVolume_DescriptionOnly() {
+	// The mock driver refuses config updates on block volumes, so a successful update shows it wasn't used.
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol1", "", map[string]string{}, storageDrivers.ContentTypeBlock, "", nil)
+	s.Req.Nil(err)
+
+	dbVol, err := storagePools.VolumeDBGet(pool, api.ProjectDefaultName, "vol1", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+
+	err = pool.UpdateCustomVolume(api.ProjectDefaultName, "vol1", "new description", dbVol.Config, nil)
+	s.Req.Nil(err)
+
+	dbVol, err = storagePools.VolumeDBGet(pool, api.ProjectDefaultName, "vol1", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Equal("new description", dbVol.Description)
+
+	// A description-only change stores the description alone, leaving the config exactly as it was.
+	config := map[string]string{}
+	for k, v := range dbVol.Config {
+		config[k] = v
+	}
+
+	config["security.unmapped"] = "true"
+	config["volatile.idmap.last"] = "[]"
+
+	err = s.d.State().DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateStoragePoolVolume(ctx, api.ProjectDefaultName, "vol1", db.StoragePoolVolumeTypeCustom, pool.ID(), dbVol.Description, config)
+	})
+	s.Req.Nil(err)
+
+	err = pool.UpdateCustomVolume(api.ProjectDefaultName, "vol1", "other description", config, nil)
+	s.Req.Nil(err)
+
+	dbVol, err = storagePools.VolumeDBGet(pool, api.ProjectDefaultName, "vol1", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Equal("other description", dbVol.Description)
+	s.Equal(config, dbVol.Config)
+
+	// A config change does reach the driver.
+	newConfig := map[string]string{}
+	for k, v := range dbVol.Config {
+		newConfig[k] = v
+	}
+
+	newConfig["security.shared"] = "true"
+
+	err = pool.UpdateCustomVolume(api.ProjectDefaultName, "vol1", "new description", newConfig, nil)
+	s.Req.ErrorIs(err, storageDrivers.ErrNotSupported)
+}
+
+func (s *storageVolumesTestSuite) TestCreateCustomVolume_Template() {
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	// Template keys are validated like volume.* keys.
+	err = pool.Update(clusterRequest.ClientTypeNormal, "", map[string]string{"volume-template.db.invalid": "foo"}, nil)
+	s.Req.Error(err)
+
+	err = pool.Update(clusterRequest.ClientTypeNormal, "", map[string]string{"volume-template.db.size": "10GiB", "volume-template.db.snapshots.expiry": "1d"}, nil)
+	s.Req.Nil(err)
+
+	pool, err = storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	// Explicit config overrides the template.
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol2", "", map[string]string{"snapshots.expiry": "2d"}, storageDrivers.ContentTypeBlock, "db", nil)
+	s.Req.Nil(err)
+
+	dbVol, err := storagePools.VolumeDBGet(pool, api.ProjectDefaultName, "vol2", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Equal("10GiB", dbVol.Config["size"])
+	s.Equal("2d", dbVol.Config["snapshots.expiry"])
+
+	// Unknown templates are rejected.
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol3", "", nil, storageDrivers.ContentTypeBlock, "missing", nil)
+	s.Req.Error(err)
+}
+
+func (s *storageVolumesTestSuite) TestApplyRetentionPolicy() {
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol4", "", map[string]string{"snapshots.retain": "2"}, storageDrivers.ContentTypeBlock, "", nil)
+	s.Req.Nil(err)
+
+	for _, snapName := range []string{"snap0", "snap1", "snap2"} {
+		err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol4", snapName, time.Time{}, false, true, false, false, nil)
+		s.Req.Nil(err)
+	}
+
+	// Only the oldest snapshot is beyond the retention count.
+	deleted, err := pool.ApplyRetentionPolicy(api.ProjectDefaultName, "vol4", storageDrivers.VolumeTypeCustom, nil)
+	s.Req.Nil(err)
+	s.Equal(1, deleted)
+
+	snapshots, err := storagePools.VolumeDBSnapshotsGet(pool, api.ProjectDefaultName, "vol4", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Req.Len(snapshots, 2)
+	s.Equal("vol4/snap1", snapshots[0].Name)
+	s.Equal("vol4/snap2", snapshots[1].Name)
+
+	// Nothing left to delete.
+	deleted, err = pool.ApplyRetentionPolicy(api.ProjectDefaultName, "vol4", storageDrivers.VolumeTypeCustom, nil)
+	s.Req.Nil(err)
+	s.Equal(0, deleted)
+}
+
+func (s *storageVolumesTestSuite) TestApplyRetentionPolicyPrefersAuto() {
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol5", "", map[string]string{"snapshots.retain": "2"}, storageDrivers.ContentTypeBlock, "", nil)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol5", "manual0", time.Time{}, false, true, false, false, nil)
+	s.Req.Nil(err)
+
+	for _, snapName := range []string{"snap0", "snap1"} {
+		err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol5", snapName, time.Time{}, false, true, true, false, nil)
+		s.Req.Nil(err)
+	}
+
+	// The oldest scheduled snapshot goes before the older manual one.
+	deleted, err := pool.ApplyRetentionPolicy(api.ProjectDefaultName, "vol5", storageDrivers.VolumeTypeCustom, nil)
+	s.Req.Nil(err)
+	s.Equal(1, deleted)
+
+	snapshots, err := storagePools.VolumeDBSnapshotsGet(pool, api.ProjectDefaultName, "vol5", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Req.Len(snapshots, 2)
+	s.Equal("vol5/manual0", snapshots[0].Name)
+	s.Equal("", snapshots[0].Config["snapshots.auto"])
+	s.Equal("vol5/snap1", snapshots[1].Name)
+	s.Equal("true", snapshots[1].Config["snapshots.auto"])
+}
+
+func (s *storageVolumesTestSuite) TestApplyRetentionPolicySkipsRestoreSafety() {
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol9", "", map[string]string{"snapshots.retain": "1"}, storageDrivers.ContentTypeBlock, "", nil)
+	s.Req.Nil(err)
+
+	for _, snapName := range []string{"pre-restore-20260101-000000", "snap0", "snap1"} {
+		err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol9", snapName, time.Time{}, false, true, false, false, nil)
+		s.Req.Nil(err)
+	}
+
+	// The pre-restore snapshot is neither counted nor pruned, even though it is the oldest.
+	deleted, err := pool.ApplyRetentionPolicy(api.ProjectDefaultName, "vol9", storageDrivers.VolumeTypeCustom, nil)
+	s.Req.Nil(err)
+	s.Equal(1, deleted)
+
+	snapshots, err := storagePools.VolumeDBSnapshotsGet(pool, api.ProjectDefaultName, "vol9", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Req.Len(snapshots, 2)
+	s.Equal("vol9/pre-restore-20260101-000000", snapshots[0].Name)
+	s.Equal("vol9/snap1", snapshots[1].Name)
+}
+
+func (s *storageVolumesTestSuite) TestCreateCustomVolumeSnapshot_Max() {
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol6", "", map[string]string{"snapshots.max": "2"}, storageDrivers.ContentTypeBlock, "", nil)
+	s.Req.Nil(err)
+
+	for _, snapName := range []string{"snap0", "snap1"} {
+		err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol6", snapName, time.Time{}, false, true, false, false, nil)
+		s.Req.Nil(err)
+	}
+
+	// New snapshots are refused once the limit is reached.
+	err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol6", "snap2", time.Time{}, false, true, false, false, nil)
+	s.Req.Error(err)
+
+	snapshots, err := storagePools.VolumeDBSnapshotsGet(pool, api.ProjectDefaultName, "vol6", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Req.Len(snapshots, 2)
+}
+
+func (s *storageVolumesTestSuite) TestCreateCustomVolumeSnapshot_MaxPrune() {
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol7", "", map[string]string{"snapshots.max": "2", "snapshots.max.policy": "prune"}, storageDrivers.ContentTypeBlock, "", nil)
+	s.Req.Nil(err)
+
+	for _, snapName := range []string{"snap0", "snap1", "snap2"} {
+		err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol7", snapName, time.Time{}, false, true, false, false, nil)
+		s.Req.Nil(err)
+	}
+
+	// The oldest snapshot made room for the new one.
+	snapshots, err := storagePools.VolumeDBSnapshotsGet(pool, api.ProjectDefaultName, "vol7", storageDrivers.VolumeTypeCustom)
+	s.Req.Nil(err)
+	s.Req.Len(snapshots, 2)
+	s.Equal("vol7/snap1", snapshots[0].Name)
+	s.Equal("vol7/snap2", snapshots[1].Name)
+}
+
+func (s *storageVolumesTestSuite) TestCreateCustomVolumeSnapshot_Quiesce() {
+	pool, err := storagePools.LoadByName(s.d.State(), daemonTestSuiteDefaultStoragePool)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol10", "", map[string]string{}, storageDrivers.ContentTypeFS, "", nil)
+	s.Req.Nil(err)
+
+	// Quiescing a filesystem volume not used by any running instance doesn't get in the way of the snapshot.
+	err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol10", "snap0", time.Time{}, false, true, false, true, nil)
+	s.Req.Nil(err)
+
+	// Block volumes are snapshotted without quiescing.
+	err = pool.CreateCustomVolume(api.ProjectDefaultName, "vol11", "", map[string]string{}, storageDrivers.ContentTypeBlock, "", nil)
+	s.Req.Nil(err)
+
+	err = pool.CreateCustomVolumeSnapshot(api.ProjectDefaultName, "vol11", "snap0", time.Time{}, false, true, false, true, nil)
+	s.Req.Nil(err)
+
+	for _, volName := range []string{"vol10", "vol11"} {
+		snapshots, err := storagePools.VolumeDBSnapshotsGet(pool, api.ProjectDefaultName, volName, storageDrivers.VolumeTypeCustom)
+		s.Req.Nil(err)
+		s.Req.Len(snapshots, 1)
+		s.Equal(volName+"/snap0", snapshots[0].Name)
+	}
+}
+
+func TestStorageVolumesTestSuite(t *testing.T) {
+	suite.Run(t, &storageVolumesTestSuite{})
+}
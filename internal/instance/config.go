@@ -178,6 +178,16 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What to do when evacuating the instance
 	"cluster.evacuate": validate.Optional(validate.IsOneOf("auto", "migrate", "live-migrate", "stop", "stateful-stop", "force-stop")),
 
+	// gendoc:generate(entity=instance, group=miscellaneous, key=templates.ignore_errors)
+	// When enabled, failures to apply the image templates while creating or copying the instance
+	// are logged and reported in the operation metadata instead of failing the operation.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  shortdesc: Whether template failures during creation are non-fatal
+	"templates.ignore_errors": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu)
 	// A number or a specific range of CPUs to expose to the instance.
 	// For virtual machines, a CPU topology of the form `sockets=2,cores=4,threads=2` may also be provided.
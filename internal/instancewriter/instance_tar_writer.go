@@ -4,10 +4,12 @@ package instancewriter
 
 import (
 	"archive/tar"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"golang.org/x/sys/unix"
 
@@ -22,6 +24,9 @@ type InstanceTarWriter struct {
 	tarWriter *tar.Writer
 	idmapSet  *idmap.Set
 	linkMap   map[uint64]string
+
+	checksumming bool
+	checksums    []string // Manifest lines of the files written while checksumming.
 }
 
 // NewInstanceTarWriter returns an InstanceTarWriter for the provided target Writer and id map.
@@ -39,6 +44,59 @@ func (ctw *InstanceTarWriter) ResetHardLinkMap() {
 	ctw.linkMap = map[uint64]string{}
 }
 
+// EnableChecksums makes the writer compute the SHA-256 checksum of the content of the files written from now on,
+// as it is streamed into the tarball. The checksums can then be added with WriteChecksumManifest.
+func (ctw *InstanceTarWriter) EnableChecksums() {
+	ctw.checksumming = true
+}
+
+// WriteChecksumManifest adds a file with the specified name listing the checksums of the files written since
+// EnableChecksums was called, in the format used by sha256sum. Checksumming is disabled afterwards.
+func (ctw *InstanceTarWriter) WriteChecksumManifest(name string) error {
+	manifest := strings.Join(ctw.checksums, "")
+
+	ctw.checksumming = false
+	ctw.checksums = nil
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o600,
+		Size:     int64(len(manifest)),
+		ModTime:  time.Now(),
+	}
+
+	err := ctw.tarWriter.WriteHeader(hdr)
+	if err != nil {
+		return fmt.Errorf("Failed to write tar header: %w", err)
+	}
+
+	_, err = io.WriteString(ctw.tarWriter, manifest)
+	if err != nil {
+		return fmt.Errorf("Failed to write checksum manifest: %w", err)
+	}
+
+	return nil
+}
+
+// copyContent copies the content of a file into the tarball, computing its checksum if needed.
+func (ctw *InstanceTarWriter) copyContent(name string, src io.Reader) error {
+	if !ctw.checksumming {
+		_, err := util.SafeCopy(ctw.tarWriter, src)
+		return err
+	}
+
+	h := sha256.New()
+	_, err := util.SafeCopy(io.MultiWriter(ctw.tarWriter, h), src)
+	if err != nil {
+		return err
+	}
+
+	ctw.checksums = append(ctw.checksums, fmt.Sprintf("%x  %s\n", h.Sum(nil), name))
+
+	return nil
+}
+
 // WriteFile adds a file to the tarball with the specified name using the srcPath file as the contents of the file.
 // The ignoreGrowth argument indicates whether to error if the srcPath file increases in size beyond the size in fi
 // during the write. If false the write will return an error. If true, no error is returned, instead only the size
@@ -173,7 +231,7 @@ func (ctw *InstanceTarWriter) WriteFile(name string, srcPath string, fi os.FileI
 			r = io.LimitReader(r, fi.Size())
 		}
 
-		_, err = util.SafeCopy(ctw.tarWriter, r)
+		err = ctw.copyContent(hdr.Name, r)
 		if err != nil {
 			return fmt.Errorf("Failed to copy file content %q: %w", srcPath, err)
 		}
@@ -200,8 +258,7 @@ func (ctw *InstanceTarWriter) WriteFileFromReader(src io.Reader, fi os.FileInfo)
 		return fmt.Errorf("Failed to write tar header: %w", err)
 	}
 
-	_, err = util.SafeCopy(ctw.tarWriter, src)
-	return err
+	return ctw.copyContent(hdr.Name, src)
 }
 
 // Close finishes writing the tarball.
@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// Test that the verify only flag survives a round trip through the migration header.
+func TestMigrationHeader_VerifyOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		verifyOnly *bool
+		want       bool
+	}{
+		{
+			name:       "Verify only",
+			verifyOnly: proto.Bool(true),
+			want:       true,
+		},
+		{
+			name:       "Full migration",
+			verifyOnly: proto.Bool(false),
+			want:       false,
+		},
+		{
+			name:       "Peer without verify only support",
+			verifyOnly: nil,
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := &MigrationHeader{
+				Fs:         MigrationFSType_RSYNC.Enum(),
+				VerifyOnly: tt.verifyOnly,
+			}
+
+			buf, err := proto.Marshal(header)
+			require.NoError(t, err)
+
+			got := &MigrationHeader{}
+			err = proto.Unmarshal(buf, got)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got.GetVerifyOnly())
+			assert.Equal(t, tt.verifyOnly != nil, got.VerifyOnly != nil)
+			assert.Equal(t, MigrationFSType_RSYNC, got.GetFs())
+		})
+	}
+}
@@ -6,7 +6,8 @@ import (
 
 // ValidatePost is used to initiate a recovery validation scan.
 type ValidatePost struct {
-	Pools []api.StoragePoolsPost `json:"pools" yaml:"pools"`
+	Pools      []api.StoragePoolsPost `json:"pools" yaml:"pools"`
+	BestEffort bool                   `json:"best_effort" yaml:"best_effort"` // Recover virtual machine disks without a backup file.
 }
 
 // ValidateVolume provides info about a missing volume that the recovery validation scan found.
@@ -16,6 +17,7 @@ type ValidateVolume struct {
 	SnapshotCount int    `json:"snapshotCount" yaml:"snapshotCount"` // Count of snapshots found for volume.
 	Project       string `json:"project" yaml:"project"`             // Project the volume belongs to.
 	Pool          string `json:"pool" yaml:"pool"`                   // Pool the volume belongs to.
+	Unconfirmed   bool   `json:"unconfirmed" yaml:"unconfirmed"`     // Config was reconstructed and requires confirmation.
 }
 
 // ValidateResult returns the result of the validation scan.
@@ -26,5 +28,6 @@ type ValidateResult struct {
 
 // ImportPost is used to initiate a recovert import.
 type ImportPost struct {
-	Pools []api.StoragePoolsPost `json:"pools" yaml:"pools"`
+	Pools      []api.StoragePoolsPost `json:"pools" yaml:"pools"`
+	BestEffort bool                   `json:"best_effort" yaml:"best_effort"` // Recover virtual machine disks without a backup file.
 }
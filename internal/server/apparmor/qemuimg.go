@@ -56,8 +56,8 @@ func handleWriter(out io.Writer, hand func(int64, int64)) io.Writer {
 // QemuImg runs qemu-img with an AppArmor profile based on the imgPath and dstPath supplied.
 // The first element of the cmd slice is expected to be a priority limiting command (such as nice or prlimit) and
 // will be added as an allowed command to the AppArmor profile. The remaining elements of the cmd slice are
-// expected to be the qemu-img command and its arguments.
-func QemuImg(sysOS *sys.OS, cmd []string, imgPath string, dstPath string, tracker *ioprogress.ProgressTracker) (string, error) {
+// expected to be the qemu-img command and its arguments. The qemu-img process is killed once ctx is done.
+func QemuImg(ctx context.Context, sysOS *sys.OS, cmd []string, imgPath string, dstPath string, tracker *ioprogress.ProgressTracker) (string, error) {
 	// It is assumed that command starts with a program which sets resource limits, like prlimit or nice
 	allowedCmds := []string{"qemu-img", cmd[0]}
 
@@ -108,12 +108,12 @@ func QemuImg(sysOS *sys.OS, cmd []string, imgPath string, dstPath string, tracke
 	p := subprocess.NewProcessWithFds(cmd[0], cmd[1:], nil, &nullWriteCloser{writer}, &nullWriteCloser{&buffer})
 	p.SetApparmor(profileName)
 
-	err = p.Start(context.Background())
+	err = p.Start(ctx)
 	if err != nil {
 		return "", fmt.Errorf("Failed running qemu-img: %w", err)
 	}
 
-	_, err = p.Wait(context.Background())
+	_, err = p.Wait(ctx)
 	if err != nil {
 		return "", subprocess.NewRunError(cmd[0], cmd[1:], err, nil, &buffer)
 	}
@@ -327,6 +327,11 @@ func (c *commonAuthorizer) RenameStoragePoolVolume(ctx context.Context, projectN
 	return nil
 }
 
+// GetStoragePoolVolumes is a no-op.
+func (c *commonAuthorizer) GetStoragePoolVolumes(ctx context.Context, storagePoolName string) ([]Object, error) {
+	return nil, nil
+}
+
 // AddStorageBucket is a no-op.
 func (c *commonAuthorizer) AddStorageBucket(ctx context.Context, projectName string, storagePoolName string, storageBucketName string, storageBucketLocation string) error {
 	return nil
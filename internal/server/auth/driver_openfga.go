@@ -908,6 +908,50 @@ func (f *FGA) RenameStoragePoolVolume(ctx context.Context, projectName string, s
 	return f.updateTuples(ctx, writes, deletions)
 }
 
+// GetStoragePoolVolumes returns the storage volumes of a storage pool known to the authorizer.
+func (f *FGA) GetStoragePoolVolumes(ctx context.Context, storagePoolName string) ([]Object, error) {
+	projectsResp, err := f.client.ListObjects(ctx).Body(client.ClientListObjectsRequest{
+		User:     ObjectServer().String(),
+		Relation: relationServer,
+		Type:     string(ObjectTypeProject),
+	}).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []Object
+	for _, projectObjectStr := range projectsResp.GetObjects() {
+		projectObject, err := ObjectFromString(projectObjectStr)
+		if err != nil {
+			return nil, err
+		}
+
+		volumesResp, err := f.client.ListObjects(ctx).Body(client.ClientListObjectsRequest{
+			User:     projectObject.String(),
+			Relation: relationProject,
+			Type:     string(ObjectTypeStorageVolume),
+		}).Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, volumeObjectStr := range volumesResp.GetObjects() {
+			volumeObject, err := ObjectFromString(volumeObjectStr)
+			if err != nil {
+				return nil, err
+			}
+
+			if volumeObject.Elements()[0] != storagePoolName {
+				continue
+			}
+
+			volumes = append(volumes, volumeObject)
+		}
+	}
+
+	return volumes, nil
+}
+
 // AddStorageBucket adds a storage bucket to the authorizer.
 func (f *FGA) AddStorageBucket(ctx context.Context, projectName string, storagePoolName string, storageBucketName string, storageBucketLocation string) error {
 	writes := []client.ClientTupleKey{
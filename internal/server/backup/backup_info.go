@@ -3,6 +3,7 @@ package backup
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"go.yaml.in/yaml/v4"
 
@@ -158,3 +159,69 @@ func GetInfo(r io.ReadSeeker, sysOS *sys.OS, outputPath string) (*Info, error) {
 
 	return &result, nil
 }
+
+// isGenericDataPath returns whether a tarball entry holds the main volume of the given backup type in the
+// generic, non-optimized format, below the given prefix.
+func isGenericDataPath(backupType Type, prefix string, name string) bool {
+	switch backupType {
+	case TypeContainer:
+		return strings.HasPrefix(name, prefix+"/container/rootfs")
+	case TypeVM:
+		return name == prefix+"/virtual-machine.img"
+	case TypeCustom:
+		return name == prefix+"/volume.img" || strings.HasPrefix(name, prefix+"/volume/")
+	}
+
+	return false
+}
+
+// HasGenericData returns whether the backup tarball contains the main volume in the generic, non-optimized
+// format below the given prefix.
+func HasGenericData(r io.ReadSeeker, sysOS *sys.OS, outputPath string, backupType Type, prefix string) (bool, error) {
+	tr, cancelFunc, err := TarReader(r, sysOS, outputPath)
+	if err != nil {
+		return false, err
+	}
+
+	defer cancelFunc()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("Error reading backup file: %w", err)
+		}
+
+		if isGenericDataPath(backupType, prefix, hdr.Name) {
+			return true, nil
+		}
+	}
+}
+
+// AdaptToDriver prepares the backup to be restored onto a pool using the given driver.
+// An optimized backup made by a different driver is restored from its generic data if the tarball has any
+// below the given prefix, otherwise an error is returned.
+func (i *Info) AdaptToDriver(driverName string, r io.ReadSeeker, sysOS *sys.OS, outputPath string, prefix string) error {
+	if i.OptimizedStorage == nil || !*i.OptimizedStorage || i.Backend == driverName {
+		return nil
+	}
+
+	hasGenericData, err := HasGenericData(r, sysOS, outputPath, i.Type, prefix)
+	if err != nil {
+		return err
+	}
+
+	if !hasGenericData {
+		return fmt.Errorf("Optimized backup storage driver %q differs from the target storage pool driver %q and the backup has no generic data to fall back to", i.Backend, driverName)
+	}
+
+	// Don't modify the values as they may be shared with other backup info.
+	optimizedFalse := false
+	i.OptimizedStorage = &optimizedFalse
+	i.OptimizedHeader = &optimizedFalse
+
+	return nil
+}
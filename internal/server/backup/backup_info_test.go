@@ -0,0 +1,121 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTarball returns an uncompressed tarball holding empty files with the given names.
+func makeTarball(t *testing.T, names ...string) *bytes.Reader {
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+// Test isGenericDataPath.
+func Test_isGenericDataPath(t *testing.T) {
+	tests := []struct {
+		backupType Type
+		prefix     string
+		name       string
+		generic    bool
+	}{
+		{TypeContainer, "backup", "backup/container/rootfs/etc/hostname", true},
+		{TypeContainer, "backup", "backup/container.bin", false},
+		{TypeContainer, "backup", "backup/container/backup.yaml", false},
+		{TypeVM, "backup", "backup/virtual-machine.img", true},
+		{TypeVM, "backup", "backup/virtual-machine.bin", false},
+		{TypeCustom, "backup", "backup/volume.img", true},
+		{TypeCustom, "backup", "backup/volume/data", true},
+		{TypeCustom, "backup", "backup/volume.bin", false},
+		{TypeCustom, "backup/disk0", "backup/disk0/volume/data", true},
+		{TypeCustom, "backup/disk0", "backup/volume/data", false},
+		{TypeBucket, "backup", "backup/bucket/object", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.generic, isGenericDataPath(tt.backupType, tt.prefix, tt.name), "%s %s", tt.backupType, tt.name)
+	}
+}
+
+// Test Info.AdaptToDriver.
+func Test_Info_AdaptToDriver(t *testing.T) {
+	optimizedTrue := true
+	optimizedFalse := false
+
+	tests := []struct {
+		name      string
+		info      Info
+		files     []string
+		driver    string
+		optimized bool
+		err       string
+	}{
+		{
+			// Check an optimized backup is kept as is for the driver that made it.
+			name:      "same driver",
+			info:      Info{Backend: "zfs", Type: TypeContainer, OptimizedStorage: &optimizedTrue},
+			files:     []string{"backup/index.yaml", "backup/container.bin"},
+			driver:    "zfs",
+			optimized: true,
+		},
+		{
+			// Check a non-optimized backup is restored on any driver.
+			name:      "generic backup",
+			info:      Info{Backend: "zfs", Type: TypeContainer, OptimizedStorage: &optimizedFalse},
+			files:     []string{"backup/index.yaml", "backup/container/rootfs/etc/hostname"},
+			driver:    "btrfs",
+			optimized: false,
+		},
+		{
+			// Check an optimized backup from another driver falls back to its generic data.
+			name:      "different driver with generic data",
+			info:      Info{Backend: "zfs", Type: TypeVM, OptimizedStorage: &optimizedTrue, OptimizedHeader: &optimizedTrue},
+			files:     []string{"backup/index.yaml", "backup/virtual-machine.bin", "backup/virtual-machine.img"},
+			driver:    "lvm",
+			optimized: false,
+		},
+		{
+			// Check an optimized backup from another driver without generic data is refused.
+			name:   "different driver without generic data",
+			info:   Info{Backend: "zfs", Type: TypeContainer, OptimizedStorage: &optimizedTrue},
+			files:  []string{"backup/index.yaml", "backup/container.bin"},
+			driver: "btrfs",
+			err:    `Optimized backup storage driver "zfs" differs from the target storage pool driver "btrfs" and the backup has no generic data to fall back to`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := tt.info
+
+			err := info.AdaptToDriver(tt.driver, makeTarball(t, tt.files...), nil, t.TempDir(), DefaultBackupPrefix)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.optimized, *info.OptimizedStorage)
+		})
+	}
+
+	// Check the fallback doesn't modify values shared with other backup info.
+	info := Info{Backend: "zfs", Type: TypeVM, OptimizedStorage: &optimizedTrue}
+	err := info.AdaptToDriver("lvm", makeTarball(t, "backup/virtual-machine.img"), nil, t.TempDir(), DefaultBackupPrefix)
+	require.NoError(t, err)
+	assert.False(t, *info.OptimizedStorage)
+	assert.True(t, optimizedTrue)
+}
@@ -17,15 +17,16 @@ import (
 
 // InstanceBackup is a value object holding all db-related details about an instance backup.
 type InstanceBackup struct {
-	ID                   int
-	InstanceID           int
-	Name                 string
-	CreationDate         time.Time
-	ExpiryDate           time.Time
-	InstanceOnly         bool
-	RootOnly             bool
-	OptimizedStorage     bool
-	CompressionAlgorithm string
+	ID                     int
+	InstanceID             int
+	Name                   string
+	CreationDate           time.Time
+	ExpiryDate             time.Time
+	InstanceOnly           bool
+	RootOnly               bool
+	OptimizedStorage       bool
+	OptimizedStorageStrict bool
+	CompressionAlgorithm   string
 }
 
 // StoragePoolVolumeBackup is a value object holding all db-related details about a storage volume backup.
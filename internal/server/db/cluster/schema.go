@@ -522,6 +522,7 @@ CREATE TABLE "storage_buckets_keys" (
     access_key TEXT NOT NULL,
     secret_key TEXT NOT NULL,
     role TEXT NOT NULL,
+    path_prefix TEXT NOT NULL DEFAULT '',
     UNIQUE (storage_bucket_id, name),
     FOREIGN KEY (storage_bucket_id) REFERENCES "storage_buckets" (id) ON DELETE CASCADE
 );
@@ -675,5 +676,5 @@ CREATE TABLE "warnings" (
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (77, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (78, strftime("%s"))
 `
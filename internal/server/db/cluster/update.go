@@ -114,6 +114,18 @@ var updates = map[int]schema.Update{
 	75: updateFromV74,
 	76: updateFromV75,
 	77: updateFromV76,
+	78: updateFromV77,
+}
+
+// updateFromV77 adds a path prefix to storage bucket keys.
+func updateFromV77(ctx context.Context, tx *sql.Tx) error {
+	q := `ALTER TABLE storage_buckets_keys ADD COLUMN path_prefix TEXT NOT NULL DEFAULT '';`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding path prefix to storage bucket keys: %w", err)
+	}
+
+	return nil
 }
 
 func updateFromV76(ctx context.Context, tx *sql.Tx) error {
@@ -436,7 +436,8 @@ func (c *ClusterTx) GetStoragePoolBucketKeys(ctx context.Context, bucketID int64
 		storage_buckets_keys.description,
 		storage_buckets_keys.role,
 		storage_buckets_keys.access_key,
-		storage_buckets_keys.secret_key
+		storage_buckets_keys.secret_key,
+		storage_buckets_keys.path_prefix
 	FROM storage_buckets_keys
 	WHERE storage_buckets_keys.storage_bucket_id = ?
 	`)
@@ -472,7 +473,7 @@ func (c *ClusterTx) GetStoragePoolBucketKeys(ctx context.Context, bucketID int64
 	err = query.Scan(ctx, c.Tx(), q.String(), func(scan func(dest ...any) error) error {
 		var bucketKey StorageBucketKey
 
-		err := scan(&bucketKey.ID, &bucketKey.Name, &bucketKey.Description, &bucketKey.Role, &bucketKey.AccessKey, &bucketKey.SecretKey)
+		err := scan(&bucketKey.ID, &bucketKey.Name, &bucketKey.Description, &bucketKey.Role, &bucketKey.AccessKey, &bucketKey.SecretKey, &bucketKey.PathPrefix)
 		if err != nil {
 			return err
 		}
@@ -523,9 +524,9 @@ func (c *ClusterTx) CreateStoragePoolBucketKey(ctx context.Context, bucketID int
 	// Insert a new Storage Bucket Key record.
 	result, err := c.tx.ExecContext(ctx, `
 		INSERT INTO storage_buckets_keys
-		(storage_bucket_id, name, description, role, access_key, secret_key)
-		VALUES (?, ?, ?, ?, ?, ?)
-		`, bucketID, info.Name, info.Description, info.Role, info.AccessKey, info.SecretKey)
+		(storage_bucket_id, name, description, role, access_key, secret_key, path_prefix)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, bucketID, info.Name, info.Description, info.Role, info.AccessKey, info.SecretKey, info.PathPrefix)
 	if err != nil {
 		var cowsqlErr cowsqlDriver.Error
 		// Detect SQLITE_CONSTRAINT_UNIQUE (2067) errors.
@@ -557,9 +558,9 @@ func (c *ClusterTx) UpdateStoragePoolBucketKey(ctx context.Context, bucketID int
 	// Update existing Storage Bucket Key record.
 	res, err := c.tx.ExecContext(ctx, `
 		UPDATE storage_buckets_keys
-		SET description = ?, role = ?, access_key = ?, secret_key = ?
+		SET description = ?, role = ?, access_key = ?, secret_key = ?, path_prefix = ?
 		WHERE storage_bucket_id = ? and id = ?
-		`, info.Description, info.Role, info.AccessKey, info.SecretKey, bucketID, bucketKeyID)
+		`, info.Description, info.Role, info.AccessKey, info.SecretKey, info.PathPrefix, bucketID, bucketKeyID)
 	if err != nil {
 		return err
 	}
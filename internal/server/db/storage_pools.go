@@ -841,6 +841,16 @@ func (c *ClusterTx) UpdateStoragePool(ctx context.Context, poolName, description
 	return c.UpdateStoragePoolConfig(poolID, c.nodeID, poolConfig)
 }
 
+// UpdateStoragePoolDescription updates the description of a storage pool, leaving its config untouched.
+func (c *ClusterTx) UpdateStoragePoolDescription(ctx context.Context, poolName string, description string) error {
+	poolID, _, _, err := c.GetStoragePoolInAnyState(ctx, poolName)
+	if err != nil {
+		return err
+	}
+
+	return updateStoragePoolDescription(c.tx, poolID, description)
+}
+
 // UpdateStoragePoolConfig updates a storage pool config.
 func (c *ClusterTx) UpdateStoragePoolConfig(poolID, nodeID int64, config map[string]string) error {
 	err := clearStoragePoolConfig(c.tx, poolID, nodeID)
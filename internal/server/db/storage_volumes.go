@@ -436,6 +436,22 @@ func (c *ClusterTx) RenameStoragePoolVolume(ctx context.Context, projectName str
 	return nil
 }
 
+// MoveStoragePoolVolumeToProject moves the storage volume attached to a given storage pool to another project.
+func (c *ClusterTx) MoveStoragePoolVolumeToProject(ctx context.Context, projectName string, volumeName string, volumeType int, poolID int64, newProjectName string) error {
+	volume, err := c.GetStoragePoolVolume(ctx, poolID, projectName, volumeType, volumeName, true)
+	if err != nil {
+		return err
+	}
+
+	stmt := "UPDATE storage_volumes SET project_id=(SELECT id FROM projects WHERE name=?) WHERE id=?"
+	_, err = c.tx.ExecContext(ctx, stmt, newProjectName, volume.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateStoragePoolVolume creates a new storage volume attached to a given storage pool.
 func (c *ClusterTx) CreateStoragePoolVolume(ctx context.Context, projectName string, volumeName string, volumeDescription string, volumeType int, poolID int64, volumeConfig map[string]string, contentType int, creationDate time.Time) (int64, error) {
 	var volumeID int64
@@ -122,7 +122,7 @@ func (d *disk) CanMigrate() bool {
 	}
 
 	// Remote disks are migratable.
-	if d.pool != nil && d.pool.Driver().Info().Remote {
+	if d.pool != nil && d.pool.Capabilities().Remote {
 		return true
 	}
 
@@ -814,7 +814,7 @@ func (d *disk) validateConfig(instConf instance.ConfigReader, partialValidation
 			return errors.New("Virtiofs mounts aren't supported with migration.stateful=true")
 		}
 
-		if d.config["path"] != "/" && d.pool != nil && !d.pool.Driver().Info().Remote && util.IsFalseOrEmpty(d.config["dependent"]) {
+		if d.config["path"] != "/" && d.pool != nil && !d.pool.Capabilities().Remote && util.IsFalseOrEmpty(d.config["dependent"]) {
 			return errors.New("Only additional disks coming from a shared storage pool are supported with migration.stateful=true")
 		}
 	}
@@ -2076,7 +2076,7 @@ func (d *disk) mountPoolVolume() (func(), string, *storagePools.MountInfo, error
 	}
 
 	volStorageName := project.StorageVolume(storageProjectName, volName)
-	srcPath := storageDrivers.GetVolumeMountPath(d.config["pool"], storageDrivers.VolumeTypeCustom, volStorageName)
+	srcPath := storageDrivers.GetVolumeMountPath(d.config["pool"], nil, storageDrivers.VolumeTypeCustom, volStorageName)
 
 	mountInfo, err = d.pool.MountCustomVolume(storageProjectName, volName, nil)
 	if err != nil {
@@ -3424,7 +3424,7 @@ func (d *disk) updateDependentConfig() (func() error, error) {
 
 			for _, snap := range snapshots {
 				_, snapName, _ := api.GetParentAndSnapshotName(snap.Name)
-				err = d.pool.CreateCustomVolumeSnapshot(storageProjectName, volName, snapName, snap.ExpiryDate.Time, false, nil)
+				err = d.pool.CreateCustomVolumeSnapshot(storageProjectName, volName, snapName, snap.ExpiryDate.Time, false, false, false, false, nil)
 				if err != nil {
 					return nil, err
 				}
@@ -736,7 +736,7 @@ func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, op *opera
 		return err
 	}
 
-	err = pool.DeleteInstance(inst, op)
+	err = pool.DeleteInstance(inst, false, op)
 	if err != nil {
 		return err
 	}
@@ -748,7 +748,7 @@ func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, op *opera
 			return err
 		}
 	} else {
-		err = pool.CreateInstanceFromImage(inst, img.Fingerprint, op)
+		err = pool.CreateInstanceFromImage(inst, img.Fingerprint, false, op)
 		if err != nil {
 			return err
 		}
@@ -791,7 +791,7 @@ func (d *common) runHooks(hooks []func() error) error {
 }
 
 // snapshot handles the common part of the snapshotting process.
-func (d *common) snapshotCommon(inst instance.Instance, name string, expiry time.Time, stateful bool) error {
+func (d *common) snapshotCommon(inst instance.Instance, name string, expiry time.Time, stateful bool, snapArgs instance.SnapshotArgs) error {
 	reverter := revert.New()
 	defer reverter.Fail()
 
@@ -825,7 +825,7 @@ func (d *common) snapshotCommon(inst instance.Instance, name string, expiry time
 		return err
 	}
 
-	err = pool.CreateInstanceSnapshot(snap, inst, d.op)
+	err = pool.CreateInstanceSnapshot(snap, inst, snapArgs.Force, snapArgs.NoWait, snapArgs.ConfigOverrides, d.op)
 	if err != nil {
 		return fmt.Errorf("Create instance snapshot: %w", err)
 	}
@@ -2689,7 +2689,7 @@ ff02::2 ip6-allrouters
 	}
 
 	if snapName != "" && expiry != nil {
-		err := d.snapshot(snapName, *expiry, false)
+		err := d.snapshot(snapName, *expiry, false, instance.SnapshotArgs{})
 		if err != nil {
 			return "", nil, fmt.Errorf("Failed taking startup snapshot: %w", err)
 		}
@@ -3915,7 +3915,7 @@ func (d *lxc) RenderState(hostInterfaces []net.Interface) (*api.InstanceState, e
 }
 
 // snapshot creates a snapshot of the instance.
-func (d *lxc) snapshot(name string, expiry time.Time, stateful bool) error {
+func (d *lxc) snapshot(name string, expiry time.Time, stateful bool, snapArgs instance.SnapshotArgs) error {
 	// Check that migration.stateful is set for stateful actions.
 	if stateful && !d.CanLiveMigrate() {
 		return errors.New("Stateful snapshots require that the instance has migration.stateful be set to true")
@@ -3997,16 +3997,16 @@ func (d *lxc) snapshot(name string, expiry time.Time, stateful bool) error {
 	// Wait for any file operations to complete to have a more consistent snapshot.
 	d.stopForkfile(false)
 
-	return d.snapshotCommon(d, name, expiry, stateful)
+	return d.snapshotCommon(d, name, expiry, stateful, snapArgs)
 }
 
 // Snapshot takes a new snapshot.
-func (d *lxc) Snapshot(name string, expiry time.Time, stateful bool) error {
-	return d.snapshot(name, expiry, stateful)
+func (d *lxc) Snapshot(name string, expiry time.Time, stateful bool, args instance.SnapshotArgs) error {
+	return d.snapshot(name, expiry, stateful, args)
 }
 
 // Restore restores a snapshot.
-func (d *lxc) Restore(sourceContainer instance.Instance, stateful bool, diskOnly bool) error {
+func (d *lxc) Restore(sourceContainer instance.Instance, stateful bool, diskOnly bool, safetySnapshot bool) error {
 	var ctxMap logger.Ctx
 
 	op, err := operationlock.Create(d.Project().Name, d.Name(), d.op, operationlock.ActionRestore, false, false)
@@ -4123,12 +4123,16 @@ func (d *lxc) Restore(sourceContainer instance.Instance, stateful bool, diskOnly
 	reverter.Success()
 
 	// Restore the rootfs.
-	err = pool.RestoreInstanceSnapshot(d, sourceContainer, nil)
+	safetySnapName, err := pool.RestoreInstanceSnapshot(d, sourceContainer, safetySnapshot, d.op)
 	if err != nil {
 		op.Done(err)
 		return err
 	}
 
+	if safetySnapName != "" && d.op != nil {
+		_ = d.op.ExtendMetadata(map[string]any{"safety_snapshot": safetySnapName})
+	}
+
 	args := db.InstanceArgs{}
 	if !diskOnly {
 		// Restore the configuration.
@@ -4338,7 +4342,7 @@ func (d *lxc) delete(force bool, cleanupDependencies bool) error {
 			}
 
 			// Remove the storage volume and database records.
-			err = pool.DeleteInstance(d, nil)
+			err = pool.DeleteInstance(d, false, nil)
 			if err != nil {
 				return err
 			}
@@ -5831,6 +5835,10 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 	d.logger.Debug("Migration send starting")
 	defer d.logger.Debug("Migration send stopped")
 
+	if args.Live && args.VerifyOnly {
+		return errors.New("Verify only migration cannot be live")
+	}
+
 	// Check for an existing operation.
 
 	// Setup a new operation.
@@ -5897,6 +5905,9 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 	indexHeaderVersion := localMigration.IndexHeaderVersion
 	offerHeader.IndexHeaderVersion = &indexHeaderVersion
 
+	// Indicate whether the target should only verify that it can accept the instance.
+	offerHeader.VerifyOnly = &args.VerifyOnly
+
 	// Add CRIU and predump info to source header.
 	maxDumpIterations := 0
 	if args.Live {
@@ -5982,6 +5993,12 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 
 	d.logger.Debug("Got migration offer response from target")
 
+	if respHeader.GetVerifyOnly() != args.VerifyOnly {
+		err := errors.New("Migration target doesn't support verify only migrations")
+		op.Done(err)
+		return err
+	}
+
 	// Negotiated migration types.
 	migrationTypes, err := localMigration.MatchTypes(respHeader, migration.MigrationFSType_RSYNC, poolMigrationTypes)
 	if err != nil {
@@ -6016,6 +6033,7 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 		ClusterMove:        clusterMove,
 		StorageMove:        storageMove,
 		DependentVolumes:   dependentVolumes,
+		VerifyOnly:         args.VerifyOnly,
 	}
 
 	// Only send the snapshots that the target requests when refreshing.
@@ -6037,7 +6055,7 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 	// should do a two stage transfer to minimize downtime.
 	instanceRunning := args.Live || (respHeader.Criu != nil && *respHeader.Criu == migration.CRIUType_NONE)
 	nonOptimizedMigration := volSourceArgs.MigrationType.FSType == migration.MigrationFSType_RSYNC || volSourceArgs.MigrationType.FSType == migration.MigrationFSType_BLOCK_AND_RSYNC
-	if instanceRunning && nonOptimizedMigration {
+	if instanceRunning && nonOptimizedMigration && !args.VerifyOnly {
 		// Indicate this info to the storage driver so that it can alter its behaviour if needed.
 		volSourceArgs.MultiSync = true
 	}
@@ -6342,7 +6360,9 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 
 		op.Done(nil)
 
-		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceMigrated.Event(d, nil))
+		if !args.VerifyOnly {
+			d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceMigrated.Event(d, nil))
+		}
 
 		return nil
 	}
@@ -6577,6 +6597,14 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 	respHeader.Snapshots = offerHeader.Snapshots
 	respHeader.Refresh = &args.Refresh
 
+	// Both sides must agree on only verifying the migration, as otherwise one of them waits for data
+	// that is never going to be sent.
+	if offerHeader.GetVerifyOnly() != args.VerifyOnly {
+		return fmt.Errorf("Mismatched verify only migration mode (source %v, target %v)", offerHeader.GetVerifyOnly(), args.VerifyOnly)
+	}
+
+	respHeader.VerifyOnly = &args.VerifyOnly
+
 	// Add CRIU info to response.
 	respHeader.Criu = criuType
 
@@ -6623,11 +6651,13 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 		// Compare the two sets.
 		syncSourceSnapshotIndexes, deleteTargetSnapshotIndexes := storagePools.CompareSnapshots(sourceSnapshotComparable, targetSnapshotsComparable, args.RefreshExcludeOlder)
 
-		// Delete the extra local snapshots first.
-		for _, deleteTargetSnapshotIndex := range deleteTargetSnapshotIndexes {
-			err := targetSnapshots[deleteTargetSnapshotIndex].Delete(true, true)
-			if err != nil {
-				return err
+		// Delete the extra local snapshots first (unless only verifying the migration).
+		if !args.VerifyOnly {
+			for _, deleteTargetSnapshotIndex := range deleteTargetSnapshotIndexes {
+				err := targetSnapshots[deleteTargetSnapshotIndex].Delete(true, true)
+				if err != nil {
+					return err
+				}
 			}
 		}
 
@@ -6796,6 +6826,7 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 			ClusterMoveSourceName: args.ClusterMoveSourceName,
 			StoragePool:           args.StoragePool,
 			DependentVolumes:      dependentVolumes,
+			VerifyOnly:            args.VerifyOnly,
 		}
 
 		// At this point we have already figured out the parent container's root
@@ -6820,7 +6851,7 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 
 				// Only create snapshot instance DB records if not doing a cluster same-name move.
 				// As otherwise the DB records will already exist.
-				if args.ClusterMoveSourceName != d.name {
+				if args.ClusterMoveSourceName != d.name && !args.VerifyOnly {
 					snapArgs, err := instance.SnapshotProtobufToInstanceArgs(d.state, d, snap)
 					if err != nil {
 						return err
@@ -6854,7 +6885,12 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 			return fmt.Errorf("Failed creating instance on target: %w", err)
 		}
 
-		isRemoteClusterMove := clusterMove && pool.Driver().Info().Remote
+		// Nothing was transferred, so there is nothing to set up.
+		if args.VerifyOnly {
+			return nil
+		}
+
+		isRemoteClusterMove := clusterMove && pool.Capabilities().Remote
 
 		// Only delete all instance volumes on error if the pool volume creation has succeeded to
 		// avoid deleting an existing conflicting volume.
@@ -6868,7 +6904,7 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 					_ = pool.DeleteInstanceSnapshot(snapshots[k], nil)
 				}
 
-				_ = pool.DeleteInstance(d, nil)
+				_ = pool.DeleteInstance(d, false, nil)
 			})
 		}
 
@@ -9214,7 +9250,7 @@ func (d *lxc) getFSStats() (*metrics.MetricSet, error) {
 			}
 
 			// Check that we have a mountpoint.
-			mountpoint := storageDrivers.GetVolumeMountPath(dev["pool"], volType, volName)
+			mountpoint := storageDrivers.GetVolumeMountPath(dev["pool"], nil, volType, volName)
 			if mountpoint == "" || !util.PathExists(mountpoint) {
 				continue
 			}
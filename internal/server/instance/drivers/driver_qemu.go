@@ -1350,7 +1350,7 @@ func (d *qemu) checkStateStorage() error {
 		return err
 	}
 
-	stateDiskSizeStr := pool.Driver().Info().DefaultVMBlockFilesystemSize
+	stateDiskSizeStr := storageDrivers.DefaultVMBlockFilesystemSize(pool.Driver())
 	if rootDiskDevice["size.state"] != "" {
 		stateDiskSizeStr = rootDiskDevice["size.state"]
 	}
@@ -1750,7 +1750,7 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 	}
 
 	if snapName != "" && expiry != nil {
-		err := d.snapshot(snapName, *expiry, false)
+		err := d.snapshot(snapName, *expiry, false, instance.SnapshotArgs{})
 		if err != nil {
 			err = fmt.Errorf("Failed taking startup snapshot: %w", err)
 			op.Done(err)
@@ -4491,7 +4491,7 @@ func (d *qemu) addRootDriveConfig(qemuDev map[string]any, mountInfo *storagePool
 		return nil, errors.New("Non-root drive config supplied")
 	}
 
-	if !d.storagePool.Driver().Info().Remote && mountInfo.DiskPath == "" {
+	if !d.storagePool.Capabilities().Remote && mountInfo.DiskPath == "" {
 		return nil, errors.New("No root disk path available from mount")
 	}
 
@@ -4505,7 +4505,7 @@ func (d *qemu) addRootDriveConfig(qemuDev map[string]any, mountInfo *storagePool
 		Limits:      rootDriveConf.Limits,
 	}
 
-	if d.storagePool.Driver().Info().Remote {
+	if d.storagePool.Capabilities().Remote {
 		vol := d.storagePool.GetVolume(storageDrivers.VolumeTypeVM, storageDrivers.ContentTypeBlock, project.Instance(d.project.Name, d.name), nil)
 
 		if slices.Contains([]string{"ceph", "cephfs"}, d.storagePool.Driver().Info().Name) {
@@ -5891,7 +5891,7 @@ func (d *qemu) IsPrivileged() bool {
 }
 
 // snapshot creates a snapshot of the instance.
-func (d *qemu) snapshot(name string, expiry time.Time, stateful bool) error {
+func (d *qemu) snapshot(name string, expiry time.Time, stateful bool, snapArgs instance.SnapshotArgs) error {
 	var err error
 	var monitor *qmp.Monitor
 
@@ -5927,8 +5927,14 @@ func (d *qemu) snapshot(name string, expiry time.Time, stateful bool) error {
 	}
 
 	// Create the snapshot.
-	err = d.snapshotCommon(d, name, expiry, stateful)
+	err = d.snapshotCommon(d, name, expiry, stateful, snapArgs)
 	if err != nil {
+		// Don't leave the VM paused if the snapshot failed, for example because another one was in progress.
+		if stateful {
+			_ = os.Remove(d.StatePath())
+			_ = monitor.Start()
+		}
+
 		return err
 	}
 
@@ -5950,12 +5956,12 @@ func (d *qemu) snapshot(name string, expiry time.Time, stateful bool) error {
 }
 
 // Snapshot takes a new snapshot.
-func (d *qemu) Snapshot(name string, expiry time.Time, stateful bool) error {
-	return d.snapshot(name, expiry, stateful)
+func (d *qemu) Snapshot(name string, expiry time.Time, stateful bool, args instance.SnapshotArgs) error {
+	return d.snapshot(name, expiry, stateful, args)
 }
 
 // Restore restores an instance snapshot.
-func (d *qemu) Restore(source instance.Instance, stateful bool, diskOnly bool) error {
+func (d *qemu) Restore(source instance.Instance, stateful bool, diskOnly bool, safetySnapshot bool) error {
 	op, err := operationlock.Create(d.Project().Name, d.Name(), d.op, operationlock.ActionRestore, false, false)
 	if err != nil {
 		return fmt.Errorf("Failed to create instance restore operation: %w", err)
@@ -6037,12 +6043,16 @@ func (d *qemu) Restore(source instance.Instance, stateful bool, diskOnly bool) e
 	d.logger.Info("Restoring instance", ctxMap)
 
 	// Restore the rootfs.
-	err = pool.RestoreInstanceSnapshot(d, source, nil)
+	safetySnapName, err := pool.RestoreInstanceSnapshot(d, source, safetySnapshot, d.op)
 	if err != nil {
 		op.Done(err)
 		return err
 	}
 
+	if safetySnapName != "" && d.op != nil {
+		_ = d.op.ExtendMetadata(map[string]any{"safety_snapshot": safetySnapName})
+	}
+
 	args := db.InstanceArgs{}
 	if !diskOnly {
 		// Restore the configuration.
@@ -7331,7 +7341,7 @@ func (d *qemu) delete(force bool, cleanupDependencies bool) error {
 			}
 
 			// Remove the storage volume and database records.
-			err = pool.DeleteInstance(d, nil)
+			err = pool.DeleteInstance(d, false, nil)
 			if err != nil {
 				return err
 			}
@@ -7600,7 +7610,7 @@ func (d *qemu) Export(metaWriter io.Writer, rootfsWriter io.Writer, properties m
 
 	cmd = append(cmd, mountInfo.DiskPath, fPath)
 
-	_, err = apparmor.QemuImg(d.state.OS, cmd, mountInfo.DiskPath, fPath, tracker)
+	_, err = apparmor.QemuImg(context.Background(), d.state.OS, cmd, mountInfo.DiskPath, fPath, tracker)
 	if err != nil {
 		return nil, fmt.Errorf("Failed converting instance to qcow2: %w", err)
 	}
@@ -7662,6 +7672,10 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 		return errors.New("Live migration requires migration.stateful to be set to true")
 	}
 
+	if args.Live && args.VerifyOnly {
+		return errors.New("Verify only migration cannot be live")
+	}
+
 	// Setup a new operation.
 	op := operationlock.Get(d.Project().Name, d.Name())
 	if op != nil && op.ActionMatch(operationlock.ActionMigrate) {
@@ -7691,7 +7705,7 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 	}
 
 	clusterMove := args.ClusterMoveSourceName != ""
-	remoteClusterMove := clusterMove && pool.Driver().Info().Remote
+	remoteClusterMove := clusterMove && pool.Capabilities().Remote
 	storageMove := args.StoragePool != ""
 
 	// The refresh argument passed to MigrationTypes() is always set
@@ -7714,6 +7728,13 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 	indexHeaderVersion := localMigration.IndexHeaderVersion
 	offerHeader.IndexHeaderVersion = &indexHeaderVersion
 
+	// Offer to skip the zero regions of block volumes.
+	sparseBlocks := true
+	offerHeader.SparseBlocks = &sparseBlocks
+
+	// Indicate whether the target should only verify that it can accept the instance.
+	offerHeader.VerifyOnly = &args.VerifyOnly
+
 	// For VMs, send block device size hint in offer header so that target can create the volume the same size.
 	blockSize, err := storagePools.InstanceDiskBlockSize(pool, d, d.op)
 	if err != nil {
@@ -7793,6 +7814,12 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 
 	d.logger.Debug("Got migration offer response from target")
 
+	if respHeader.GetVerifyOnly() != args.VerifyOnly {
+		err := errors.New("Migration target doesn't support verify only migrations")
+		op.Done(err)
+		return err
+	}
+
 	// Negotiated migration types.
 	migrationTypes, err := localMigration.MatchTypes(respHeader, storagePools.FallbackMigrationType(contentType), poolMigrationTypes)
 	if err != nil {
@@ -7826,6 +7853,8 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 		ClusterMove:        clusterMove,
 		StorageMove:        storageMove,
 		DependentVolumes:   dependentVolumes,
+		SparseBlocks:       respHeader.GetSparseBlocks(),
+		VerifyOnly:         args.VerifyOnly,
 	}
 
 	// Only send the snapshots that the target requests when refreshing.
@@ -7949,7 +7978,9 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 
 		op.Done(nil)
 
-		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceMigrated.Event(d, nil))
+		if !args.VerifyOnly {
+			d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceMigrated.Event(d, nil))
+		}
 
 		return nil
 	}
@@ -8237,7 +8268,7 @@ func (d *qemu) migrateSendLive(ctx context.Context, pool storagePools.Pool, clus
 
 	// If we are performing an intra-cluster member move on a Ceph storage pool without storage change
 	// then we can treat this as shared storage and avoid needing to sync the root disk.
-	sameSharedStorage := clusterMoveSourceName != "" && pool.Driver().Info().Remote && storagePool == ""
+	sameSharedStorage := clusterMoveSourceName != "" && pool.Capabilities().Remote && storagePool == ""
 	disksToMigrate := len(volSourceArgs.DependentVolumes) > 0
 
 	dependentVolumeMove := clusterMoveSourceName != "" && disksToMigrate
@@ -8351,7 +8382,7 @@ func (d *qemu) migrateSendLive(ctx context.Context, pool storagePools.Pool, clus
 			}
 
 			// Check that we're on shared storage.
-			if !diskPool.Driver().Info().Remote {
+			if !diskPool.Capabilities().Remote {
 				continue
 			}
 
@@ -8586,6 +8617,18 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 	respHeader.Snapshots = offerHeader.Snapshots
 	respHeader.Refresh = &args.Refresh
 
+	// Accept skipping the zero regions of block volumes if offered.
+	sparseBlocks := offerHeader.GetSparseBlocks()
+	respHeader.SparseBlocks = &sparseBlocks
+
+	// Both sides must agree on only verifying the migration, as otherwise one of them waits for data
+	// that is never going to be sent.
+	if offerHeader.GetVerifyOnly() != args.VerifyOnly {
+		return fmt.Errorf("Mismatched verify only migration mode (source %v, target %v)", offerHeader.GetVerifyOnly(), args.VerifyOnly)
+	}
+
+	respHeader.VerifyOnly = &args.VerifyOnly
+
 	localDevices := d.localDevices.CloneNative()
 	volumesWithTypes, err := storagePools.DependentVolumesMatchMigrationType(d.state, offerHeader.DependentVolumes, args.Snapshots, localDevices, false)
 	if err != nil {
@@ -8629,11 +8672,13 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 		// Compare the two sets.
 		syncSourceSnapshotIndexes, deleteTargetSnapshotIndexes := storagePools.CompareSnapshots(sourceSnapshotComparable, targetSnapshotsComparable, args.RefreshExcludeOlder)
 
-		// Delete the extra local snapshots first.
-		for _, deleteTargetSnapshotIndex := range deleteTargetSnapshotIndexes {
-			err := targetSnapshots[deleteTargetSnapshotIndex].Delete(true, true)
-			if err != nil {
-				return err
+		// Delete the extra local snapshots first (unless only verifying the migration).
+		if !args.VerifyOnly {
+			for _, deleteTargetSnapshotIndex := range deleteTargetSnapshotIndexes {
+				err := targetSnapshots[deleteTargetSnapshotIndex].Delete(true, true)
+				if err != nil {
+					return err
+				}
 			}
 		}
 
@@ -8782,6 +8827,8 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 			ClusterMoveSourceName: args.ClusterMoveSourceName,
 			StoragePool:           args.StoragePool,
 			DependentVolumes:      dependentVolumes,
+			SparseBlocks:          sparseBlocks,
+			VerifyOnly:            args.VerifyOnly,
 		}
 
 		// At this point we have already figured out the parent instances's root
@@ -8809,7 +8856,7 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 
 				// Only create snapshot instance DB records if not doing a cluster same-name move.
 				// As otherwise the DB records will already exist.
-				if args.ClusterMoveSourceName != d.name {
+				if args.ClusterMoveSourceName != d.name && !args.VerifyOnly {
 					snapArgs, err := instance.SnapshotProtobufToInstanceArgs(d.state, d, snap)
 					if err != nil {
 						return err
@@ -8849,11 +8896,16 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 			return fmt.Errorf("Failed creating instance on target: %w", err)
 		}
 
+		// Nothing was transferred, so there is nothing to set up.
+		if args.VerifyOnly {
+			return nil
+		}
+
 		isRemoteClusterMove := clusterMove && poolInfo.Remote
 		reverter.Add(func() {
 			// Delete the instance unless it is moved within the same cluster on a shared pool.
 			if (!isRemoteClusterMove && !storageMove) || storageMove {
-				_ = pool.DeleteInstance(d, d.op)
+				_ = pool.DeleteInstance(d, false, d.op)
 			}
 		})
 
@@ -8878,7 +8930,7 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 				}
 
 				// Check that we're on shared storage.
-				if !diskPool.Driver().Info().Remote {
+				if !diskPool.Capabilities().Remote {
 					continue
 				}
 
@@ -8910,7 +8962,7 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 					_ = pool.DeleteInstanceSnapshot(snapshots[k], nil)
 				}
 
-				_ = pool.DeleteInstance(d, nil)
+				_ = pool.DeleteInstance(d, false, nil)
 			})
 		}
 
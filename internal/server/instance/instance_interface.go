@@ -102,8 +102,8 @@ type Instance interface {
 	ForEachDependentDiskType(diskAction func(dev deviceConfig.DeviceNamed) error) error
 
 	// Snapshots & migration & backups.
-	Restore(source Instance, stateful bool, diskOnly bool) error
-	Snapshot(name string, expiry time.Time, stateful bool) error
+	Restore(source Instance, stateful bool, diskOnly bool, safetySnapshot bool) error
+	Snapshot(name string, expiry time.Time, stateful bool, args SnapshotArgs) error
 	Snapshots() ([]Instance, error)
 	Backups() ([]backup.InstanceBackup, error)
 	UpdateBackupFile() error
@@ -246,6 +246,13 @@ type Info struct {
 	Features map[string]any    // Map of supported features.
 }
 
+// SnapshotArgs represent optional arguments for instance snapshot creation.
+type SnapshotArgs struct {
+	Force           bool              // Skip the storage pool's snapshots.reserve_percent check.
+	ConfigOverrides map[string]string // Storage volume config to set on the snapshot volume.
+	NoWait          bool              // Fail rather than wait when another snapshot of the instance is in progress.
+}
+
 // MigrateArgs represent arguments for instance migration send and receive.
 type MigrateArgs struct {
 	ControlSend           func(m proto.Message) error
@@ -257,6 +264,7 @@ type MigrateArgs struct {
 	Disconnect            func()
 	ClusterMoveSourceName string // Will be empty if not a cluster move, othwise indicates the source instance.
 	StoragePool           string
+	VerifyOnly            bool // Only check that the target can accept the instance, without transferring it.
 }
 
 // MigrateSendArgs represent arguments for instance migration send.
@@ -103,6 +103,8 @@ const (
 	WarningsTotal
 	// UptimeSeconds represents the daemon uptime in seconds.
 	UptimeSeconds
+	// StorageImageCreatesTotal represents the number of instance volumes created from images.
+	StorageImageCreatesTotal
 	// ProjectResourcesTotal represents the current resource count in a project.
 	ProjectResourcesTotal
 	// ProjectLimit represents the current project resource limit.
@@ -225,6 +227,7 @@ var MetricNames = map[MetricType]string{
 	ProjectLimit:                "incus_project_limit",
 	ProjectResourcesTotal:       "incus_project_resources_total",
 	ProjectUsage:                "incus_project_usage",
+	StorageImageCreatesTotal:    "incus_storage_image_creates_total",
 	TimeSeconds:                 "incus_time_seconds",
 	UptimeSeconds:               "incus_uptime_seconds",
 	WarningsTotal:               "incus_warnings_total",
@@ -298,6 +301,7 @@ var MetricHeaders = map[MetricType]string{
 	ProjectLimit:                "# HELP incus_project_limit Current project resource limit.",
 	ProjectResourcesTotal:       "# HELP incus_project_resources_total Current resource count in a project.",
 	ProjectUsage:                "# HELP incus_project_usage Current project resource usage.",
+	StorageImageCreatesTotal:    "# HELP incus_storage_image_creates_total The number of instance volumes created from images, by pool and outcome.",
 	TimeSeconds:                 "# HELP incus_time_seconds The current unix epoch.",
 	UptimeSeconds:               "# HELP incus_uptime_seconds The daemon uptime in seconds.",
 	WarningsTotal:               "# HELP incus_warnings_total The number of active warnings.",
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"slices"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
 
@@ -13,7 +14,6 @@ import (
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/ioprogress"
-	"github.com/lxc/incus/v7/shared/units"
 )
 
 // Info represents the index frame sent if supported.
@@ -28,7 +28,8 @@ type Info struct {
 type InfoResponse struct {
 	StatusCode int
 	Error      string
-	Refresh    *bool // This is used to let the source know whether to actually refresh a volume.
+	Refresh    *bool    // This is used to let the source know whether to actually refresh a volume.
+	Warnings   []string // Compatibility warnings reported by the target during a verify only migration.
 }
 
 // Err returns the error of the response.
@@ -76,6 +77,8 @@ type VolumeSourceArgs struct {
 	ClusterMove        bool
 	StorageMove        bool
 	DependentVolumes   []DependentVolumeArgs
+	VerifyOnly         bool // Only negotiate and validate the migration, don't transfer any data.
+	SparseBlocks       bool // Whether only the non-zero regions of block volumes are sent.
 }
 
 // VolumeTargetArgs represents the arguments needed to setup a volume migration sink.
@@ -84,6 +87,7 @@ type VolumeTargetArgs struct {
 	Name                  string
 	Description           string
 	Config                map[string]string // Only used for custom volume migration.
+	TargetConfigOverrides map[string]string // Only used for instance migration, applied over the source volume config.
 	Snapshots             []*migration.Snapshot
 	MigrationType         Type
 	TrackProgress         bool
@@ -96,6 +100,8 @@ type VolumeTargetArgs struct {
 	ClusterMoveSourceName string
 	StoragePool           string
 	DependentVolumes      []DependentVolumeArgs
+	VerifyOnly            bool // Only negotiate and validate the migration, don't transfer any data.
+	SparseBlocks          bool // Whether only the non-zero regions of block volumes are received.
 }
 
 // TypesToHeader converts one or more Types to a MigrationHeader. It uses the first type argument
@@ -349,17 +355,22 @@ func VolumeSnapshotToProtobuf(vol *api.StorageVolumeSnapshot) *migration.Snapsho
 }
 
 func progressWrapperRender(op *operations.Operation, key string, description string, progressInt int64, speedInt int64) {
+	if op == nil {
+		return
+	}
+
+	// The stage is the transfer phase (such as "fs" or "block"), the ioprogress tracker already limits
+	// how often this is called.
 	meta := map[string]any{}
+	operations.SetProgressMetadata(meta, strings.TrimSuffix(key, "_progress"), description, 0, progressInt, speedInt)
 
-	progress := fmt.Sprintf("%s (%s/s)", units.GetByteSizeString(progressInt, 2), units.GetByteSizeString(speedInt, 2))
-	if description != "" {
-		progress = fmt.Sprintf("%s: %s (%s/s)", description, units.GetByteSizeString(progressInt, 2), units.GetByteSizeString(speedInt, 2))
+	// Also report the volume or snapshot being transferred.
+	progress, ok := meta["progress"].(map[string]string)
+	if ok && description != "" {
+		progress["volume"] = description
 	}
 
-	if meta[key] != progress {
-		meta[key] = progress
-		_ = op.ExtendMetadata(meta)
-	}
+	_ = op.ExtendMetadata(meta)
 }
 
 // ProgressReader reports the read progress.
@@ -0,0 +1,138 @@
+package migration
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// sparseBlockSize is the granularity at which zero regions are detected when sending a sparse block stream.
+const sparseBlockSize = 64 * 1024
+
+// sparseHeaderSize is the size of the header preceding each extent of a sparse block stream.
+const sparseHeaderSize = 12
+
+// SendSparseBlocks reads a block volume from r and writes only its non-zero regions to w.
+// Each extent is sent as a big-endian 8-byte offset and 4-byte length followed by the data, and the stream
+// ends with an empty extent whose offset is the total size of the volume.
+func SendSparseBlocks(w io.Writer, r io.Reader) error {
+	buf := make([]byte, sparseHeaderSize+sparseBlockSize)
+	var offset uint64
+
+	for {
+		n, err := io.ReadFull(r, buf[sparseHeaderSize:])
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		if n > 0 && !isZero(buf[sparseHeaderSize:sparseHeaderSize+n]) {
+			binary.BigEndian.PutUint64(buf[0:8], offset)
+			binary.BigEndian.PutUint32(buf[8:12], uint32(n))
+
+			_, err := w.Write(buf[:sparseHeaderSize+n])
+			if err != nil {
+				return err
+			}
+		}
+
+		offset += uint64(n)
+
+		if n < sparseBlockSize {
+			break
+		}
+	}
+
+	// Indicate the end of the volume.
+	binary.BigEndian.PutUint64(buf[0:8], offset)
+	binary.BigEndian.PutUint32(buf[8:12], 0)
+
+	_, err := w.Write(buf[:sparseHeaderSize])
+
+	return err
+}
+
+// ReceiveSparseBlocks reads a stream generated by SendSparseBlocks from r and writes the extents to w.
+// The regions that were skipped by the sender are either seeked over, if w is known to be zeroed already,
+// or explicitly filled with zeroes when writeZeroes is set. It returns the total size of the volume.
+func ReceiveSparseBlocks(w io.WriteSeeker, r io.Reader, writeZeroes bool) (int64, error) {
+	header := make([]byte, sparseHeaderSize)
+	buf := make([]byte, sparseBlockSize)
+	var pos uint64
+
+	// Move to the given offset, taking care of the skipped region.
+	skipTo := func(offset uint64) error {
+		if offset < pos {
+			return fmt.Errorf("Invalid sparse extent offset %d before current position %d", offset, pos)
+		}
+
+		if !writeZeroes {
+			_, err := w.Seek(int64(offset), io.SeekStart)
+			if err != nil {
+				return err
+			}
+
+			pos = offset
+			return nil
+		}
+
+		clear(buf)
+		for pos < offset {
+			n, err := w.Write(buf[:min(uint64(len(buf)), offset-pos)])
+			if err != nil {
+				return err
+			}
+
+			pos += uint64(n)
+		}
+
+		return nil
+	}
+
+	for {
+		_, err := io.ReadFull(r, header)
+		if err != nil {
+			return -1, fmt.Errorf("Failed reading sparse extent header: %w", err)
+		}
+
+		offset := binary.BigEndian.Uint64(header[0:8])
+		length := binary.BigEndian.Uint32(header[8:12])
+
+		err = skipTo(offset)
+		if err != nil {
+			return -1, err
+		}
+
+		// An empty extent marks the end of the volume.
+		if length == 0 {
+			return int64(pos), nil
+		}
+
+		if length > sparseBlockSize {
+			return -1, fmt.Errorf("Invalid sparse extent length %d", length)
+		}
+
+		_, err = io.ReadFull(r, buf[:length])
+		if err != nil {
+			return -1, fmt.Errorf("Failed reading sparse extent: %w", err)
+		}
+
+		_, err = w.Write(buf[:length])
+		if err != nil {
+			return -1, err
+		}
+
+		pos += uint64(length)
+	}
+}
+
+// isZero returns whether all bytes of p are zero.
+func isZero(p []byte) bool {
+	for _, v := range p {
+		if v != 0 {
+			return false
+		}
+	}
+
+	return true
+}
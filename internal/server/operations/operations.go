@@ -117,6 +117,9 @@ type Operation struct {
 	// Indicates if operation has finished.
 	finished *cancel.Canceller
 
+	// Indicates if cancellation of the operation was requested.
+	cancelled *cancel.Canceller
+
 	// Locking for concurrent access to the Operation
 	lock sync.Mutex
 
@@ -146,6 +149,7 @@ func OperationCreate(s *state.State, projectName string, opClass OperationClass,
 	op.url = fmt.Sprintf("/%s/operations/%s", version.APIVersion, op.id)
 	op.resources = opResources
 	op.finished = cancel.New(context.Background())
+	op.cancelled = cancel.New(context.Background())
 	op.state = s
 	op.logger = logger.AddContext(logger.Ctx{"operation": op.id, "project": op.projectName, "class": op.class.String(), "description": op.description})
 
@@ -389,6 +393,9 @@ func (op *Operation) Cancel() (chan error, error) {
 				return
 			}
 
+			// Only signal the cancellation once the operation agreed to it.
+			op.cancelled.Cancel()
+
 			op.lock.Lock()
 			op.status = api.Cancelled
 			op.lock.Unlock()
@@ -416,6 +423,8 @@ func (op *Operation) Cancel() (chan error, error) {
 	}
 
 	if !hasOnCancel {
+		op.cancelled.Cancel()
+
 		op.lock.Lock()
 		op.status = api.Cancelled
 		op.lock.Unlock()
@@ -687,6 +696,15 @@ func (op *Operation) Resources() map[string][]api.URL {
 	return op.resources
 }
 
+// CancelContext returns a context that is cancelled once cancellation of the operation is requested.
+func (op *Operation) CancelContext() context.Context {
+	if op.cancelled == nil {
+		return context.Background()
+	}
+
+	return op.cancelled
+}
+
 // SetCanceler sets a canceler.
 func (op *Operation) SetCanceler(canceler *cancel.HTTPRequestCanceller) {
 	op.canceler = canceler
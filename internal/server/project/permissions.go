@@ -1333,6 +1333,9 @@ type projectInfo struct {
 	Profiles  []api.Profile
 	Instances []api.Instance
 	Volumes   []db.StorageVolumeArgs
+
+	// VMStateSizes holds the volume.vm.state_size setting of the storage pools that set it, keyed on pool name.
+	VMStateSizes map[string]string
 }
 
 // Fetch the given project from the database along with its profiles, instances
@@ -1419,11 +1422,24 @@ func fetchProject(tx *db.ClusterTx, projectName string, skipIfNoLimits bool) (*p
 		return nil, fmt.Errorf("Fetch project custom volumes from database: %w", err)
 	}
 
+	pools, _, err := tx.GetStoragePools(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Fetch storage pools from database: %w", err)
+	}
+
+	vmStateSizes := map[string]string{}
+	for _, pool := range pools {
+		if pool.Config["volume.vm.state_size"] != "" {
+			vmStateSizes[pool.Name] = pool.Config["volume.vm.state_size"]
+		}
+	}
+
 	info := &projectInfo{
-		Project:   *project,
-		Profiles:  profiles,
-		Instances: instances,
-		Volumes:   volumes,
+		Project:      *project,
+		Profiles:     profiles,
+		Instances:    instances,
+		Volumes:      volumes,
+		VMStateSizes: vmStateSizes,
 	}
 
 	return info, nil
@@ -1495,7 +1511,7 @@ func getTotalsAcrossProjectEntities(info *projectInfo, keys []string, skipUnset
 	}
 
 	for _, inst := range info.Instances {
-		limits, err := getInstanceLimits(inst, keys, skipUnset)
+		limits, err := getInstanceLimits(inst, keys, skipUnset, info.VMStateSizes)
 		if err != nil {
 			return nil, err
 		}
@@ -1509,7 +1525,8 @@ func getTotalsAcrossProjectEntities(info *projectInfo, keys []string, skipUnset
 }
 
 // Return the effective instance-level values for the limits with the given keys.
-func getInstanceLimits(inst api.Instance, keys []string, skipUnset bool) (map[string]int64, error) {
+// The vmStateSizes map holds the volume.vm.state_size setting of the storage pools, keyed on pool name.
+func getInstanceLimits(inst api.Instance, keys []string, skipUnset bool, vmStateSizes map[string]string) (map[string]int64, error) {
 	var err error
 	limits := map[string]int64{}
 
@@ -1562,9 +1579,13 @@ func getInstanceLimits(inst api.Instance, keys []string, skipUnset bool) (map[st
 			if inst.Type == instancetype.VM.String() {
 				sizeStateValue, ok := device["size.state"]
 				if !ok {
+					// Use the pool's default state size if set.
 					// TODO: In case the VMs storage drivers config drive size isn't the default,
 					// the limits accounting will be incorrect.
-					sizeStateValue = deviceconfig.DefaultVMBlockFilesystemSize
+					sizeStateValue = vmStateSizes[device["pool"]]
+					if sizeStateValue == "" {
+						sizeStateValue = deviceconfig.DefaultVMBlockFilesystemSize
+					}
 				}
 
 				sizeStateLimit, err := parser(sizeStateValue)
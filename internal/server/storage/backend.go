@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"crypto/rand"
 	"database/sql"
@@ -18,13 +20,17 @@ import (
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/google/uuid"
 	"go.yaml.in/yaml/v4"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sys/unix"
 
 	incus "github.com/lxc/incus/v7/client"
 	internalInstance "github.com/lxc/incus/v7/internal/instance"
@@ -33,6 +39,7 @@ import (
 	"github.com/lxc/incus/v7/internal/linux"
 	"github.com/lxc/incus/v7/internal/migration"
 	"github.com/lxc/incus/v7/internal/rsync"
+	"github.com/lxc/incus/v7/internal/server/auth"
 	"github.com/lxc/incus/v7/internal/server/backup"
 	backupConfig "github.com/lxc/incus/v7/internal/server/backup/config"
 	"github.com/lxc/incus/v7/internal/server/cluster/request"
@@ -51,12 +58,14 @@ import (
 	"github.com/lxc/incus/v7/internal/server/storage/drivers"
 	"github.com/lxc/incus/v7/internal/server/storage/memorypipe"
 	"github.com/lxc/incus/v7/internal/server/storage/s3"
+	"github.com/lxc/incus/v7/internal/server/storage/s3/local"
 	localUtil "github.com/lxc/incus/v7/internal/server/util"
 	internalUtil "github.com/lxc/incus/v7/internal/util"
 	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/archive"
 	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/osarch"
 	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/units"
 	"github.com/lxc/incus/v7/shared/util"
@@ -67,6 +76,18 @@ var (
 	unavailablePoolsMu = sync.Mutex{}
 )
 
+// bucketMount tracks the mounts of a local bucket volume held by the in-process S3 server.
+type bucketMount struct {
+	projectName string
+	bucketName  string
+	refs        int
+}
+
+var (
+	bucketMounts   = make(map[string]map[string]*bucketMount) // Keyed by pool name and bucket volume name.
+	bucketMountsMu = sync.Mutex{}
+)
+
 // ConnectIfInstanceIsRemote is a reference to cluster.ConnectIfInstanceIsRemote.
 //
 //nolint:typecheck
@@ -79,6 +100,38 @@ var instanceDiskVolumeEffectiveFields = []string{
 	"size.state",
 }
 
+// snapshotFixedVolumeFields are volume config keys which can't differ between a snapshot and its parent volume.
+var snapshotFixedVolumeFields = []string{
+	"block.create_options",
+	"block.filesystem",
+	"block.mount_options",
+	"block.type",
+	"initial.gid",
+	"initial.mode",
+	"initial.uid",
+	"lvm.stripes",
+	"lvm.stripes.size",
+	"security.shared",
+	"security.shifted",
+	"security.unmapped",
+	"size",
+	"size.state",
+	"truenas.blocksize",
+	"truenas.use_refquota",
+	"zfs.block_mode",
+	"zfs.blocksize",
+	"zfs.delegate",
+	"zfs.reserve_space",
+	"zfs.use_refquota",
+}
+
+// migrationFixedVolumeFields are volume config keys which can't be overridden on the target of an instance migration.
+var migrationFixedVolumeFields = []string{
+	"block.type",
+	"size",
+	"size.state",
+}
+
 type backend struct {
 	driver drivers.Driver
 	id     int64
@@ -143,6 +196,86 @@ func (b *backend) isStatusReady() error {
 	return nil
 }
 
+// operationLock acquires the named operation lock, waiting at most for the pool's operation.lock_timeout.
+func (b *backend) operationLock(lockName string) (locking.UnlockFunc, error) {
+	ctx := context.Background()
+
+	if b.db.Config["operation.lock_timeout"] != "" {
+		timeout, err := time.ParseDuration(b.db.Config["operation.lock_timeout"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid operation.lock_timeout: %w", err)
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	unlock, err := locking.Lock(ctx, lockName)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, api.StatusErrorf(http.StatusServiceUnavailable, "Timed out waiting for concurrent operation")
+		}
+
+		return nil, err
+	}
+
+	return unlock, nil
+}
+
+// transferLimiter bounds the number of concurrent cross-pool transfers into a pool.
+type transferLimiter struct {
+	limit int
+	slots chan struct{}
+}
+
+var (
+	transferLimiters   = make(map[string]*transferLimiter)
+	transferLimitersMu = sync.Mutex{}
+)
+
+// acquireTransferSlot waits for one of the cross-pool transfer slots of the pool, as set by transfers.max_concurrent,
+// and returns a function to release it. Transfers aren't limited if the setting is empty.
+// Waiting for a slot stops when the operation is cancelled.
+func (b *backend) acquireTransferSlot(op *operations.Operation) (func(), error) {
+	if b.db.Config["transfers.max_concurrent"] == "" {
+		return func() {}, nil
+	}
+
+	limit, err := strconv.Atoi(b.db.Config["transfers.max_concurrent"])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid transfers.max_concurrent value: %w", err)
+	}
+
+	transferLimitersMu.Lock()
+	limiter := transferLimiters[b.name]
+
+	// Start a new limiter when the setting changes, running transfers release their slot on the old one.
+	if limiter == nil || limiter.limit != limit {
+		limiter = &transferLimiter{limit: limit, slots: make(chan struct{}, limit)}
+		transferLimiters[b.name] = limiter
+	}
+
+	transferLimitersMu.Unlock()
+
+	if len(limiter.slots) == cap(limiter.slots) {
+		b.logger.Debug("Waiting for a free cross-pool transfer slot", logger.Ctx{"limit": limit})
+	}
+
+	ctx := context.Background()
+	if op != nil {
+		ctx = op.CancelContext()
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("Cancelled while waiting for a cross-pool transfer slot: %w", ctx.Err())
+	}
+
+	return func() { <-limiter.slots }, nil
+}
+
 // ToAPI returns the storage pool as an API representation.
 func (b *backend) ToAPI() api.StoragePool {
 	return b.db
@@ -153,6 +286,32 @@ func (b *backend) Driver() drivers.Driver {
 	return b.driver
 }
 
+// Capabilities returns the features supported by the storage pool.
+func (b *backend) Capabilities() Capabilities {
+	info := b.driver.Info()
+
+	// Volumes on a filesystem can be shrunk by lowering their quota, block backed ones depend on the filesystem.
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentTypeFS, "", nil)
+	shrinkable := !vol.IsBlockBacked() || drivers.FilesystemTypeCanBeShrunk(vol.ConfigBlockFilesystem())
+
+	return Capabilities{
+		Buckets:           info.Buckets,
+		Snapshots:         info.Snapshots,
+		OptimizedImages:   b.optimizedImagesEnabled(),
+		Remote:            info.Remote,
+		Shrinkable:        shrinkable,
+		RunningCopyFreeze: info.RunningCopyFreeze,
+		Delegation:        info.Delegation,
+		Encryption:        info.Encryption,
+	}
+}
+
+// optimizedImagesEnabled returns whether images are stored as optimized volumes on the pool.
+// This requires driver support and can be turned off with the pool's images.optimized setting.
+func (b *backend) optimizedImagesEnabled() bool {
+	return b.driver.Info().OptimizedImages && util.IsTrueOrEmpty(b.db.Config["images.optimized"])
+}
+
 // MigrationTypes returns the migration transport method preferred when sending a migration, based
 // on the migration method requested by the driver's ability. The copySnapshots argument indicates
 // whether snapshots are migrated as well. clusterMove determines whether the migration is done
@@ -162,6 +321,17 @@ func (b *backend) MigrationTypes(contentType drivers.ContentType, refresh bool,
 	return b.driver.MigrationTypes(contentType, refresh, copySnapshots, clusterMove, storageMove)
 }
 
+// EffectiveMigrationTypes negotiates the migration type that would be used to transfer a volume from srcPool
+// to this pool and returns a human-readable description of it.
+func (b *backend) EffectiveMigrationTypes(srcPool Pool, contentType drivers.ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) (string, error) {
+	migrationType, err := negotiateMigrationType(srcPool, b, contentType, refresh, copySnapshots, clusterMove, storageMove)
+	if err != nil {
+		return "", err
+	}
+
+	return MigrationTypeDescription(migrationType, refresh), nil
+}
+
 // Create creates the storage pool layout on the storage device.
 // localOnly is used for clustering where only a single node should do remote storage setup.
 func (b *backend) Create(clientType request.ClientType, op *operations.Operation) error {
@@ -241,6 +411,32 @@ func (b *backend) GetVolume(volType drivers.VolumeType, contentType drivers.Cont
 	return drivers.NewVolume(b.driver, b.name, volType, contentType, volName, volConfig, b.db.Config).Clone()
 }
 
+// VolumeConfigDefaults returns the effective config a new volume of the given type would get on this pool.
+func (b *backend) VolumeConfigDefaults(volType drivers.VolumeType, contentType drivers.ContentType) (map[string]string, error) {
+	vol := b.GetVolume(volType, contentType, "", nil)
+
+	err := b.driver.FillVolumeConfig(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	config := vol.Config()
+
+	// The default size isn't stored in the config but is applied when creating the volume.
+	if config["size"] == "" && vol.ConfigSize() != "" {
+		config["size"] = vol.ConfigSize()
+	}
+
+	// Add the driver's recommendations for the content type where the pool doesn't set a value.
+	for k, v := range b.driver.RecommendedVolumeConfig(vol) {
+		if config[k] == "" {
+			config[k] = v
+		}
+	}
+
+	return config, nil
+}
+
 // GetResources returns utilisation information about the pool.
 func (b *backend) GetResources() (*api.ResourcesStoragePool, error) {
 	l := b.logger.AddContext(nil)
@@ -254,6 +450,101 @@ func (b *backend) GetResources() (*api.ResourcesStoragePool, error) {
 	return b.driver.GetResources()
 }
 
+// checkSnapshotReserve returns an error if the existing snapshots of the volume already use the share of
+// the pool's capacity allowed by the pool's snapshots.reserve_percent setting.
+func (b *backend) checkSnapshotReserve(projectName string, volName string, volType drivers.VolumeType, contentType drivers.ContentType) error {
+	if b.db.Config["snapshots.reserve_percent"] == "" {
+		return nil
+	}
+
+	reservePercent, err := strconv.ParseInt(b.db.Config["snapshots.reserve_percent"], 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid snapshots.reserve_percent value: %w", err)
+	}
+
+	if reservePercent <= 0 {
+		return nil
+	}
+
+	res, err := b.GetResources()
+	if err != nil {
+		if errors.Is(err, drivers.ErrNotSupported) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed getting pool resources: %w", err)
+	}
+
+	if res.Space.Total == 0 {
+		return nil
+	}
+
+	dbSnapshots, err := VolumeDBSnapshotsGet(b, projectName, volName, volType)
+	if err != nil {
+		return err
+	}
+
+	if len(dbSnapshots) == 0 {
+		return nil
+	}
+
+	storageName := func(name string) string {
+		if volType == drivers.VolumeTypeCustom {
+			return project.StorageVolume(projectName, name)
+		}
+
+		return project.Instance(projectName, name)
+	}
+
+	// Cache the usage of all of the volume's snapshots, so that the loop below stays cheap.
+	parentVol := b.GetVolume(volType, contentType, storageName(volName), nil)
+	err = b.driver.CacheVolumeSnapshots(parentVol)
+	if err != nil {
+		return err
+	}
+
+	if parentVol.IsVMBlock() {
+		err = b.driver.CacheVolumeSnapshots(parentVol.NewVMBlockFilesystemVolume())
+		if err != nil {
+			return err
+		}
+	}
+
+	var used uint64
+	for _, dbSnapshot := range dbSnapshots {
+		// There's no need to pass config as it's not needed when retrieving the volume usage.
+		snapVol := b.GetVolume(volType, contentType, storageName(dbSnapshot.Name), nil)
+
+		vols := []drivers.Volume{snapVol}
+		if snapVol.IsVMBlock() {
+			vols = append(vols, snapVol.NewVMBlockFilesystemVolume())
+		}
+
+		for _, vol := range vols {
+			size, err := b.driver.GetVolumeUsage(vol)
+			if err != nil {
+				// The usage of the snapshots can't be measured, so there is nothing to enforce.
+				if errors.Is(err, drivers.ErrNotSupported) {
+					return nil
+				}
+
+				return fmt.Errorf("Failed getting usage of snapshot %q: %w", dbSnapshot.Name, err)
+			}
+
+			if size > 0 {
+				used += uint64(size)
+			}
+		}
+	}
+
+	limit := res.Space.Total * uint64(reservePercent) / 100
+	if used >= limit {
+		return api.StatusErrorf(http.StatusInsufficientStorage, "Snapshots of volume %q already use %s, exceeding the %s (%d%% of storage pool %q capacity) allowed by snapshots.reserve_percent", volName, units.GetByteSizeStringIEC(int64(used), 2), units.GetByteSizeStringIEC(int64(limit), 2), reservePercent, b.name)
+	}
+
+	return nil
+}
+
 // IsUsed returns whether the storage pool is used by any volumes or profiles (excluding image volumes).
 func (b *backend) IsUsed() (bool, error) {
 	usedBy, err := UsedBy(context.TODO(), b.state, b, true, true, db.StoragePoolVolumeTypeNameImage)
@@ -283,26 +574,14 @@ func (b *backend) Update(clientType request.ClientType, newDesc string, newConfi
 	// Diff the configurations.
 	changedConfig, userOnly := b.detectChangedConfig(b.db.Config, newConfig)
 
-	// Check if the pool source is being changed that the local state is still pending, otherwise prevent it.
-	_, sourceChanged := changedConfig["source"]
-	if sourceChanged && b.LocalStatus() != api.StoragePoolStatusPending {
-		return errors.New("Pool source cannot be changed when not in pending state")
-	}
-
-	// Prevent shrinking the storage pool.
-	newSize, sizeChanged := changedConfig["size"]
-	if sizeChanged && newSize != "" && newSize != drivers.MaxValue {
-		oldSizeBytes, _ := units.ParseByteSizeString(b.db.Config["size"])
-		newSizeBytes, _ := units.ParseByteSizeString(newSize)
-
-		if newSizeBytes < oldSizeBytes {
-			return errors.New("Pool cannot be shrunk")
-		}
+	err = b.checkUpdate(changedConfig)
+	if err != nil {
+		return err
 	}
 
 	// Apply changes to local member if both global pool and node are not pending and non-user config changed.
 	// Otherwise just apply changes to DB (below) ready for the actual global create request to be initiated.
-	if len(changedConfig) > 0 && b.Status() != api.StoragePoolStatusPending && b.LocalStatus() != api.StoragePoolStatusPending && !userOnly {
+	if b.needsDriverUpdate(changedConfig, userOnly) {
 		err = b.driver.Update(changedConfig)
 		if err != nil {
 			return err
@@ -326,6 +605,101 @@ func (b *backend) Update(clientType request.ClientType, newDesc string, newConfi
 	return nil
 }
 
+// PreviewUpdate reports what Update would do with the given description and config, without applying anything.
+func (b *backend) PreviewUpdate(newDesc string, newConfig map[string]string) (*api.StoragePoolUpdatePreview, error) {
+	l := b.logger.AddContext(logger.Ctx{"newDesc": newDesc, "newConfig": newConfig})
+	l.Debug("PreviewUpdate started")
+	defer l.Debug("PreviewUpdate finished")
+
+	changedConfig, userOnly := b.detectChangedConfig(b.db.Config, newConfig)
+
+	preview := &api.StoragePoolUpdatePreview{
+		ChangedConfig:      changedConfig,
+		UserOnly:           userOnly,
+		DescriptionChanged: newDesc != b.db.Description,
+	}
+
+	// Report validation and guardrail failures as a rejection rather than an error.
+	err := b.driver.Validate(newConfig)
+	if err == nil {
+		err = b.checkUpdate(changedConfig)
+	}
+
+	if err != nil {
+		preview.Rejected = true
+		preview.RejectedReason = err.Error()
+
+		return preview, nil
+	}
+
+	preview.DriverUpdate = b.needsDriverUpdate(changedConfig, userOnly)
+
+	return preview, nil
+}
+
+// checkUpdate checks that the changed pool config is allowed.
+func (b *backend) checkUpdate(changedConfig map[string]string) error {
+	// Check if the pool source is being changed that the local state is still pending, otherwise prevent it.
+	_, sourceChanged := changedConfig["source"]
+	if sourceChanged && b.LocalStatus() != api.StoragePoolStatusPending {
+		return errors.New("Pool source cannot be changed when not in pending state")
+	}
+
+	// The snapshot directory layout can't be changed once volumes may have been created.
+	_, snapshotsLayoutChanged := changedConfig["snapshots.layout"]
+	if snapshotsLayoutChanged && b.LocalStatus() != api.StoragePoolStatusPending {
+		return errors.New("Pool snapshots.layout cannot be changed when not in pending state")
+	}
+
+	// Prevent shrinking the storage pool.
+	newSize, sizeChanged := changedConfig["size"]
+	if sizeChanged && newSize != "" && newSize != drivers.MaxValue {
+		oldSizeBytes, _ := units.ParseByteSizeString(b.db.Config["size"])
+		newSizeBytes, _ := units.ParseByteSizeString(newSize)
+
+		if newSizeBytes < oldSizeBytes {
+			return errors.New("Pool cannot be shrunk")
+		}
+	}
+
+	return nil
+}
+
+// needsDriverUpdate returns whether the changed pool config must be applied by the storage driver on this member.
+// This is the case when non-user config changed and neither the global pool nor the local member are pending.
+func (b *backend) needsDriverUpdate(changedConfig map[string]string, userOnly bool) bool {
+	return len(changedConfig) > 0 && b.Status() != api.StoragePoolStatusPending && b.LocalStatus() != api.StoragePoolStatusPending && !userOnly
+}
+
+// SetDescription updates only the pool description, without going through the driver.
+func (b *backend) SetDescription(newDesc string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"newDesc": newDesc})
+	l.Debug("SetDescription started")
+	defer l.Debug("SetDescription finished")
+
+	if newDesc == b.db.Description {
+		return nil
+	}
+
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateStoragePoolDescription(ctx, b.name, newDesc)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.db.Description = newDesc
+
+	var requestor *api.EventLifecycleRequestor
+	if op != nil {
+		requestor = op.Requestor()
+	}
+
+	b.state.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.StoragePoolUpdated.Event(b.name, requestor, logger.Ctx{"description": newDesc}))
+
+	return nil
+}
+
 // warningsDelete deletes any persistent warnings for the pool.
 func (b *backend) warningsDelete() error {
 	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -339,7 +713,9 @@ func (b *backend) warningsDelete() error {
 }
 
 // Delete removes the pool.
-func (b *backend) Delete(clientType request.ClientType, op *operations.Operation) error {
+// Left over non-image volumes are only removed if force is set. Otherwise they are listed in the error if they
+// prevent the deletion.
+func (b *backend) Delete(clientType request.ClientType, force bool, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"clientType": clientType})
 	l.Debug("Delete started")
 	defer l.Debug("Delete finished")
@@ -356,6 +732,9 @@ func (b *backend) Delete(clientType request.ClientType, op *operations.Operation
 		return nil
 	}
 
+	// Names of the left over non-image volumes, reported if the pool can't be deleted.
+	var leftoverVols []string
+
 	if clientType != request.ClientTypeNormal && b.driver.Info().Remote {
 		if b.driver.Info().Deactivate || b.driver.Info().MountedRoot {
 			_, err := b.driver.Unmount()
@@ -373,28 +752,32 @@ func (b *backend) Delete(clientType request.ClientType, op *operations.Operation
 			}
 		}
 	} else {
-		// Remove any left over image volumes.
+		// Remove any left over image volumes, and the other left over volumes too if forced.
 		// This can occur during partial image unpack or if the storage pool has been recovered from an
 		// instance backup file and the image volume DB records were not restored.
-		// If non-image volumes exist, we don't delete the, even if they can then prevent the storage pool
-		// from being deleted, because they should not exist by this point and we don't want to end up
-		// removing an instance or custom volume accidentally.
 		// Errors listing volumes are ignored, as we should still try and delete the storage pool.
 		vols, _ := b.driver.ListVolumes()
-		for _, vol := range vols {
-			if vol.Type() == drivers.VolumeTypeImage {
-				err := b.driver.DeleteVolume(vol, op)
-				if err != nil {
-					return fmt.Errorf("Failed deleting left over image volume %q (%s): %w", vol.Name(), vol.ContentType(), err)
-				}
-
-				l.Warn("Deleted left over image volume", logger.Ctx{"volName": vol.Name(), "contentType": vol.ContentType()})
+		leftoverVols, err = deleteLeftoverVolumes(vols, force, func(vol drivers.Volume) error {
+			err := b.driver.DeleteVolume(vol, op)
+			if err != nil {
+				return err
 			}
+
+			l.Warn("Deleted left over volume", logger.Ctx{"volName": vol.Name(), "volType": vol.Type(), "contentType": vol.ContentType()})
+
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 
 		// Delete the low-level storage.
-		err := b.driver.Delete(op)
+		err = b.driver.Delete(op)
 		if err != nil {
+			if len(leftoverVols) > 0 {
+				return fmt.Errorf("Storage pool still contains left over volumes %s: %w", strings.Join(leftoverVols, ", "), err)
+			}
+
 			return err
 		}
 	}
@@ -402,6 +785,10 @@ func (b *backend) Delete(clientType request.ClientType, op *operations.Operation
 	// Delete the mountpoint.
 	err = os.Remove(path)
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		if len(leftoverVols) > 0 {
+			return fmt.Errorf("Storage pool still contains left over volumes %s: %w", strings.Join(leftoverVols, ", "), err)
+		}
+
 		return fmt.Errorf("Failed to remove directory %q: %w", path, err)
 	}
 
@@ -461,35 +848,135 @@ func (b *backend) Mount() (bool, error) {
 	return ourMount, nil
 }
 
-// Unmount unmounts the storage pool.
-func (b *backend) Unmount() (bool, error) {
-	b.logger.Debug("Unmount started")
-	defer b.logger.Debug("Unmount finished")
+// WaitUntilReady tries to mount the storage pool until it becomes ready or the context is done.
+// Retries use an exponential backoff, starting at one second and capped at one minute.
+func (b *backend) WaitUntilReady(ctx context.Context) error {
+	delay := time.Second
 
-	return b.driver.Unmount()
-}
+	for {
+		err := b.isStatusReady()
+		if err == nil {
+			return nil
+		}
 
-// ApplyPatch runs the requested patch at both backend and driver level.
-func (b *backend) ApplyPatch(name string) error {
-	b.logger.Info("Applying patch", logger.Ctx{"name": name})
+		_, err = b.Mount()
+		if err == nil {
+			err = b.isStatusReady()
+			if err == nil {
+				return nil
+			}
+		}
 
-	// Run early backend patches.
-	patch, ok := earlyPatches[name]
-	if ok {
-		err := patch(b)
-		if err != nil {
-			return err
+		b.logger.Debug("Storage pool not ready, retrying", logger.Ctx{"delay": delay, "err": err})
+
+		t := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return fmt.Errorf("Failed waiting for storage pool to become ready: %w", err)
+		case <-t.C:
 		}
-	}
 
-	// Run the driver patch itself.
-	err := b.driver.ApplyPatch(name)
-	if err != nil {
-		return err
+		delay = min(delay*2, time.Minute)
 	}
+}
 
-	// Run late backend patches.
-	patch, ok = latePatches[name]
+// HealthCheck probes the storage pool through its driver to check that it's genuinely usable on this server.
+// The pool is mounted if needed, its mount path is checked and its resources are retrieved.
+// The availability of the pool is updated from the result.
+func (b *backend) HealthCheck(ctx context.Context) (*HealthCheckResult, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("HealthCheck started")
+	defer l.Debug("HealthCheck finished")
+
+	if b.Status() == api.StoragePoolStatusPending {
+		return nil, errors.New("The pool is in pending state")
+	}
+
+	// Run the probe in the background so that a wedged pool can't block the caller past its deadline.
+	resultCh := make(chan HealthCheckResult, 1)
+	go func() {
+		result := HealthCheckResult{}
+
+		result.Mounted, result.Error = b.Mount()
+		if result.Error != nil {
+			result.Error = fmt.Errorf("Failed mounting storage pool: %w", result.Error)
+			resultCh <- result
+			return
+		}
+
+		path := drivers.GetPoolMountPath(b.name)
+		if !internalUtil.IsDir(path) {
+			result.Error = fmt.Errorf("Storage pool path %q isn't accessible", path)
+			resultCh <- result
+			return
+		}
+
+		result.Resources, result.Error = b.driver.GetResources()
+		if result.Error != nil {
+			result.Error = fmt.Errorf("Failed getting storage pool resources: %w", result.Error)
+			resultCh <- result
+			return
+		}
+
+		result.Healthy = true
+		resultCh <- result
+	}()
+
+	var result HealthCheckResult
+
+	select {
+	case result = <-resultCh:
+	case <-ctx.Done():
+		result.Error = fmt.Errorf("Timed out checking storage pool health: %w", ctx.Err())
+	}
+
+	unavailablePoolsMu.Lock()
+	if result.Healthy {
+		delete(unavailablePools, b.Name())
+	} else {
+		unavailablePools[b.Name()] = struct{}{}
+	}
+
+	unavailablePoolsMu.Unlock()
+
+	if !result.Healthy {
+		l.Warn("Storage pool health check failed", logger.Ctx{"err": result.Error})
+	}
+
+	return &result, nil
+}
+
+// Unmount unmounts the storage pool.
+func (b *backend) Unmount() (bool, error) {
+	b.logger.Debug("Unmount started")
+	defer b.logger.Debug("Unmount finished")
+
+	return b.driver.Unmount()
+}
+
+// ApplyPatch runs the requested patch at both backend and driver level.
+func (b *backend) ApplyPatch(name string) error {
+	b.logger.Info("Applying patch", logger.Ctx{"name": name})
+
+	// Run early backend patches.
+	patch, ok := earlyPatches[name]
+	if ok {
+		err := patch(b)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Run the driver patch itself.
+	err := b.driver.ApplyPatch(name)
+	if err != nil {
+		return err
+	}
+
+	// Run late backend patches.
+	patch, ok = latePatches[name]
 	if ok {
 		err := patch(b)
 		if err != nil {
@@ -500,6 +987,37 @@ func (b *backend) ApplyPatch(name string) error {
 	return nil
 }
 
+// ListPatches returns the patches known to the pool's driver and backend along with their applied status.
+// Patches are tracked per server rather than per pool, so a patch is reported as applied once it has been
+// applied to all pools on this server.
+func (b *backend) ListPatches() ([]PatchInfo, error) {
+	appliedPatches, err := b.state.DB.Node.GetAppliedPatches()
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading applied patches: %w", err)
+	}
+
+	names := b.driver.Patches()
+	names = append(names, slices.Collect(maps.Keys(earlyPatches))...)
+	names = append(names, slices.Collect(maps.Keys(latePatches))...)
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	patches := make([]PatchInfo, 0, len(names))
+	for _, name := range names {
+		_, early := earlyPatches[name]
+		_, late := latePatches[name]
+
+		patches = append(patches, PatchInfo{
+			Name:    name,
+			Applied: slices.Contains(appliedPatches, name),
+			Early:   early,
+			Late:    late,
+		})
+	}
+
+	return patches, nil
+}
+
 // ensureInstanceSymlink creates a symlink in the instance directory to the instance's mount path
 // if doesn't exist already.
 func (b *backend) ensureInstanceSymlink(instanceType instancetype.Type, projectName string, instanceName string, mountPath string) error {
@@ -553,7 +1071,7 @@ func (b *backend) ensureInstanceSnapshotSymlink(instanceType instancetype.Type,
 	snapshotSymlink := InstancePath(instanceType, projectName, parentName, true)
 	volStorageName := project.Instance(projectName, parentName)
 
-	snapshotTargetPath := drivers.GetVolumeSnapshotDir(b.name, volType, volStorageName)
+	snapshotTargetPath := drivers.GetVolumeSnapshotDir(b.name, b.driver.Config(), volType, volStorageName)
 
 	// Remove any old symlinks left over by previous bugs that may point to a different pool.
 	if util.PathExists(snapshotSymlink) {
@@ -586,7 +1104,7 @@ func (b *backend) removeInstanceSnapshotSymlinkIfUnused(instanceType instancetyp
 	snapshotSymlink := InstancePath(instanceType, projectName, parentName, true)
 	volStorageName := project.Instance(projectName, parentName)
 
-	snapshotTargetPath := drivers.GetVolumeSnapshotDir(b.name, volType, volStorageName)
+	snapshotTargetPath := drivers.GetVolumeSnapshotDir(b.name, b.driver.Config(), volType, volStorageName)
 
 	// If snapshot parent directory doesn't exist, remove symlink.
 	if !util.PathExists(snapshotTargetPath) {
@@ -624,6 +1142,17 @@ func (b *backend) applyInstanceRootDiskOverrides(inst instance.Instance, vol *dr
 	return nil
 }
 
+// applyNewVMStateSize sets the size of the filesystem volume of a new virtual machine volume to the pool's
+// volume.vm.state_size setting, unless the instance's root disk sets size.state.
+// Existing virtual machine volumes keep using the driver's default as their filesystem volume was created with it.
+func (b *backend) applyNewVMStateSize(vol *drivers.Volume) {
+	if !vol.IsVMBlock() || vol.Config()["size.state"] != "" || b.db.Config["volume.vm.state_size"] == "" {
+		return
+	}
+
+	vol.SetConfigStateSize(b.db.Config["volume.vm.state_size"])
+}
+
 // applyInstanceRootDiskInitialValues applies the instance's root disk initial config to the volume's config.
 func (b *backend) applyInstanceRootDiskInitialValues(inst instance.Instance, volConfig map[string]string) error {
 	_, rootDiskConf, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
@@ -694,6 +1223,8 @@ func (b *backend) CreateInstance(inst instance.Instance, op *operations.Operatio
 		return err
 	}
 
+	b.applyNewVMStateSize(&vol)
+
 	var filler *drivers.VolumeFiller
 	if inst.Type() == instancetype.Container {
 		filler = &drivers.VolumeFiller{
@@ -714,14 +1245,14 @@ func (b *backend) CreateInstance(inst instance.Instance, op *operations.Operatio
 		return err
 	}
 
-	reverter.Add(func() { _ = b.DeleteInstance(inst, op) })
+	reverter.Add(func() { _ = b.DeleteInstance(inst, false, op) })
 
 	err = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, inst.Name(), vol.MountPath())
 	if err != nil {
 		return err
 	}
 
-	err = inst.DeferTemplateApply(instance.TemplateTriggerCreate)
+	err = b.deferTemplateApply(inst, instance.TemplateTriggerCreate, op)
 	if err != nil {
 		return err
 	}
@@ -1028,13 +1559,14 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 	l.Debug("CreateInstanceFromCopy started")
 	defer l.Debug("CreateInstanceFromCopy finished")
 
-	err := b.isStatusReady()
+	err := validateInstanceCopyTypes(inst, src)
 	if err != nil {
 		return err
 	}
 
-	if inst.Type() != src.Type() {
-		return errors.New("Instance types must match")
+	err = b.isStatusReady()
+	if err != nil {
+		return err
 	}
 
 	volType, err := InstanceTypeToVolumeType(inst.Type())
@@ -1100,7 +1632,7 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 		_ = linux.SyncFS(src.RootfsPath())
 	}
 
-	reverter.Add(func() { _ = b.DeleteInstance(inst, op) })
+	reverter.Add(func() { _ = b.DeleteInstance(inst, false, op) })
 
 	if b.Name() == srcPool.Name() {
 		l.Debug("CreateInstanceFromCopy same-pool mode detected")
@@ -1150,6 +1682,8 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 			return err
 		}
 
+		b.applyNewVMStateSize(&vol)
+
 		err = b.driver.CreateVolumeFromCopy(vol, srcVol, snapshots, allowInconsistent, op)
 		if err != nil {
 			return err
@@ -1163,7 +1697,7 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 				return fmt.Errorf("Failed loading storage pool: %w", err)
 			}
 
-			err = diskPool.CreateCustomVolumeFromCopy(inst.Project().Name, src.Project().Name, newDevices[dev.Name]["source"], "", nil, dev.Config["pool"], dev.Config["source"], snapshots, op)
+			err = diskPool.CreateCustomVolumeFromCopy(inst.Project().Name, src.Project().Name, newDevices[dev.Name]["source"], "", nil, dev.Config["pool"], dev.Config["source"], snapshots, nil, "", false, op)
 			if err != nil {
 				return err
 			}
@@ -1226,6 +1760,14 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 			dstDependentVolumes = append(dstDependentVolumes, vol)
 		}
 
+		// Bound the number of concurrent cross-pool transfers into the pool.
+		releaseTransfer, err := b.acquireTransferSlot(op)
+		if err != nil {
+			return err
+		}
+
+		defer releaseTransfer()
+
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -1248,6 +1790,7 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 				VolumeOnly:         !snapshots,
 				Info:               &localMigration.Info{Config: srcConfig},
 				StorageMove:        true,
+				SparseBlocks:       true,
 				DependentVolumes:   srcDependentVolumes,
 			}, op)
 		})
@@ -1255,6 +1798,7 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 		g.Go(func() error {
 			return b.CreateInstanceFromMigration(inst, bEnd, localMigration.VolumeTargetArgs{
 				IndexHeaderVersion: localMigration.IndexHeaderVersion,
+				SparseBlocks:       true,
 				Name:               inst.Name(),
 				Snapshots:          migrationSnapshots,
 				MigrationType:      migrationTypes[0],
@@ -1487,6 +2031,14 @@ func (b *backend) RefreshCustomVolume(projectName string, srcProjectName string,
 			}
 		}
 
+		// Bound the number of concurrent cross-pool transfers into the pool.
+		releaseTransfer, err := b.acquireTransferSlot(op)
+		if err != nil {
+			return err
+		}
+
+		defer releaseTransfer()
+
 		ctx, cancel := context.WithCancel(context.Background())
 
 		// Use in-memory pipe pair to simulate a connection between the sender and receiver.
@@ -1505,6 +2057,7 @@ func (b *backend) RefreshCustomVolume(projectName string, srcProjectName string,
 				ContentType:        string(contentType),
 				Info:               &localMigration.Info{Config: srcConfig},
 				StorageMove:        true,
+				SparseBlocks:       true,
 			}, op)
 			if err != nil {
 				cancel()
@@ -1516,6 +2069,7 @@ func (b *backend) RefreshCustomVolume(projectName string, srcProjectName string,
 		go func() {
 			err := b.CreateCustomVolumeFromMigration(projectName, bEnd, localMigration.VolumeTargetArgs{
 				IndexHeaderVersion: localMigration.IndexHeaderVersion,
+				SparseBlocks:       true,
 				Name:               volName,
 				Description:        desc,
 				Config:             config,
@@ -1558,20 +2112,69 @@ func (b *backend) RefreshCustomVolume(projectName string, srcProjectName string,
 	return nil
 }
 
+// RefreshOrCreateCustomVolume creates the custom volume from a copy of the source volume if it doesn't exist yet,
+// or refreshes it from the source volume if it does. It returns whether the volume was created.
+// The onlySnapshots argument is passed to CreateCustomVolumeFromCopy and excludeOlder to RefreshCustomVolume.
+// Selecting snapshots isn't supported when refreshing, so onlySnapshots must be empty if the volume exists.
+func (b *backend) RefreshOrCreateCustomVolume(projectName string, srcProjectName string, volName string, desc string, config map[string]string, srcPoolName string, srcVolName string, snapshots bool, onlySnapshots []string, excludeOlder bool, op *operations.Operation) (bool, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "srcProjectName": srcProjectName, "volName": volName, "srcPoolName": srcPoolName, "srcVolName": srcVolName, "snapshots": snapshots, "onlySnapshots": onlySnapshots, "excludeOlder": excludeOlder})
+	l.Debug("RefreshOrCreateCustomVolume started")
+	defer l.Debug("RefreshOrCreateCustomVolume finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil && !response.IsNotFoundError(err) {
+		return false, err
+	}
+
+	if err != nil {
+		err = b.CreateCustomVolumeFromCopy(projectName, srcProjectName, volName, desc, config, srcPoolName, srcVolName, snapshots, onlySnapshots, "", false, op)
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	if len(onlySnapshots) > 0 {
+		return false, api.StatusErrorf(http.StatusBadRequest, "Snapshots can't be selected when refreshing existing custom volume %q", volName)
+	}
+
+	err = b.RefreshCustomVolume(projectName, srcProjectName, volName, desc, config, srcPoolName, srcVolName, snapshots, excludeOlder, op)
+	if err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
 // RefreshInstance synchronises one instance's volume (and optionally snapshots) over another.
 // Snapshots that are not present in the source but are in the destination are removed from the
 // destination if snapshots are included in the synchronisation. An empty srcSnapshots argument
-// indicates a volume-only refresh.
-func (b *backend) RefreshInstance(inst instance.Instance, src instance.Instance, srcSnapshots []instance.Instance, allowInconsistent bool, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "src": src.Name(), "srcSnapshots": len(srcSnapshots)})
+// indicates a volume-only refresh. If configOnly is true, only the config filesystem volume of the
+// VM is synchronised and its block volume is left untouched.
+func (b *backend) RefreshInstance(inst instance.Instance, src instance.Instance, srcSnapshots []instance.Instance, allowInconsistent bool, configOnly bool, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "src": src.Name(), "srcSnapshots": len(srcSnapshots), "configOnly": configOnly})
 	l.Debug("RefreshInstance started")
 	defer l.Debug("RefreshInstance finished")
 
 	// This indicates whether or not it's a volume-only refresh.
 	snapshots := len(srcSnapshots) > 0
 
-	if inst.Type() != src.Type() {
-		return errors.New("Instance types must match")
+	err := validateInstanceCopyTypes(inst, src)
+	if err != nil {
+		return err
+	}
+
+	if configOnly {
+		err = validateVMConfigOnlyRefresh(inst, src, snapshots)
+		if err != nil {
+			return err
+		}
 	}
 
 	volType, err := InstanceTypeToVolumeType(inst.Type())
@@ -1662,7 +2265,22 @@ func (b *backend) RefreshInstance(inst instance.Instance, src instance.Instance,
 		_ = linux.SyncFS(src.RootfsPath())
 	}
 
-	if b.Name() == srcPool.Name() {
+	if configOnly {
+		l.Debug("RefreshInstance config volume only mode detected")
+
+		// Get the source volume from its own pool so it can be mounted.
+		srcPoolVol := srcPool.GetVolume(volType, contentType, srcVolStorageName, srcConfig.Volume.Config)
+		err = b.refreshVMConfigVolume(vol, srcPoolBackend, srcPoolVol, op)
+		if err != nil {
+			return err
+		}
+
+		// The refresh replaced the backup file of the instance with the one of the source, regenerate it.
+		err = b.UpdateInstanceBackupFile(inst, true, op)
+		if err != nil {
+			return err
+		}
+	} else if b.Name() == srcPool.Name() {
 		l.Debug("RefreshInstance same-pool mode detected")
 
 		// Create database entries for new storage volume snapshots.
@@ -1714,6 +2332,14 @@ func (b *backend) RefreshInstance(inst instance.Instance, src instance.Instance,
 			return err
 		}
 
+		// Bound the number of concurrent cross-pool transfers into the pool.
+		releaseTransfer, err := b.acquireTransferSlot(op)
+		if err != nil {
+			return err
+		}
+
+		defer releaseTransfer()
+
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -1737,12 +2363,14 @@ func (b *backend) RefreshInstance(inst instance.Instance, src instance.Instance,
 				Info:               &localMigration.Info{Config: srcConfig},
 				VolumeOnly:         !snapshots,
 				StorageMove:        true,
+				SparseBlocks:       true,
 			}, op)
 		})
 
 		g.Go(func() error {
 			return b.CreateInstanceFromMigration(inst, bEnd, localMigration.VolumeTargetArgs{
 				IndexHeaderVersion: localMigration.IndexHeaderVersion,
+				SparseBlocks:       true,
 				Name:               inst.Name(),
 				Snapshots:          migrationSnapshots,
 				MigrationType:      migrationTypes[0],
@@ -1765,7 +2393,7 @@ func (b *backend) RefreshInstance(inst instance.Instance, src instance.Instance,
 		return err
 	}
 
-	err = inst.DeferTemplateApply(instance.TemplateTriggerCopy)
+	err = b.deferTemplateApply(inst, instance.TemplateTriggerCopy, op)
 	if err != nil {
 		return err
 	}
@@ -1774,6 +2402,54 @@ func (b *backend) RefreshInstance(inst instance.Instance, src instance.Instance,
 	return nil
 }
 
+// refreshVMConfigVolume synchronises the config filesystem volume of a VM from the one of the source VM volume
+// on srcPool, without transferring the block volume.
+func (b *backend) refreshVMConfigVolume(vol drivers.Volume, srcPool *backend, srcVol drivers.Volume, op *operations.Operation) error {
+	// The config volume can only be refreshed on its own if it doesn't hold the block volume.
+	for _, pool := range []*backend{b, srcPool} {
+		if !pool.driver.Info().SeparateVMConfigVolumes {
+			return fmt.Errorf("Storage pool %q doesn't support refreshing the VM config volume on its own", pool.name)
+		}
+	}
+
+	fsVol := vol.NewVMBlockFilesystemVolume()
+	srcFSVol := srcVol.NewVMBlockFilesystemVolume()
+	bwlimit := b.driver.Config()["rsync.bwlimit"]
+
+	err := srcFSVol.MountTask(func(srcMountPath string, op *operations.Operation) error {
+		return fsVol.MountTask(func(mountPath string, op *operations.Operation) error {
+			_, err := rsync.LocalCopy(srcMountPath, mountPath, bwlimit, true)
+			return err
+		}, op)
+	}, op)
+	if err != nil {
+		return fmt.Errorf("Failed refreshing VM config volume: %w", err)
+	}
+
+	return nil
+}
+
+// deferTemplateApply defers the application of the instance templates for the given trigger.
+// If templates.ignore_errors is enabled on the instance, failures are only logged and added to the operation metadata.
+func (b *backend) deferTemplateApply(inst instance.Instance, trigger instance.TemplateTrigger, op *operations.Operation) error {
+	err := inst.DeferTemplateApply(trigger)
+	if err == nil {
+		return nil
+	}
+
+	if util.IsFalseOrEmpty(inst.ExpandedConfig()["templates.ignore_errors"]) {
+		return err
+	}
+
+	b.logger.Warn("Failed applying instance templates", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "trigger": trigger, "err": err})
+
+	if op != nil {
+		_ = op.ExtendMetadata(map[string]any{"template_error": err.Error()})
+	}
+
+	return nil
+}
+
 // imageFiller returns a function that can be used as a filler function with CreateVolume().
 // The function returned will unpack the specified image archive into the specified mount path
 // provided, and for VM images, a raw root block path is required to unpack the qcow2 image into.
@@ -1781,17 +2457,40 @@ func (b *backend) imageFiller(fingerprint string, op *operations.Operation) func
 	return func(vol drivers.Volume, rootBlockPath string, allowUnsafeResize bool, targetIsZero bool, targetFormat string) (int64, error) {
 		var tracker *ioprogress.ProgressTracker
 		if op != nil { // Not passed when being done as part of pre-migration setup.
+			// Extend the metadata so that the progress of the operation the unpack is part of is kept.
 			metadata := make(map[string]any)
 			tracker = &ioprogress.ProgressTracker{
 				Handler: func(percent, speed int64) {
 					operations.SetProgressMetadata(metadata, "create_instance_from_image_unpack", "Unpacking image", percent, 0, speed)
-					_ = op.UpdateMetadata(metadata)
+					_ = op.ExtendMetadata(metadata)
 				},
 			}
+
+			// Report the start of the unpack as some formats don't provide progress.
+			tracker.Handler(0, 0)
+		}
+
+		// Abort the unpack when the operation is cancelled, the caller's reverter then removes the partial volume.
+		ctx := context.Background()
+		if op != nil {
+			ctx = op.CancelContext()
 		}
 
 		imageFile := internalUtil.VarPath("images", fingerprint)
-		return ImageUnpack(imageFile, vol, rootBlockPath, b.state.OS, allowUnsafeResize, targetIsZero, tracker, targetFormat)
+		size, err := ImageUnpack(ctx, imageFile, vol, rootBlockPath, b.state.OS, allowUnsafeResize, targetIsZero, tracker, targetFormat)
+		if err != nil {
+			if ctx.Err() != nil {
+				return -1, fmt.Errorf("Image unpack cancelled: %w", ctx.Err())
+			}
+
+			return -1, err
+		}
+
+		if tracker != nil {
+			tracker.Handler(100, 0)
+		}
+
+		return size, nil
 	}
 }
 
@@ -1811,9 +2510,49 @@ func (b *backend) isoFiller(data io.Reader) func(vol drivers.Volume, rootBlockPa
 	}
 }
 
+// foreignFiller returns a function that can be used as a filler function with CreateVolume().
+// The function returned copies a foreign disk image or directory into the new volume.
+func (b *backend) foreignFiller(srcPath string, srcFormat string, op *operations.Operation) func(vol drivers.Volume, rootBlockPath string, allowUnsafeResize bool, targetIsZero bool, targetFormat string) (int64, error) {
+	return func(vol drivers.Volume, rootBlockPath string, allowUnsafeResize bool, targetIsZero bool, targetFormat string) (int64, error) {
+		if rootBlockPath == "" {
+			_, err := rsync.LocalCopy(srcPath, vol.MountPath(), "", true)
+			if err != nil {
+				return -1, err
+			}
+
+			return 0, nil
+		}
+
+		var tracker *ioprogress.ProgressTracker
+		if op != nil {
+			metadata := make(map[string]any)
+			tracker = &ioprogress.ProgressTracker{
+				Handler: func(percent, speed int64) {
+					operations.SetProgressMetadata(metadata, "import_foreign_volume", "Importing volume", percent, 0, speed)
+					_ = op.UpdateMetadata(metadata)
+				},
+			}
+		}
+
+		size, err := ForeignVolumeSize(b.state.OS, srcPath, srcFormat)
+		if err != nil {
+			return -1, err
+		}
+
+		err = ForeignVolumeUnpack(b.state.OS, srcPath, srcFormat, rootBlockPath, targetFormat, tracker)
+		if err != nil {
+			return -1, err
+		}
+
+		return size, nil
+	}
+}
+
 // CreateInstanceFromImage creates a new volume for an instance populated with the image requested.
+// If forceNonOptimized is set, the image is unpacked directly into the new volume and the optimized image
+// volume is neither used nor (re)generated.
 // On failure caller is expected to call DeleteInstance() to clean up.
-func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint string, op *operations.Operation) error {
+func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint string, forceNonOptimized bool, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
 	l.Debug("CreateInstanceFromImage started")
 	defer l.Debug("CreateInstanceFromImage finished")
@@ -1840,9 +2579,12 @@ func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint st
 	}
 
 	// Determine whether an optimized image should be used.
-	useOptimizedImage, err := b.shouldUseOptimizedImage(fingerprint, contentType, volumeConfig, op)
-	if err != nil {
-		return err
+	useOptimizedImage := false
+	if !forceNonOptimized {
+		useOptimizedImage, err = b.shouldUseOptimizedImage(fingerprint, contentType, volumeConfig, op)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Validate config and create database entry for new storage volume.
@@ -1871,6 +2613,8 @@ func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint st
 		return err
 	}
 
+	b.applyNewVMStateSize(&vol)
+
 	// Leave reverting on failure to caller, they are expected to call DeleteInstance().
 
 	// If the driver doesn't support optimized image volumes or the optimized image volume should not be used,
@@ -1885,46 +2629,85 @@ func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint st
 		if err != nil {
 			return err
 		}
+
+		recordImageCreate(b.name, ImageCreateUnpack)
 	} else {
-		// If the driver supports optimized images then ensure the optimized image volume has been created
-		// for the images's fingerprint and that it matches the pool's current volume settings, and if not
-		// recreating using the pool's current volume settings.
-		err = b.EnsureImage(fingerprint, op)
-		if err != nil {
-			return err
-		}
+		// Hold the image lock until the new volume has been copied from the optimized image volume so that a
+		// concurrent EnsureImage cannot delete or regenerate it while we're copying from it. The lock is
+		// released right after the copy, so that the fallback unpack below doesn't block other instances.
+		var imgVol drivers.Volume
+		var newVolSize string
+
+		err = func() error {
+			unlock, err := b.operationLock(drivers.OperationLockName("EnsureImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
+			if err != nil {
+				return err
+			}
 
-		// Try and load existing volume config on this storage pool so we can compare filesystems if needed.
-		imgDBVol, err := VolumeDBGet(b, api.ProjectDefaultName, fingerprint, drivers.VolumeTypeImage)
-		if err != nil {
-			return err
-		}
+			defer unlock()
 
-		imgVol := b.GetVolume(drivers.VolumeTypeImage, contentType, fingerprint, imgDBVol.Config)
+			// If the driver supports optimized images then ensure the optimized image volume has been created
+			// for the images's fingerprint and that it matches the pool's current volume settings, and if not
+			// recreating using the pool's current volume settings.
+			_, err = b.ensureImage(fingerprint, op)
+			if err != nil {
+				return err
+			}
 
-		// Derive the volume size to use for a new volume when copying from a source volume.
-		// Where possible (if the source volume has a volatile.rootfs.size property), it checks that the
-		// source volume isn't larger than the volume's "size" and the pool's "volume.size" setting.
-		l.Debug("Checking volume size")
-		newVolSize, err := vol.ConfigSizeFromSource(imgVol)
-		if err != nil {
-			return err
-		}
+			// Try and load existing volume config on this storage pool so we can compare filesystems if needed.
+			imgDBVol, err := VolumeDBGet(b, api.ProjectDefaultName, fingerprint, drivers.VolumeTypeImage)
+			if err != nil {
+				return err
+			}
+
+			imgVol = b.GetVolume(drivers.VolumeTypeImage, contentType, fingerprint, imgDBVol.Config)
+
+			// Derive the volume size to use for a new volume when copying from a source volume.
+			// Where possible (if the source volume has a volatile.rootfs.size property), it checks that the
+			// source volume isn't larger than the volume's "size" and the pool's "volume.size" setting.
+			l.Debug("Checking volume size")
+			newVolSize, err = vol.ConfigSizeFromSource(imgVol)
+			if err != nil {
+				return err
+			}
+
+			// Set the derived size directly as the "size" property on the new volume so that it is applied.
+			vol.SetConfigSize(newVolSize)
+			l.Debug("Set new volume size", logger.Ctx{"size": newVolSize})
+
+			// Warn early if the new block volume is smaller than the cached image volume, as the copy may then
+			// have to be shrunk, which some drivers and filesystems can't do.
+			if vol.IsBlockBacked() && newVolSize != "" {
+				newVolSizeBytes, err := units.ParseByteSizeString(newVolSize)
+				if err != nil {
+					return err
+				}
+
+				imgVolSizeBytes, err := units.ParseByteSizeString(imgVol.ConfigSize())
+				if err != nil {
+					return err
+				}
 
-		// Set the derived size directly as the "size" property on the new volume so that it is applied.
-		vol.SetConfigSize(newVolSize)
-		l.Debug("Set new volume size", logger.Ctx{"size": newVolSize})
+				if newVolSizeBytes > 0 && newVolSizeBytes < imgVolSizeBytes {
+					l.Warn("Instance volume size is smaller than the cached image volume, the image may have to be unpacked rather than copied", logger.Ctx{"fingerprint": fingerprint, "size": newVolSize, "imageVolumeSize": imgVol.ConfigSize()})
+				}
+			}
 
-		// Proceed to create a new volume by copying the optimized image volume.
-		err = b.driver.CreateVolumeFromCopy(vol, imgVol, false, false, op)
+			// Proceed to create a new volume by copying the optimized image volume.
+			return b.driver.CreateVolumeFromCopy(vol, imgVol, false, false, op)
+		}()
 
 		// If the driver returns ErrCannotBeShrunk, this means that the cached volume that the new volume
 		// is to be created from is larger than the requested new volume size, and cannot be shrunk.
 		// So we unpack the image directly into a new volume rather than use the optimized snapsot.
 		// This is slower but allows for individual volumes to be created from an image that are smaller
-		// than the pool's volume settings.
+		// than the pool's volume settings, unless the pool's images.shrink_fallback setting disables it.
 		if errors.Is(err, drivers.ErrCannotBeShrunk) {
-			l.Debug("Cached image volume is larger than new volume and cannot be shrunk, creating non-optimized volume")
+			if util.IsFalse(b.db.Config["images.shrink_fallback"]) {
+				return api.StatusErrorf(http.StatusBadRequest, "Instance volume size %q is smaller than the cached image volume size %q and the copy can't be shrunk, increase the root disk size or enable images.shrink_fallback on the pool", newVolSize, imgVol.ConfigSize())
+			}
+
+			l.Warn("Cached image volume is larger than new volume and cannot be shrunk, unpacking the image instead", logger.Ctx{"fingerprint": fingerprint, "size": newVolSize, "imageVolumeSize": imgVol.ConfigSize()})
 
 			volFiller := drivers.VolumeFiller{
 				Fingerprint: fingerprint,
@@ -1935,8 +2718,12 @@ func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint st
 			if err != nil {
 				return err
 			}
+
+			recordImageCreate(b.name, ImageCreateShrinkFallback)
 		} else if err != nil {
 			return err
+		} else {
+			recordImageCreate(b.name, ImageCreateOptimized)
 		}
 	}
 
@@ -1945,7 +2732,7 @@ func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint st
 		return err
 	}
 
-	err = inst.DeferTemplateApply(instance.TemplateTriggerCreate)
+	err = b.deferTemplateApply(inst, instance.TemplateTriggerCreate, op)
 	if err != nil {
 		return err
 	}
@@ -1985,12 +2772,39 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 		return err
 	}
 
-	reverter := revert.New()
-	defer reverter.Fail()
+	// Validate that the volume can be accepted, report the result to the source and stop.
+	if args.VerifyOnly {
+		if args.IndexHeaderVersion == 0 {
+			return errors.New("Verify only migration requires the migration index header")
+		}
 
-	if !inst.IsSnapshot() && srcInfo.Config != nil && srcInfo.Config.Container != nil {
-		// Create dependent volumes if they exist.
-		cleanupDependentVols, err := b.createDependentVolumesFromMigration(inst, conn, args, srcInfo, op)
+		warnings, verifyErr := b.migrationVerifyInstanceTarget(inst, volType, contentType, args, srcInfo)
+
+		err = b.migrationVerifyResultSend(l, conn, warnings, verifyErr)
+		if err != nil {
+			return err
+		}
+
+		if verifyErr != nil {
+			return verifyErr
+		}
+
+		if op != nil {
+			err = op.ExtendMetadata(map[string]any{"migration_type": args.MigrationType.FSType.String(), "migration_warnings": warnings})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	if !inst.IsSnapshot() && srcInfo.Config != nil && srcInfo.Config.Container != nil {
+		// Create dependent volumes if they exist.
+		cleanupDependentVols, err := b.createDependentVolumesFromMigration(inst, conn, args, srcInfo, op)
 		if err != nil {
 			return err
 		}
@@ -1999,22 +2813,14 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 	}
 
 	// Now that we got the source details, validate against the instance limits.
-	_, rootDiskConf, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+	err = b.migrationCheckInstanceRootSize(inst, args.VolumeSize)
 	if err != nil {
 		return err
 	}
 
-	if rootDiskConf["size"] != "" {
-		rootDiskConfBytes, err := units.ParseByteSizeString(rootDiskConf["size"])
-		if err != nil {
-			return err
-		}
-
-		// Compare volume size with configured root size.
-		// Add a 4MiB allowed extra to account for round to nearest extent (16k on ZFS, 4MiB on LVM).
-		if args.VolumeSize > (rootDiskConfBytes + (4 * 1024 * 1024)) {
-			return errors.New("The configured target instance root disk size is smaller than the migration source")
-		}
+	err = b.migrationValidateConfigOverrides(inst, volType, contentType, args)
+	if err != nil {
+		return err
 	}
 
 	var volumeDescription string
@@ -2038,6 +2844,11 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 		volumeDescription = args.Description
 	}
 
+	if dbVol == nil && len(args.TargetConfigOverrides) > 0 {
+		volumeConfig = util.CloneMap(volumeConfig)
+		maps.Copy(volumeConfig, args.TargetConfigOverrides)
+	}
+
 	volStorageName := project.Instance(inst.Project().Name, inst.Name())
 	vol := b.GetVolume(volType, contentType, volStorageName, volumeConfig)
 
@@ -2077,6 +2888,15 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 
 	isRemoteClusterMove := args.ClusterMoveSourceName != "" && b.driver.Info().Remote
 
+	// When moving between cluster members of a remote pool, the driver skips the transfer so make sure
+	// that the volume is really shared with the source member.
+	if isRemoteClusterMove && args.StoragePool == "" {
+		err = b.migrationCheckSharedVolume(inst, dbVol, volExists, srcInfo, args.Snapshots)
+		if err != nil {
+			return err
+		}
+	}
+
 	if !args.Refresh {
 		if volExists {
 			if !isRemoteClusterMove {
@@ -2136,6 +2956,12 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 						snapCreationDate = srcInfo.Config.VolumeSnapshots[i].CreatedAt
 					}
 				}
+
+				// Snapshots get the same overrides as their parent volume.
+				if len(args.TargetConfigOverrides) > 0 {
+					snapConfig = util.CloneMap(snapConfig)
+					maps.Copy(snapConfig, args.TargetConfigOverrides)
+				}
 			}
 
 			// Validate config and create database entry for new storage volume.
@@ -2155,6 +2981,8 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 		return err
 	}
 
+	b.applyNewVMStateSize(&vol)
+
 	// Override args.Name and args.Config to ensure volume is created based on instance.
 	args.Config = vol.Config()
 	args.Name = inst.Name()
@@ -2210,7 +3038,7 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 
 				// Ensure if the image doesn't yet exist on a driver which supports
 				// optimized storage, then it gets created first.
-				err = b.EnsureImage(preFiller.Fingerprint, op)
+				_, err = b.EnsureImage(preFiller.Fingerprint, op)
 				if err != nil {
 					return err
 				}
@@ -2231,7 +3059,7 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 	}
 
 	if !isRemoteClusterMove {
-		reverter.Add(func() { _ = b.DeleteInstance(inst, op) })
+		reverter.Add(func() { _ = b.DeleteInstance(inst, false, op) })
 	}
 
 	err = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, inst.Name(), vol.MountPath())
@@ -2351,6 +3179,16 @@ func (b *backend) RenameInstance(inst instance.Instance, newName string, op *ope
 		})
 	})
 
+	// Record volume rename with authorizer.
+	err = b.state.Authorizer.RenameStoragePoolVolume(b.state.ShutdownCtx, inst.Project().Name, b.Name(), vol.Type().Singular(), inst.Name(), newName, "")
+	if err != nil {
+		logger.Error("Failed to rename storage volume in authorizer", logger.Ctx{"name": inst.Name(), "newName": newName, "type": vol.Type(), "pool": b.Name(), "project": inst.Project().Name, "error": err})
+	} else {
+		reverter.Add(func() {
+			_ = b.state.Authorizer.RenameStoragePoolVolume(b.state.ShutdownCtx, inst.Project().Name, b.Name(), vol.Type().Singular(), newName, inst.Name(), "")
+		})
+	}
+
 	// Rename the volume and its snapshots on the storage device.
 
 	err = b.driver.RenameVolume(vol, newVolStorageName, op)
@@ -2358,6 +3196,8 @@ func (b *backend) RenameInstance(inst instance.Instance, newName string, op *ope
 		return err
 	}
 
+	vol.ClearCachedDiskPath()
+
 	reverter.Add(func() {
 		// There's no need to pass config as it's not needed when renaming a volume.
 		newVol := b.GetVolume(volType, contentType, newVolStorageName, nil)
@@ -2371,10 +3211,10 @@ func (b *backend) RenameInstance(inst instance.Instance, newName string, op *ope
 	}
 
 	reverter.Add(func() {
-		_ = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, inst.Name(), drivers.GetVolumeMountPath(b.name, volType, volStorageName))
+		_ = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, inst.Name(), drivers.GetVolumeMountPath(b.name, b.driver.Config(), volType, volStorageName))
 	})
 
-	err = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, newName, drivers.GetVolumeMountPath(b.name, volType, newVolStorageName))
+	err = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, newName, drivers.GetVolumeMountPath(b.name, b.driver.Config(), volType, newVolStorageName))
 	if err != nil {
 		return err
 	}
@@ -2396,19 +3236,19 @@ func (b *backend) RenameInstance(inst instance.Instance, newName string, op *ope
 		}
 	}
 
-	// Record volume rename with authorizer.
-	err = b.state.Authorizer.RenameStoragePoolVolume(b.state.ShutdownCtx, inst.Project().Name, b.Name(), vol.Type().Singular(), inst.Name(), newName, "")
-	if err != nil {
-		logger.Error("Failed to rename storage volume in authorizer", logger.Ctx{"name": inst.Name(), "newName": newName, "type": vol.Type(), "pool": b.Name(), "project": inst.Project().Name, "error": err})
-	}
+	// Use the unprefixed instance name so the event URL matches the API path of the volume, with the
+	// project passed as a query parameter.
+	newVol := b.GetVolume(volType, contentType, newName, nil)
+	b.state.Events.SendLifecycle(inst.Project().Name, lifecycle.StorageVolumeRenamed.Event(newVol, volType.Singular(), inst.Project().Name, op, logger.Ctx{"old_name": inst.Name()}))
 
 	reverter.Success()
 	return nil
 }
 
 // DeleteInstance removes the instance's root volume (all snapshots need to be removed first).
-func (b *backend) DeleteInstance(inst instance.Instance, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+// If force is true, any remaining snapshot volumes are removed before the root volume.
+func (b *backend) DeleteInstance(inst instance.Instance, force bool, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "force": force})
 	l.Debug("DeleteInstance started")
 	defer l.Debug("DeleteInstance finished")
 
@@ -2429,7 +3269,7 @@ func (b *backend) DeleteInstance(inst instance.Instance, op *operations.Operatio
 	}
 
 	// Check all snapshots are already removed.
-	if len(dbVolSnaps) > 0 {
+	if len(dbVolSnaps) > 0 && !force {
 		return errors.New("Cannot remove an instance volume that has snapshots")
 	}
 
@@ -2437,6 +3277,47 @@ func (b *backend) DeleteInstance(inst instance.Instance, op *operations.Operatio
 	volStorageName := project.Instance(inst.Project().Name, inst.Name())
 	contentType := InstanceContentType(inst)
 
+	// Remove any remaining snapshots, newest first. Snapshots that still have an instance record go through
+	// DeleteInstanceSnapshot so that their symlinks and dependent volume snapshots are cleaned up too.
+	// Each snapshot is removed from storage before its DB record so that a failure leaves the remaining
+	// snapshots intact and consistent.
+	for i := len(dbVolSnaps) - 1; i >= 0; i-- {
+		snapInst, err := instance.LoadByProjectAndName(b.state, inst.Project().Name, dbVolSnaps[i].Name)
+		if err == nil {
+			err = b.DeleteInstanceSnapshot(snapInst, op)
+			if err != nil {
+				return err
+			}
+
+			continue
+		} else if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return err
+		}
+
+		// The snapshot only has a volume record, remove it directly.
+		_, snapName, _ := api.GetParentAndSnapshotName(dbVolSnaps[i].Name)
+		snapVol := b.GetVolume(volType, contentType, drivers.GetSnapshotVolumeName(volStorageName, snapName), dbVolSnaps[i].Config)
+
+		l.Debug("Deleting instance snapshot volume", logger.Ctx{"volName": volStorageName, "snapshotName": snapName})
+
+		snapExists, err := b.driver.HasVolume(snapVol)
+		if err != nil {
+			return err
+		}
+
+		if snapExists {
+			err = b.driver.DeleteVolumeSnapshot(snapVol, op)
+			if err != nil {
+				return fmt.Errorf("Error deleting storage volume snapshot %q: %w", snapName, err)
+			}
+		}
+
+		err = VolumeDBDelete(b, inst.Project().Name, dbVolSnaps[i].Name, volType)
+		if err != nil {
+			return err
+		}
+	}
+
 	// There's no need to pass config as it's not needed when deleting a volume.
 	vol := b.GetVolume(volType, contentType, volStorageName, nil)
 
@@ -2454,6 +3335,8 @@ func (b *backend) DeleteInstance(inst instance.Instance, op *operations.Operatio
 		if err != nil {
 			return fmt.Errorf("Error deleting storage volume: %w", err)
 		}
+
+		vol.ClearCachedDiskPath()
 	}
 
 	// Remove symlinks.
@@ -2519,56 +3402,60 @@ func (b *backend) UpdateInstance(inst instance.Instance, newDesc string, newConf
 		return err
 	}
 
-	// Apply config changes if there are any.
+	// A description-only change doesn't involve the storage driver.
 	changedConfig, userOnly := b.detectChangedConfig(curVol.Config, newConfig)
-	if len(changedConfig) != 0 {
-		// Check that the volume's size property isn't being changed.
-		if changedConfig["size"] != "" {
-			return errors.New(`Instance volume "size" property cannot be changed`)
+	if len(changedConfig) == 0 {
+		if newDesc != curVol.Description {
+			err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.UpdateStoragePoolVolume(ctx, inst.Project().Name, inst.Name(), volDBType, b.ID(), newDesc, curVol.Config)
+			})
+			if err != nil {
+				return err
+			}
 		}
 
-		// Check that the volume's size.state property isn't being changed.
-		if changedConfig["size.state"] != "" {
-			return errors.New(`Instance volume "size.state" property cannot be changed`)
-		}
+		b.state.Events.SendLifecycle(inst.Project().Name, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), inst.Project().Name, op, nil))
 
-		// Check that the volume's block.filesystem property isn't being changed.
-		if changedConfig["block.filesystem"] != "" {
-			return errors.New(`Instance volume "block.filesystem" property cannot be changed`)
-		}
+		return nil
+	}
 
-		// Load storage volume from database.
-		dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
-		if err != nil {
-			return err
-		}
+	// Check that the volume's size property isn't being changed.
+	if changedConfig["size"] != "" {
+		return errors.New(`Instance volume "size" property cannot be changed`)
+	}
 
-		// Generate the effective root device volume for instance.
-		volStorageName := project.Instance(inst.Project().Name, inst.Name())
-		curVol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
-		err = b.applyInstanceRootDiskOverrides(inst, &curVol)
-		if err != nil {
-			return err
-		}
+	// Check that the volume's size.state property isn't being changed.
+	if changedConfig["size.state"] != "" {
+		return errors.New(`Instance volume "size.state" property cannot be changed`)
+	}
 
-		if !userOnly {
-			err = b.driver.UpdateVolume(curVol, changedConfig)
-			if err != nil {
-				return err
-			}
-		}
+	// Check that the volume's block.filesystem property isn't being changed.
+	if changedConfig["block.filesystem"] != "" {
+		return errors.New(`Instance volume "block.filesystem" property cannot be changed`)
 	}
 
-	// Update the database if something changed.
-	if len(changedConfig) != 0 || newDesc != curVol.Description {
-		err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-			return tx.UpdateStoragePoolVolume(ctx, inst.Project().Name, inst.Name(), volDBType, b.ID(), newDesc, newConfig)
-		})
+	// Generate the effective root device volume for instance.
+	vol := b.GetVolume(volType, contentType, volStorageName, curVol.Config)
+	err = b.applyInstanceRootDiskOverrides(inst, &vol)
+	if err != nil {
+		return err
+	}
+
+	if !userOnly {
+		err = b.driver.UpdateVolume(vol, changedConfig)
 		if err != nil {
 			return err
 		}
 	}
 
+	// Update the database.
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateStoragePoolVolume(ctx, inst.Project().Name, inst.Name(), volDBType, b.ID(), newDesc, newConfig)
+	})
+	if err != nil {
+		return err
+	}
+
 	b.state.Events.SendLifecycle(inst.Project().Name, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), inst.Project().Name, op, nil))
 
 	return nil
@@ -2612,6 +3499,10 @@ func (b *backend) MigrateInstance(inst instance.Instance, conn io.ReadWriteClose
 		return errors.New("Snapshots should not be transferred during final sync")
 	}
 
+	if args.VerifyOnly && (args.FinalSync || args.IndexHeaderVersion == 0) {
+		return errors.New("Verify only migration requires the migration index header and cannot be a final sync")
+	}
+
 	if args.Info == nil {
 		return errors.New("Migration info required")
 	}
@@ -2640,6 +3531,10 @@ func (b *backend) MigrateInstance(inst instance.Instance, conn io.ReadWriteClose
 
 	args.Name = inst.Name() // Override args.Name to ensure instance volume is sent.
 
+	// Track the amount of data sent and received over the migration connection.
+	statsConn := newMigrationStatsConn(conn)
+	conn = statsConn
+
 	// Send migration index header frame with volume info and wait for receipt if not doing final sync.
 	if !args.FinalSync {
 		resp, err := b.migrationIndexHeaderSend(l, args.IndexHeaderVersion, conn, args.Info)
@@ -2652,6 +3547,11 @@ func (b *backend) MigrateInstance(inst instance.Instance, conn io.ReadWriteClose
 		}
 	}
 
+	// Wait for the target's verification result and stop before any data is transferred.
+	if args.VerifyOnly {
+		return b.migrationVerifyResultReceive(l, conn, args.MigrationType, op)
+	}
+
 	if !inst.IsSnapshot() && args.Info.Config != nil && args.Info.Config.Container != nil {
 		// Migrate dependent volumes if they exist.
 		err = b.migrateDependentVolumes(inst, conn, args, op)
@@ -2695,6 +3595,144 @@ func (b *backend) MigrateInstance(inst instance.Instance, conn io.ReadWriteClose
 		}
 	}
 
+	return b.migrationStatsReport(l, statsConn, args.MigrationType, op)
+}
+
+// MoveInstanceToMember moves a stopped instance and its snapshots to another cluster member of the local pool.
+// The move goes through the API as a regular cluster move, so the target member receives the volume through
+// CreateInstanceFromMigration with ClusterMoveSourceName set and the instance location is updated in the database.
+// The pool must be defined on the target member, which must have enough free space for the instance and snapshot volumes.
+func (b *backend) MoveInstanceToMember(inst instance.Instance, targetMember string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "targetMember": targetMember})
+	l.Debug("MoveInstanceToMember started")
+	defer l.Debug("MoveInstanceToMember finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	if !b.state.ServerClustered {
+		return api.StatusErrorf(http.StatusBadRequest, "Instances can only be moved between members of a cluster")
+	}
+
+	if b.driver.Info().Remote {
+		return api.StatusErrorf(http.StatusBadRequest, "Instances on remote storage pool %q don't need their volume moved between cluster members", b.name)
+	}
+
+	if inst.IsSnapshot() {
+		return api.StatusErrorf(http.StatusBadRequest, "Instance snapshots can't be moved on their own")
+	}
+
+	if inst.IsRunning() {
+		return api.StatusErrorf(http.StatusBadRequest, "Instance %q must be stopped to be moved to another cluster member", inst.Name())
+	}
+
+	// The volume only exists on the member the instance is located on.
+	if inst.Location() != b.state.ServerName {
+		return api.StatusErrorf(http.StatusBadRequest, "Instance %q must be moved from cluster member %q it is located on", inst.Name(), inst.Location())
+	}
+
+	if targetMember == inst.Location() {
+		return api.StatusErrorf(http.StatusBadRequest, "Instance %q is already located on cluster member %q", inst.Name(), targetMember)
+	}
+
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, err := tx.GetNodeByName(ctx, targetMember)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading cluster member %q: %w", targetMember, err)
+	}
+
+	if !slices.Contains(b.db.Locations, targetMember) {
+		return api.StatusErrorf(http.StatusBadRequest, "Storage pool %q isn't defined on cluster member %q", b.name, targetMember)
+	}
+
+	// Work out the space needed by the instance volume, falling back to its size when usage isn't supported.
+	usage, err := b.GetInstanceUsage(inst)
+	if err != nil {
+		return fmt.Errorf("Failed getting instance usage: %w", err)
+	}
+
+	needed := usage.Used
+	if needed < 0 {
+		needed = usage.Total
+	}
+
+	// The snapshots are moved along with the instance, so account for their usage too.
+	// When their usage can't be retrieved, assume each snapshot is a full copy of the instance volume.
+	if needed > 0 {
+		volType, err := InstanceTypeToVolumeType(inst.Type())
+		if err != nil {
+			return err
+		}
+
+		contentType := InstanceContentType(inst)
+
+		snapshots, err := inst.Snapshots()
+		if err != nil {
+			return err
+		}
+
+		instanceNeeded := needed
+		for _, snap := range snapshots {
+			snapVol := b.GetVolume(volType, contentType, project.Instance(snap.Project().Name, snap.Name()), nil)
+
+			snapUsed, err := b.driver.GetVolumeUsage(snapVol)
+			if err != nil && !errors.Is(err, drivers.ErrNotSupported) {
+				return fmt.Errorf("Failed getting instance snapshot %q usage: %w", snap.Name(), err)
+			}
+
+			if err != nil || snapUsed < 0 {
+				snapUsed = instanceNeeded
+			}
+
+			needed += snapUsed
+		}
+	}
+
+	// Get a local client, the requests using a target are forwarded to that cluster member.
+	args := &incus.ConnectionArgs{
+		SkipGetServer: true,
+		UserAgent:     request.UserAgentClient,
+	}
+
+	client, err := incus.ConnectIncusUnix(b.state.OS.GetUnixSocket(), args)
+	if err != nil {
+		return err
+	}
+
+	resources, err := client.UseTarget(targetMember).GetStoragePoolResources(b.name)
+	if err != nil {
+		return fmt.Errorf("Failed getting storage pool resources on cluster member %q: %w", targetMember, err)
+	}
+
+	if resources.Space.Total > 0 && needed > 0 && resources.Space.Total-resources.Space.Used < uint64(needed) {
+		return api.StatusErrorf(http.StatusInsufficientStorage, "Storage pool %q on cluster member %q doesn't have enough free space for instance %q (%d bytes needed, %d bytes free)", b.name, targetMember, inst.Name(), needed, resources.Space.Total-resources.Space.Used)
+	}
+
+	// Move the instance, this performs the migration between the members and updates its location.
+	remoteOp, err := client.UseProject(inst.Project().Name).UseTarget(targetMember).MigrateInstance(inst.Name(), api.InstancePost{Migration: true})
+	if err != nil {
+		return fmt.Errorf("Failed moving instance %q to cluster member %q: %w", inst.Name(), targetMember, err)
+	}
+
+	// Wait for the move, cancelling it if our own operation gets cancelled.
+	ctx := context.Background()
+	if op != nil {
+		ctx = op.CancelContext()
+	}
+
+	err = remoteOp.WaitContext(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			_ = remoteOp.Cancel()
+		}
+
+		return fmt.Errorf("Failed moving instance %q to cluster member %q: %w", inst.Name(), targetMember, err)
+	}
+
 	return nil
 }
 
@@ -2722,7 +3760,7 @@ func (b *backend) CleanupInstancePaths(inst instance.Instance, op *operations.Op
 	vol := b.GetVolume(volType, contentType, volStorageName, nil)
 
 	// Remove empty snapshot mount paths.
-	snapshotDir := drivers.GetVolumeSnapshotDir(b.Name(), vol.Type(), vol.Name())
+	snapshotDir := drivers.GetVolumeSnapshotDir(b.Name(), b.driver.Config(), vol.Type(), vol.Name())
 
 	ents, err := os.ReadDir(snapshotDir)
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
@@ -2752,6 +3790,12 @@ func (b *backend) CleanupInstancePaths(inst instance.Instance, op *operations.Op
 		return fmt.Errorf("Failed removing instance snapshots directory %q: %w", snapshotDir, err)
 	}
 
+	// Remove the shard directory that held the snapshots directory if no longer used.
+	err = drivers.DeleteSnapshotShardDirIfEmpty(b.Name(), b.driver.Config(), vol.Type(), vol.Name())
+	if err != nil {
+		return err
+	}
+
 	// Remove empty mount path.
 	err = os.Remove(vol.MountPath())
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
@@ -2769,12 +3813,73 @@ func (b *backend) CleanupInstancePaths(inst instance.Instance, op *operations.Op
 		return fmt.Errorf("Failed removing instance snapshots symlink: %w", err)
 	}
 
+	// Remove stale backups directory entries.
+	err = b.removeStaleInstanceBackups(inst)
+	if err != nil {
+		return fmt.Errorf("Failed removing stale instance backups: %w", err)
+	}
+
+	return nil
+}
+
+// removeStaleInstanceBackups removes entries in the instance's backups directory that have no matching backup
+// record, such as those left behind by an interrupted backup, and then the directory itself if empty.
+// Backups still recorded in the database are never removed.
+func (b *backend) removeStaleInstanceBackups(inst instance.Instance) error {
+	backupsPath := internalUtil.VarPath("backups", "instances", project.Instance(inst.Project().Name, inst.Name()))
+
+	ents, err := os.ReadDir(backupsPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed listing instance backups directory %q: %w", backupsPath, err)
+	}
+
+	var backupNames []string
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		backupNames, err = tx.GetInstanceBackups(ctx, inst.Project().Name, inst.Name())
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading instance backups: %w", err)
+	}
+
+	for _, ent := range ents {
+		// Backup names are in the form <instance>/<backup> and stored as <backup> in the backups directory.
+		if slices.Contains(backupNames, inst.Name()+internalInstance.SnapshotDelimiter+ent.Name()) {
+			continue
+		}
+
+		entPath := filepath.Join(backupsPath, ent.Name())
+		b.logger.Debug("Removing stale instance backup", logger.Ctx{"path": entPath})
+
+		err = os.RemoveAll(entPath)
+		if err != nil {
+			return fmt.Errorf("Failed removing stale instance backup %q: %w", entPath, err)
+		}
+	}
+
+	empty, _ := internalUtil.PathIsEmpty(backupsPath)
+	if empty {
+		err = os.Remove(backupsPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Failed removing instance backups directory %q: %w", backupsPath, err)
+		}
+	}
+
 	return nil
 }
 
 // BackupInstance creates an instance backup.
-func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, dependentVolumes bool, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "optimized": optimized, "snapshots": snapshots})
+// If checksum is true, the SHA-256 checksums of the backed up files are computed while they are written and
+// added to the tarball in a manifest file.
+func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, optimizedStrict bool, snapshots bool, dependentVolumes bool, checksum bool, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "optimized": optimized, "optimizedStrict": optimizedStrict, "snapshots": snapshots, "checksum": checksum})
 	l.Debug("BackupInstance started")
 	defer l.Debug("BackupInstance finished")
 
@@ -2785,8 +3890,8 @@ func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewrit
 
 	contentType := InstanceContentType(inst)
 
-	// Load storage volume from database.
-	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	// Load storage volume and its snapshots from database.
+	dbVol, dbSnapshots, err := VolumeDBGetWithSnapshots(b, inst.Project().Name, inst.Name(), volType)
 	if err != nil {
 		return err
 	}
@@ -2799,32 +3904,65 @@ func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewrit
 		return err
 	}
 
-	// Ensure the backup file reflects current config.
-	err = b.UpdateInstanceBackupFile(inst, snapshots, op)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = b.UpdateInstanceBackupFile(inst, true, nil)
-	}()
-
-	var snapNames []string
-	if snapshots {
-		// Get snapshots in age order, oldest first, and pass names to storage driver.
-		instSnapshots, err := inst.Snapshots()
+	// Refuse to silently fall back to a non-optimized backup when strictly requested.
+	if optimized && optimizedStrict {
+		err = checkOptimizedBackup(b, vol)
 		if err != nil {
-			return err
+			return api.StatusErrorf(http.StatusBadRequest, "Cannot create optimized backup of instance %q: %v", inst.Name(), err)
 		}
 
-		snapNames = make([]string, 0, len(instSnapshots))
-		for _, instSnapshot := range instSnapshots {
-			_, snapName, _ := api.GetParentAndSnapshotName(instSnapshot.Name())
-			snapNames = append(snapNames, snapName)
-		}
-	}
+		if dependentVolumes {
+			err = inst.ForEachDependentDiskType(func(dev deviceConfig.DeviceNamed) error {
+				diskPool, err := LoadByName(b.state, dev.Config["pool"])
+				if err != nil {
+					return fmt.Errorf("Failed loading storage pool: %w", err)
+				}
 
-	if dbVol.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
+				diskDBVol, err := VolumeDBGet(diskPool, inst.Project().Name, dev.Config["source"], drivers.VolumeTypeCustom)
+				if err != nil {
+					return err
+				}
+
+				diskVol := diskPool.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(diskDBVol.ContentType), project.StorageVolume(inst.Project().Name, diskDBVol.Name), diskDBVol.Config)
+
+				err = checkOptimizedBackup(diskPool, diskVol)
+				if err != nil {
+					return api.StatusErrorf(http.StatusBadRequest, "Cannot create optimized backup of volume %q: %v", diskDBVol.Name, err)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Ensure the backup file reflects current config.
+	err = b.UpdateInstanceBackupFile(inst, snapshots, op)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = b.UpdateInstanceBackupFile(inst, true, nil)
+	}()
+
+	var snapNames []string
+	if snapshots {
+		// Snapshots are in age order, oldest first, pass names to storage driver.
+		snapNames = make([]string, 0, len(dbSnapshots))
+		for _, dbSnapshot := range dbSnapshots {
+			_, snapName, _ := api.GetParentAndSnapshotName(dbSnapshot.Name)
+			snapNames = append(snapNames, snapName)
+		}
+	}
+
+	if checksum {
+		tarWriter.EnableChecksums()
+	}
+
+	if dbVol.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
 		err = b.qcow2BackupVolume(vol, dbVol, inst.Project().Name, tarWriter, backup.DefaultBackupPrefix, snapNames, op)
 		if err != nil {
 			return err
@@ -2856,6 +3994,13 @@ func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewrit
 		}
 	}
 
+	if checksum {
+		err = tarWriter.WriteChecksumManifest(filepath.Join(backup.DefaultBackupPrefix, "manifest.sha256"))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -2895,28 +4040,76 @@ func (b *backend) GetInstanceUsage(inst instance.Instance) (*VolumeUsage, error)
 	}
 
 	// Get the total size.
-	_, rootDiskConf, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+	total, err := b.EffectiveInstanceRootSize(inst)
+	if err != nil {
+		return nil, err
+	}
+
+	if total >= 0 {
+		val.Total = total
+	}
+
+	return &val, nil
+}
+
+// GetInstanceIOStats returns the cumulative IO counters of the instance's root volume.
+func (b *backend) GetInstanceIOStats(inst instance.Instance) (*drivers.VolumeIOStats, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	l.Debug("GetInstanceIOStats started")
+	defer l.Debug("GetInstanceIOStats finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	// Load storage volume from database, the config is needed to find block backed volumes.
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
 	if err != nil {
 		return nil, err
 	}
 
-	sizeStr, ok := rootDiskConf["size"]
-	if !ok && volType == drivers.VolumeTypeVM {
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
+
+	return b.driver.GetVolumeIOStats(vol)
+}
+
+// EffectiveInstanceRootSize returns the size in bytes the instance's root volume is expected to have.
+// The size is resolved from the root disk device, then the pool's "volume.size" setting and finally the
+// default block size for virtual machines. Returns 0 if the size is unlimited.
+func (b *backend) EffectiveInstanceRootSize(inst instance.Instance) (int64, error) {
+	_, rootDiskConf, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+	if err != nil {
+		return -1, err
+	}
+
+	sizeStr := rootDiskConf["size"]
+	if sizeStr == "" {
+		sizeStr = b.db.Config["volume.size"]
+	}
+
+	if sizeStr == "" && inst.Type() == instancetype.VM {
 		sizeStr = drivers.DefaultBlockSize
 	}
 
-	if sizeStr != "" {
-		total, err := units.ParseByteSizeString(sizeStr)
-		if err != nil {
-			return nil, err
-		}
+	if sizeStr == "" {
+		return 0, nil
+	}
 
-		if total >= 0 {
-			val.Total = total
-		}
+	size, err := units.ParseByteSizeString(sizeStr)
+	if err != nil {
+		return -1, err
 	}
 
-	return &val, nil
+	return size, nil
 }
 
 // SetInstanceQuota sets the quota on the instance's root volume.
@@ -2944,6 +4137,7 @@ func (b *backend) SetInstanceQuota(inst instance.Instance, size string, vmStateS
 	// Apply the main volume quota.
 	// There's no need to pass config as it's not needed when setting quotas.
 	vol := b.GetVolume(volType, contentVolume, volStorageName, dbVol.Config)
+
 	err = b.driver.SetVolumeQuota(vol, size, false, op)
 	if err != nil {
 		return err
@@ -2976,6 +4170,106 @@ func (b *backend) SetInstanceQuota(inst instance.Instance, size string, vmStateS
 	return nil
 }
 
+// RepairVMConfigVolume recreates the config filesystem volume of a VM if it went missing from storage while
+// its block volume survived. The recreated volume is empty, so the firmware state (NVRAM) of the VM is reset.
+// It returns whether the volume had to be recreated.
+func (b *backend) RepairVMConfigVolume(inst instance.Instance, op *operations.Operation) (bool, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	l.Debug("RepairVMConfigVolume started")
+	defer l.Debug("RepairVMConfigVolume finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return false, err
+	}
+
+	if inst.Type() != instancetype.VM {
+		return false, errors.New("Instance must be a virtual machine")
+	}
+
+	if inst.IsSnapshot() {
+		return false, errors.New("Instance must not be a snapshot")
+	}
+
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), drivers.VolumeTypeVM)
+	if err != nil {
+		return false, err
+	}
+
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(drivers.VolumeTypeVM, drivers.ContentTypeBlock, volStorageName, dbVol.Config)
+
+	// Get the effective size.state of the instance.
+	err = b.applyInstanceRootDiskOverrides(inst, &vol)
+	if err != nil {
+		return false, err
+	}
+
+	fsVol := vol.NewVMBlockFilesystemVolume()
+
+	fsVolExists, err := b.driver.HasVolume(fsVol)
+	if err != nil {
+		return false, err
+	}
+
+	if fsVolExists {
+		return false, nil
+	}
+
+	volExists, err := b.driver.HasVolume(vol)
+	if err != nil {
+		return false, err
+	}
+
+	if !volExists {
+		return false, errors.New("Virtual machine block volume is missing, its config filesystem volume can't be repaired")
+	}
+
+	l.Warn("Virtual machine config filesystem volume is missing, recreating it", logger.Ctx{"size": fsVol.ConfigSize()})
+
+	err = b.driver.CreateVolume(fsVol, nil, op)
+	if err != nil {
+		return false, fmt.Errorf("Failed recreating virtual machine config filesystem volume: %w", err)
+	}
+
+	err = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, inst.Name(), vol.MountPath())
+	if err != nil {
+		return false, err
+	}
+
+	l.Warn("Recreated virtual machine config filesystem volume, firmware state (NVRAM) has been reset")
+
+	return true, nil
+}
+
+// checkVMVolumePair checks that the block volume of a virtual machine and its config filesystem volume either
+// both exist or are both missing, returning an error naming the missing one otherwise. Other volumes are ignored.
+func (b *backend) checkVMVolumePair(vol drivers.Volume) error {
+	if !vol.IsVMBlock() {
+		return nil
+	}
+
+	volExists, err := b.driver.HasVolume(vol)
+	if err != nil {
+		return err
+	}
+
+	fsVolExists, err := b.driver.HasVolume(vol.NewVMBlockFilesystemVolume())
+	if err != nil {
+		return err
+	}
+
+	if volExists && !fsVolExists {
+		return errors.New("Virtual machine config filesystem volume is missing while its block volume exists")
+	}
+
+	if !volExists && fsVolExists {
+		return errors.New("Virtual machine block volume is missing while its config filesystem volume exists")
+	}
+
+	return nil
+}
+
 // MountInstance mounts the instance's root volume.
 func (b *backend) MountInstance(inst instance.Instance, op *operations.Operation) (*MountInfo, error) {
 	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
@@ -3142,12 +4436,31 @@ func (b *backend) UnmountInstance(inst instance.Instance, op *operations.Operati
 	return err
 }
 
-// getInstanceDisk returns the location of the disk.
-func (b *backend) getInstanceDisk(inst instance.Instance) (string, error) {
-	if inst.Type() != instancetype.VM {
-		return "", drivers.ErrNotSupported
+// InstanceMountPath returns the resolved on-disk mount path of the instance's volume, or of the snapshot volume
+// if the instance is a snapshot. An error is returned if the path doesn't currently exist.
+func (b *backend) InstanceMountPath(inst instance.Instance) (string, error) {
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return "", err
+	}
+
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, InstanceContentType(inst), volStorageName, nil)
+
+	mountPath, err := filepath.EvalSymlinks(vol.MountPath())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", api.StatusErrorf(http.StatusNotFound, "Mount path %q of instance %q doesn't exist", vol.MountPath(), inst.Name())
+		}
+
+		return "", fmt.Errorf("Failed resolving mount path of instance %q: %w", inst.Name(), err)
 	}
 
+	return mountPath, nil
+}
+
+// getInstanceDisk returns the location of the disk.
+func (b *backend) getInstanceDisk(inst instance.Instance) (string, error) {
 	// Check we can convert the instance to the volume type needed.
 	volType, err := InstanceTypeToVolumeType(inst.Type())
 	if err != nil {
@@ -3158,22 +4471,61 @@ func (b *backend) getInstanceDisk(inst instance.Instance) (string, error) {
 	volStorageName := project.Instance(inst.Project().Name, inst.Name())
 
 	// Get the volume.
-	// There's no need to pass config as it's not needed when getting the
-	// location of the disk block device.
-	vol := b.GetVolume(volType, contentType, volStorageName, nil)
+	// VMs don't need the config to locate the disk block device, but containers need it to
+	// know whether their volume is block-backed.
+	var volConfig map[string]string
+	if inst.Type() != instancetype.VM {
+		dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+		if err != nil {
+			return "", err
+		}
+
+		volConfig = dbVol.Config
+	}
+
+	vol := b.GetVolume(volType, contentType, volStorageName, volConfig)
+
+	// Filesystem-native container volumes don't have a disk block device.
+	if inst.Type() != instancetype.VM && !vol.IsBlockBacked() {
+		return "", drivers.ErrNotSupported
+	}
 
 	// Get the location of the disk block device.
+	diskPath, err := b.getVolumeDiskPath(vol)
+	if err != nil {
+		return "", err
+	}
+
+	return diskPath, nil
+}
+
+// getVolumeDiskPath returns the location of the volume's disk, reusing the cached location while the
+// volume remains mounted.
+func (b *backend) getVolumeDiskPath(vol drivers.Volume) (string, error) {
+	diskPath, gen, found := vol.CachedDiskPath()
+	if found {
+		return diskPath, nil
+	}
+
 	diskPath, err := b.driver.GetVolumeDiskPath(vol)
 	if err != nil {
 		return "", err
 	}
 
+	vol.SetCachedDiskPath(diskPath, gen)
+
 	return diskPath, nil
 }
 
 // CreateInstanceSnapshot creates a snapshot of an instance volume.
-func (b *backend) CreateInstanceSnapshot(inst instance.Instance, src instance.Instance, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "src": src.Name()})
+// The configOverrides are merged over the parent volume config to form the snapshot volume config.
+// If force is true, the pool's snapshots.reserve_percent check is skipped.
+// Snapshots of the same instance are made one at a time. If nowait is true and another snapshot of the instance is
+// in progress, ErrBusy is returned instead of waiting for it.
+// The instance is only frozen once its turn comes, except for stateful VM snapshots whose VM is already paused by
+// the instance driver while saving its state, so they wait in the queue with the VM paused.
+func (b *backend) CreateInstanceSnapshot(inst instance.Instance, src instance.Instance, force bool, nowait bool, configOverrides map[string]string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "src": src.Name(), "configOverrides": configOverrides})
 	l.Debug("CreateInstanceSnapshot started")
 	defer l.Debug("CreateInstanceSnapshot finished")
 
@@ -3197,17 +4549,62 @@ func (b *backend) CreateInstanceSnapshot(inst instance.Instance, src instance.In
 
 	contentType := InstanceContentType(inst)
 
+	if !force {
+		err = b.checkSnapshotReserve(src.Project().Name, src.Name(), volType, contentType)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Lock this operation to ensure that the only one snapshot is made at the time.
+	// Other operations are queued behind this one, unless nowait is set.
+	unlock, err := b.snapshotQueueLock(drivers.OperationLockName("CreateInstanceSnapshot", b.name, volType, contentType, src.Name()), nowait, op)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
 	// Load storage volume from database.
 	srcDBVol, err := VolumeDBGet(b, src.Project().Name, src.Name(), volType)
 	if err != nil {
 		return err
 	}
 
+	var pruneSnapshotNames []string
+
+	_, snapName, _ := api.GetParentAndSnapshotName(inst.Name())
+	if !isRestoreSafetySnapshot(snapName) {
+		pruneSnapshotNames, err = b.checkSnapshotLimit(src.Project().Name, src.Name(), volType, srcDBVol.Config)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Prune the snapshots beyond the limit only once the new snapshot exists, after unfreezing the instance but
+	// still holding the snapshot lock.
+	created := false
+	defer func() {
+		if created {
+			b.pruneSnapshots(src.Project().Name, src.Name(), volType, pruneSnapshotNames, op)
+		}
+	}()
+
+	// Apply the snapshot specific config on top of the parent volume config.
+	snapConfig := util.CloneMap(srcDBVol.Config)
+	for k, v := range configOverrides {
+		if strings.HasPrefix(k, "volatile.") || slices.Contains(snapshotFixedVolumeFields, k) {
+			return fmt.Errorf("Config key %q cannot differ between the snapshot and its parent volume", k)
+		}
+
+		snapConfig[k] = v
+	}
+
 	reverter := revert.New()
 	defer reverter.Fail()
 
 	// Validate config and create database entry for new storage volume.
-	err = VolumeDBCreate(b, inst.Project().Name, inst.Name(), srcDBVol.Description, volType, true, srcDBVol.Config, inst.CreationDate(), time.Time{}, contentType, false, true)
+	err = VolumeDBCreate(b, inst.Project().Name, inst.Name(), srcDBVol.Description, volType, true, snapConfig, inst.CreationDate(), time.Time{}, contentType, false, true)
 	if err != nil {
 		return err
 	}
@@ -3230,22 +4627,13 @@ func (b *backend) CreateInstanceSnapshot(inst instance.Instance, src instance.In
 
 	volStorageName := project.Instance(inst.Project().Name, inst.Name())
 
-	// Get the volume.
-	vol := b.GetVolume(volType, contentType, volStorageName, srcDBVol.Config)
+	// Get the volume, with the config overrides applied so the driver sees them.
+	vol := b.GetVolume(volType, contentType, volStorageName, snapConfig)
 	err = b.applyInstanceRootDiskOverrides(inst, &vol)
 	if err != nil {
 		return err
 	}
 
-	// Lock this operation to ensure that the only one snapshot is made at the time.
-	// Other operations will wait for this one to finish.
-	unlock, err := locking.Lock(context.TODO(), drivers.OperationLockName("CreateInstanceSnapshot", b.name, vol.Type(), contentType, src.Name()))
-	if err != nil {
-		return err
-	}
-
-	defer unlock()
-
 	err = b.driver.CreateVolumeSnapshot(vol, op)
 	if err != nil {
 		return err
@@ -3290,7 +4678,7 @@ func (b *backend) CreateInstanceSnapshot(inst instance.Instance, src instance.In
 		}
 
 		_, snapshotName, _ := api.GetParentAndSnapshotName(inst.Name())
-		err = diskPool.CreateCustomVolumeSnapshot(inst.Project().Name, dev.Config["source"], snapshotName, time.Time{}, inst.IsStateful(), op)
+		err = diskPool.CreateCustomVolumeSnapshot(inst.Project().Name, dev.Config["source"], snapshotName, time.Time{}, inst.IsStateful(), force, false, false, op)
 		if err != nil {
 			return fmt.Errorf("Failed to create device snapshot for volume %q: %w", dev.Config["source"], err)
 		}
@@ -3305,6 +4693,7 @@ func (b *backend) CreateInstanceSnapshot(inst instance.Instance, src instance.In
 		return err
 	}
 
+	created = true
 	reverter.Success()
 	return nil
 }
@@ -3607,45 +4996,119 @@ func (b *backend) CanRestoreInstanceSnapshot(inst instance.Instance, src instanc
 	return nil
 }
 
-// RestoreInstanceSnapshot restores an instance snapshot.
-func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.Instance, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "src": src.Name()})
-	l.Debug("RestoreInstanceSnapshot started")
-	defer l.Debug("RestoreInstanceSnapshot finished")
+// restoreVolume restores a volume from one of its snapshots, reporting the progress to the operation.
+func (b *backend) restoreVolume(vol drivers.Volume, snapshotName string, op *operations.Operation) error {
+	metadata := make(map[string]any)
 
-	reverter := revert.New()
-	defer reverter.Fail()
+	var tracker *ioprogress.ProgressTracker
+	if op != nil {
+		tracker = &ioprogress.ProgressTracker{
+			Handler: func(value, speed int64) {
+				if tracker.Length > 0 {
+					operations.SetProgressMetadata(metadata, "restore_volume", "Restoring volume", value, 0, speed)
+				} else {
+					operations.SetProgressMetadata(metadata, "restore_volume", "Restoring volume", 0, value, speed)
+				}
 
-	if inst.Type() != src.Type() {
-		return errors.New("Instance types must match")
+				_ = op.UpdateMetadata(metadata)
+			},
+		}
 	}
 
-	if inst.IsSnapshot() {
-		return errors.New("Instance must not be snapshot")
+	err := b.driver.RestoreVolume(vol, snapshotName, tracker, op)
+	if err != nil {
+		return err
 	}
 
-	if !src.IsSnapshot() {
-		return errors.New("Source instance must be a snapshot")
+	// Drivers doing an instant rollback don't report any progress, so always report completion.
+	if op != nil {
+		operations.SetProgressMetadata(metadata, "restore_volume", "Restoring volume", 100, 0, 0)
+		_ = op.UpdateMetadata(metadata)
 	}
 
-	// Target instance must not be running.
-	if inst.IsRunning() {
-		return errors.New("Instance must not be running to restore")
-	}
+	return nil
+}
 
-	snaps, err := inst.Snapshots()
+// restoreVolumeByCopy restores a volume by copying the content of one of its snapshots onto it.
+// Unlike a driver rollback, this leaves all of the volume's snapshots in place.
+func (b *backend) restoreVolumeByCopy(vol drivers.Volume, snapVol drivers.Volume, op *operations.Operation) error {
+	bwlimit := b.driver.Config()["rsync.bwlimit"]
+
+	copyVolume := func(vol drivers.Volume, snapVol drivers.Volume) error {
+		return vol.MountTask(func(mountPath string, op *operations.Operation) error {
+			return snapVol.MountTask(func(snapMountPath string, op *operations.Operation) error {
+				if vol.ContentType() != drivers.ContentTypeBlock {
+					_, err := rsync.LocalCopy(snapMountPath, mountPath, bwlimit, true)
+					return err
+				}
+
+				snapDevPath, err := b.driver.GetVolumeDiskPath(snapVol)
+				if err != nil {
+					return err
+				}
+
+				devPath, err := b.driver.GetVolumeDiskPath(vol)
+				if err != nil {
+					return err
+				}
+
+				return drivers.CopyDevice(snapDevPath, devPath)
+			}, op)
+		}, op)
+	}
+
+	// For VMs, restore the config filesystem volume too.
+	if vol.IsVMBlock() {
+		err := copyVolume(vol.NewVMBlockFilesystemVolume(), snapVol.NewVMBlockFilesystemVolume())
+		if err != nil {
+			return err
+		}
+	}
+
+	return copyVolume(vol, snapVol)
+}
+
+// RestoreInstanceSnapshot restores an instance snapshot.
+// If safetySnapshot is true, a snapshot of the current state is taken first and its name returned. That snapshot
+// is kept even if the restore fails and isn't subject to the snapshots.max limit.
+func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.Instance, safetySnapshot bool, op *operations.Operation) (string, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "src": src.Name()})
+	l.Debug("RestoreInstanceSnapshot started")
+	defer l.Debug("RestoreInstanceSnapshot finished")
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	if inst.Type() != src.Type() {
+		return "", errors.New("Instance types must match")
+	}
+
+	if inst.IsSnapshot() {
+		return "", errors.New("Instance must not be snapshot")
+	}
+
+	if !src.IsSnapshot() {
+		return "", errors.New("Source instance must be a snapshot")
+	}
+
+	// Target instance must not be running.
+	if inst.IsRunning() {
+		return "", errors.New("Instance must not be running to restore")
+	}
+
+	snaps, err := inst.Snapshots()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if len(snaps) > 0 && snaps[len(snaps)-1].Name() != src.Name() && inst.HasDependentDisk() {
-		return fmt.Errorf("Snapshot %q cannot be restored due to subsequent snapshot(s).", src.Name())
+		return "", fmt.Errorf("Snapshot %q cannot be restored due to subsequent snapshot(s).", src.Name())
 	}
 
 	// Check we can convert the instance to the volume type needed.
 	volType, err := InstanceTypeToVolumeType(inst.Type())
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	contentType := InstanceContentType(inst)
@@ -3653,7 +5116,7 @@ func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.I
 	// Load storage volume from database.
 	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Generate the effective root device volume for instance.
@@ -3661,17 +5124,33 @@ func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.I
 	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
 	err = b.applyInstanceRootDiskOverrides(inst, &vol)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	_, snapshotName, isSnap := api.GetParentAndSnapshotName(src.Name())
 	if !isSnap {
-		return errors.New("Volume name must be a snapshot")
+		return "", errors.New("Volume name must be a snapshot")
 	}
 
 	srcDBVol, err := VolumeDBGet(b, src.Project().Name, src.Name(), volType)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	// Take a snapshot of the current state first if requested, it is kept even if the restore fails.
+	var safetySnapName string
+	if safetySnapshot {
+		if inst.HasDependentDisk() {
+			return "", errors.New("Pre-restore snapshots aren't supported for instances with dependent disks")
+		}
+
+		safetySnapName = restoreSafetySnapshotName()
+		err = inst.Snapshot(safetySnapName, time.Time{}, false, instance.SnapshotArgs{})
+		if err != nil {
+			return "", fmt.Errorf("Failed creating pre-restore snapshot: %w", err)
+		}
+
+		l.Info("Created pre-restore snapshot", logger.Ctx{"snapshot": safetySnapName})
 	}
 
 	// Restore snapshot volume config if different.
@@ -3679,14 +5158,14 @@ func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.I
 	if len(changedConfig) != 0 || dbVol.Description != srcDBVol.Description {
 		volDBType, err := VolumeTypeToDBType(volType)
 		if err != nil {
-			return err
+			return "", err
 		}
 
 		err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 			return tx.UpdateStoragePoolVolume(ctx, inst.Project().Name, inst.Name(), volDBType, b.ID(), srcDBVol.Description, srcDBVol.Config)
 		})
 		if err != nil {
-			return err
+			return "", err
 		}
 
 		reverter.Add(func() {
@@ -3703,7 +5182,7 @@ func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.I
 			return fmt.Errorf("Failed loading storage pool: %w", err)
 		}
 
-		err = diskPool.RestoreCustomVolume(inst.Project().Name, dev.Config["source"], snapshotName, op)
+		_, err = diskPool.RestoreCustomVolume(inst.Project().Name, dev.Config["source"], snapshotName, false, op)
 		if err != nil {
 			return err
 		}
@@ -3711,10 +5190,14 @@ func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.I
 		return nil
 	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	deleteSnapshots := func(snapshots []string, inst instance.Instance) error {
+		if safetySnapName != "" && slices.Contains(snapshots, safetySnapName) {
+			return fmt.Errorf("Restoring snapshot %q would delete the pre-restore snapshot %q", snapshotName, safetySnapName)
+		}
+
 		// We need to delete some snapshots and try again.
 		snaps, err := inst.Snapshots()
 		if err != nil {
@@ -3746,47 +5229,60 @@ func (b *backend) RestoreInstanceSnapshot(inst instance.Instance, src instance.I
 			if errors.As(err, &snapErr) {
 				err = deleteSnapshots(snapErr.Snapshots, inst)
 				if err != nil {
-					return err
+					return "", err
 				}
 
 				// Now try restoring again.
 				err = b.qcow2RestoreSnapshot(vol, snapVol, inst.Project().Name, op)
 				if err != nil {
-					return err
+					return "", err
 				}
 
-				return nil
+				return safetySnapName, nil
 			}
 
-			return err
+			return "", err
 		}
 
-		return nil
+		return safetySnapName, nil
+	}
+
+	// Rolling back to the snapshot would delete the newer pre-restore snapshot on drivers which can only roll
+	// back to their latest snapshot, so copy the snapshot's content onto the volume instead.
+	if safetySnapName != "" && b.driver.CanRestoreVolume(vol, snapshotName) != nil {
+		snapVol := b.GetVolume(volType, contentType, project.Instance(inst.Project().Name, src.Name()), srcDBVol.Config)
+		err = b.restoreVolumeByCopy(vol, snapVol, op)
+		if err != nil {
+			return "", err
+		}
+
+		reverter.Success()
+		return safetySnapName, nil
 	}
 
-	err = b.driver.RestoreVolume(vol, snapshotName, op)
+	err = b.restoreVolume(vol, snapshotName, op)
 	if err != nil {
 		var snapErr drivers.ErrDeleteSnapshots
 		if errors.As(err, &snapErr) {
 			err = deleteSnapshots(snapErr.Snapshots, inst)
 			if err != nil {
-				return err
+				return "", err
 			}
 
 			// Now try restoring again.
-			err = b.driver.RestoreVolume(vol, snapshotName, op)
+			err = b.restoreVolume(vol, snapshotName, op)
 			if err != nil {
-				return err
+				return "", err
 			}
 
-			return nil
+			return safetySnapName, nil
 		}
 
-		return err
+		return "", err
 	}
 
 	reverter.Success()
-	return nil
+	return safetySnapName, nil
 }
 
 // MountInstanceSnapshot mounts an instance snapshot. It is mounted as read only so that the
@@ -3876,44 +5372,247 @@ func (b *backend) UnmountInstanceSnapshot(inst instance.Instance, op *operations
 	return err
 }
 
+// CleanupSnapshotWritableClones deletes any writable snapshot clone left behind on the pool, for example
+// when the daemon stopped while a clone was in use. It must only be called before any clone can be created.
+func (b *backend) CleanupSnapshotWritableClones() error {
+	if !b.driver.Info().CheapClones {
+		return nil
+	}
+
+	vols, err := b.driver.ListVolumes()
+	if err != nil {
+		if errors.Is(err, drivers.ErrNotSupported) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed listing volumes: %w", err)
+	}
+
+	for _, vol := range vols {
+		if vol.Type() != drivers.VolumeTypeContainer && vol.Type() != drivers.VolumeTypeVM {
+			continue
+		}
+
+		if !strings.HasPrefix(vol.Name(), snapshotWritableClonePrefix) {
+			continue
+		}
+
+		b.logger.Warn("Deleting leftover writable snapshot clone", logger.Ctx{"volName": vol.Name()})
+
+		err = b.driver.DeleteVolume(vol, nil)
+		if err != nil {
+			return fmt.Errorf("Failed deleting snapshot clone %q: %w", vol.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// MountInstanceSnapshotWritableClone mounts a temporary writable clone of an instance snapshot.
+// This is only supported by drivers that can clone a snapshot without copying its data. The returned
+// cleanup function unmounts and destroys the clone and must be called once the caller is done with it.
+func (b *backend) MountInstanceSnapshotWritableClone(inst instance.Instance, op *operations.Operation) (*MountInfo, func() error, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	l.Debug("MountInstanceSnapshotWritableClone started")
+	defer l.Debug("MountInstanceSnapshotWritableClone finished")
+
+	if !inst.IsSnapshot() {
+		return nil, nil, errors.New("Instance must be a snapshot")
+	}
+
+	if !b.driver.Info().CheapClones {
+		return nil, nil, drivers.ErrNotSupported
+	}
+
+	// Check we can convert the instance to the volume type needed.
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Load storage volume from database.
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	// Generate the effective root device volume for instance.
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	snapVol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
+	err = b.applyInstanceRootDiskOverrides(inst, &snapVol)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The clone only lives on disk, so give it a name that can't clash with any instance.
+	cloneStorageName := snapshotWritableClonePrefix + uuid.New().String()
+	cloneVol := b.GetVolume(volType, contentType, cloneStorageName, snapVol.Config())
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	err = b.driver.CreateVolumeFromCopy(cloneVol, snapVol, false, false, op)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed cloning snapshot: %w", err)
+	}
+
+	reverter.Add(func() { _ = b.driver.DeleteVolume(cloneVol, nil) })
+
+	err = b.driver.MountVolume(cloneVol, op)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed mounting snapshot clone: %w", err)
+	}
+
+	reverter.Add(func() { _, _ = b.driver.UnmountVolume(cloneVol, false, nil) })
+
+	mountInfo := &MountInfo{
+		MountPath: cloneVol.MountPath(),
+	}
+
+	if contentType == drivers.ContentTypeBlock {
+		mountInfo.DiskPath, err = b.driver.GetVolumeDiskPath(cloneVol)
+		if err != nil && !errors.Is(err, drivers.ErrNotSupported) {
+			return nil, nil, fmt.Errorf("Failed getting disk path: %w", err)
+		}
+	}
+
+	cleanup := func() error {
+		_, err := b.driver.UnmountVolume(cloneVol, false, nil)
+		if err != nil {
+			return fmt.Errorf("Failed unmounting snapshot clone: %w", err)
+		}
+
+		err = b.driver.DeleteVolume(cloneVol, nil)
+		if err != nil {
+			return fmt.Errorf("Failed deleting snapshot clone: %w", err)
+		}
+
+		return nil
+	}
+
+	reverter.Success()
+	return mountInfo, cleanup, nil
+}
+
+// GetInstanceSnapshotUsage returns the disk usage of an instance snapshot volume.
+// For VMs this includes both the block and config filesystem snapshot volumes.
+func (b *backend) GetInstanceSnapshotUsage(inst instance.Instance) (int64, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	l.Debug("GetInstanceSnapshotUsage started")
+	defer l.Debug("GetInstanceSnapshotUsage finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return -1, err
+	}
+
+	if !inst.IsSnapshot() {
+		return -1, errors.New("Instance must be a snapshot")
+	}
+
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return -1, err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	// Cache the usage of all of the parent's snapshots, so that calling this in a loop stays cheap.
+	parentName, _, _ := api.GetParentAndSnapshotName(inst.Name())
+	parentVol := b.GetVolume(volType, contentType, project.Instance(inst.Project().Name, parentName), nil)
+
+	err = b.driver.CacheVolumeSnapshots(parentVol)
+	if err != nil {
+		return -1, err
+	}
+
+	if parentVol.IsVMBlock() {
+		err = b.driver.CacheVolumeSnapshots(parentVol.NewVMBlockFilesystemVolume())
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	// There's no need to pass config as it's not needed when retrieving the volume usage.
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, nil)
+
+	size, err := b.driver.GetVolumeUsage(vol)
+	if err != nil {
+		if errors.Is(err, drivers.ErrNotSupported) {
+			return -1, nil
+		}
+
+		return -1, err
+	}
+
+	if vol.IsVMBlock() {
+		fsSize, err := b.driver.GetVolumeUsage(vol.NewVMBlockFilesystemVolume())
+		if err != nil && !errors.Is(err, drivers.ErrNotSupported) {
+			return -1, err
+		}
+
+		if err == nil {
+			size += fsSize
+		}
+	}
+
+	return size, nil
+}
+
 // EnsureImage creates an optimized volume of the image if supported by the storage pool driver and the volume
 // doesn't already exist. If the volume already exists then it is checked to ensure it matches the pools current
 // volume settings ("volume.size" and "block.filesystem" if applicable). If not the optimized volume is removed
-// and regenerated to apply the pool's current volume settings.
-func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) error {
+// and regenerated to apply the pool's current volume settings. The returned result indicates whether the volume
+// was created, regenerated or reused, and is EnsureImageNone if the driver doesn't use optimized images.
+func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) (EnsureImageResult, error) {
 	l := b.logger.AddContext(logger.Ctx{"fingerprint": fingerprint})
 	l.Debug("EnsureImage started")
 	defer l.Debug("EnsureImage finished")
 
 	err := b.isStatusReady()
 	if err != nil {
-		return err
+		return EnsureImageNone, err
 	}
 
-	if !b.driver.Info().OptimizedImages {
-		return nil // Nothing to do for drivers that don't support optimized images volumes.
+	if !b.optimizedImagesEnabled() {
+		return EnsureImageNone, nil // Nothing to do for pools that don't use optimized images volumes.
 	}
 
 	// We need to lock this operation to ensure that the image is not being created multiple times.
 	// Uses a lock name of "EnsureImage_<fingerprint>" to avoid deadlocking with CreateVolume below that also
 	// establishes a lock on the volume type & name if it needs to mount the volume before filling.
-	unlock, err := locking.Lock(context.TODO(), drivers.OperationLockName("EnsureImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
+	unlock, err := b.operationLock(drivers.OperationLockName("EnsureImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
 	if err != nil {
-		return err
+		return EnsureImageNone, err
 	}
 
 	defer unlock()
 
+	return b.ensureImage(fingerprint, op)
+}
+
+// ensureImage creates or regenerates the optimized image volume if needed.
+// The caller must hold the EnsureImage lock for the fingerprint.
+func (b *backend) ensureImage(fingerprint string, op *operations.Operation) (EnsureImageResult, error) {
+	l := b.logger.AddContext(logger.Ctx{"fingerprint": fingerprint})
+
+	result := EnsureImageCreated
+
 	var image *api.Image
 
-	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
 		// Load image info from database.
 		_, image, err = tx.GetImageFromAnyProject(ctx, fingerprint)
 
 		return err
 	})
 	if err != nil {
-		return err
+		return EnsureImageNone, err
 	}
 
 	// Derive content type from image type. Image types are not the same as instance types, so don't use
@@ -3927,7 +5626,7 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 	// Try and load any existing volume config on this storage pool so we can compare filesystems if needed.
 	imgDBVol, err := VolumeDBGet(b, api.ProjectDefaultName, fingerprint, drivers.VolumeTypeImage)
 	if err != nil && !response.IsNotFoundError(err) {
-		return err
+		return EnsureImageNone, err
 	}
 
 	// Create the new image volume. No config for an image volume so set to nil.
@@ -3943,7 +5642,7 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 		tmpImgVol := imgVol.Clone()
 		err := b.Driver().FillVolumeConfig(tmpImgVol)
 		if err != nil {
-			return err
+			return EnsureImageNone, err
 		}
 
 		// Add existing image volume's config to imgVol.
@@ -3965,11 +5664,13 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 				l.Debug("Block volume filesystem of pool has changed since cached image volume created, regenerating image volume")
 			}
 
-			err = b.DeleteImage(fingerprint, op)
+			err = b.deleteImage(fingerprint, true, op)
 			if err != nil {
-				return err
+				return EnsureImageNone, err
 			}
 
+			result = EnsureImageRegenerated
+
 			// Reset img volume variables as we just deleted the old one.
 			imgDBVol = nil
 			imgVol = b.GetVolume(drivers.VolumeTypeImage, contentType, fingerprint, nil)
@@ -3979,7 +5680,7 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 	// Check if we already have a suitable volume on storage device.
 	volExists, err := b.driver.HasVolume(imgVol)
 	if err != nil {
-		return err
+		return EnsureImageNone, err
 	}
 
 	if volExists {
@@ -3991,7 +5692,7 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 			l.Debug("Checking image volume size")
 			newVolSize, err := imgVol.ConfigSizeFromSource(imgVol)
 			if err != nil {
-				return err
+				return EnsureImageNone, err
 			}
 
 			imgVol.SetConfigSize(newVolSize)
@@ -4004,19 +5705,21 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 				// If the driver cannot resize the existing image volume to the new policy size
 				// then delete the image volume and try to recreate using the new policy settings.
 				l.Debug("Volume size of pool has changed since cached image volume created and cached volume cannot be resized, regenerating image volume")
-				err = b.DeleteImage(fingerprint, op)
+				err = b.deleteImage(fingerprint, true, op)
 				if err != nil {
-					return err
+					return EnsureImageNone, err
 				}
 
+				result = EnsureImageRegenerated
+
 				// Reset img volume variables as we just deleted the old one.
 				imgDBVol = nil
 				imgVol = b.GetVolume(drivers.VolumeTypeImage, contentType, fingerprint, nil)
 			} else if err != nil {
-				return err
+				return EnsureImageNone, err
 			} else {
 				// We already have a valid volume at the correct size, just return.
-				return nil
+				return EnsureImageReused, nil
 			}
 		} else {
 			// We have an unrecorded on-disk volume, assume it's a partial unpack and delete it.
@@ -4025,7 +5728,7 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 			l.Warn("Deleting leftover/partially unpacked image volume")
 			err = b.driver.DeleteVolume(imgVol, op)
 			if err != nil {
-				return fmt.Errorf("Failed deleting leftover/partially unpacked image volume: %w", err)
+				return EnsureImageNone, fmt.Errorf("Failed deleting leftover/partially unpacked image volume: %w", err)
 			}
 		}
 	}
@@ -4041,7 +5744,7 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 	// Validate config and create database entry for new storage volume.
 	err = VolumeDBCreate(b, api.ProjectDefaultName, fingerprint, "", drivers.VolumeTypeImage, false, imgVol.Config(), time.Now().UTC(), time.Time{}, contentType, false, false)
 	if err != nil {
-		return err
+		return EnsureImageNone, err
 	}
 
 	reverter.Add(func() { _ = VolumeDBDelete(b, api.ProjectDefaultName, fingerprint, drivers.VolumeTypeImage) })
@@ -4064,11 +5767,16 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 
 	err = b.driver.CreateVolume(imgVol, &volFiller, op)
 	if err != nil {
-		return err
+		return EnsureImageNone, err
 	}
 
 	reverter.Add(func() { _ = b.driver.DeleteVolume(imgVol, op) })
 
+	// Don't keep the image volume if the operation was cancelled once the unpack finished.
+	if op != nil && op.CancelContext().Err() != nil {
+		return EnsureImageNone, errors.New("Image unpack cancelled")
+	}
+
 	// If the volume filler has recorded the size of the unpacked volume, then store this in the image DB row.
 	if volFiller.Size != 0 {
 		imgVol.Config()["volatile.rootfs.size"] = fmt.Sprintf("%d", volFiller.Size)
@@ -4077,23 +5785,23 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 			return tx.UpdateStoragePoolVolume(ctx, api.ProjectDefaultName, fingerprint, db.StoragePoolVolumeTypeImage, b.id, "", imgVol.Config())
 		})
 		if err != nil {
-			return err
+			return EnsureImageNone, err
 		}
 	}
 
 	reverter.Success()
-	return nil
+	return result, nil
 }
 
 // shouldUseOptimizedImage determines if an optimized image should be used based on the provided volume config.
 // It returns true if the volume config aligns with the pool's default configuration, and an optimized image does
 // not exist or also matches the pool's default configuration.
 func (b *backend) shouldUseOptimizedImage(fingerprint string, contentType drivers.ContentType, volConfig map[string]string, op *operations.Operation) (bool, error) {
-	canOptimizeImage := b.driver.Info().OptimizedImages
+	canOptimizeImage := b.optimizedImagesEnabled()
 
 	// If the volume config is empty, the default pool configuration is used, making the driver's support
 	// for optimized images the determining factor. However, an optimized image cannot be utilized if the
-	// driver lacks support for it.
+	// driver lacks support for it or if the pool has them disabled.
 	if !canOptimizeImage || len(volConfig) == 0 {
 		return canOptimizeImage, nil
 	}
@@ -4152,13 +5860,29 @@ func volumeConfigsMatch(vol1, vol2 drivers.Volume) bool {
 }
 
 // DeleteImage removes an image from the database and underlying storage device if needed.
-func (b *backend) DeleteImage(fingerprint string, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"fingerprint": fingerprint})
+// Unless force is true, the deletion is refused while the driver reports volumes cloned from the image volume.
+func (b *backend) DeleteImage(fingerprint string, force bool, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"fingerprint": fingerprint, "force": force})
 	l.Debug("DeleteImage started")
 	defer l.Debug("DeleteImage finished")
 
+	// Take the same lock as EnsureImage so the image volume isn't deleted while it's being created or
+	// while an instance is being created from it.
+	unlock, err := b.operationLock(drivers.OperationLockName("EnsureImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	return b.deleteImage(fingerprint, force, op)
+}
+
+// deleteImage removes an image volume from the storage pool.
+// The caller must hold the EnsureImage lock for the fingerprint.
+func (b *backend) deleteImage(fingerprint string, force bool, op *operations.Operation) error {
 	// We need to lock this operation to ensure that the image is not being deleted multiple times.
-	unlock, err := locking.Lock(context.TODO(), drivers.OperationLockName("DeleteImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
+	unlock, err := b.operationLock(drivers.OperationLockName("DeleteImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
 	if err != nil {
 		return err
 	}
@@ -4190,10 +5914,21 @@ func (b *backend) DeleteImage(fingerprint string, op *operations.Operation) erro
 	}
 
 	if volExists {
-		err = b.driver.DeleteVolume(vol, op)
-		if err != nil {
-			return err
-		}
+		if !force {
+			clones, err := b.driver.GetVolumeClones(vol)
+			if err != nil && !errors.Is(err, drivers.ErrNotSupported) {
+				return fmt.Errorf("Failed listing volumes cloned from image volume: %w", err)
+			}
+
+			if len(clones) > 0 {
+				return api.StatusErrorf(http.StatusBadRequest, "Image volume %q still has %d volume(s) cloned from it, use force to delete it anyway", fingerprint, len(clones))
+			}
+		}
+
+		err = b.driver.DeleteVolume(vol, op)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = VolumeDBDelete(b, api.ProjectDefaultName, fingerprint, vol.Type())
@@ -4217,6 +5952,125 @@ func (b *backend) DeleteImage(fingerprint string, op *operations.Operation) erro
 	return nil
 }
 
+// GarbageCollectImages removes the image volumes of the pool that are no longer used.
+// Image volumes whose record refers to an image that doesn't exist in any project anymore are deleted
+// along with their record. Image volumes found on storage without a record on this member are deleted
+// from storage, unless a record shows up while the image lock is held (an image being created concurrently).
+// If dryRun is true, nothing is deleted and all the candidates are returned without the concurrency check.
+// Returns the fingerprints of the deleted volumes, which on error only includes the ones deleted so far.
+func (b *backend) GarbageCollectImages(dryRun bool, op *operations.Operation) ([]string, error) {
+	l := b.logger.AddContext(logger.Ctx{"dryRun": dryRun})
+	l.Debug("GarbageCollectImages started")
+	defer l.Debug("GarbageCollectImages finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	imageVolType := db.StoragePoolVolumeTypeImage
+	dbVolumes := map[string]bool{}
+	staleDBVolumes := []string{}
+
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		vols, err := tx.GetStoragePoolVolumes(ctx, b.ID(), true, db.StorageVolumeFilter{Type: &imageVolType})
+		if err != nil {
+			return fmt.Errorf("Failed loading image volumes: %w", err)
+		}
+
+		for _, vol := range vols {
+			dbVolumes[vol.Name] = true
+
+			_, _, err = tx.GetImageFromAnyProject(ctx, vol.Name)
+			if err != nil {
+				if response.IsNotFoundError(err) {
+					staleDBVolumes = append(staleDBVolumes, vol.Name)
+					continue
+				}
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Find image volumes on storage that have no database record.
+	vols, err := b.driver.ListVolumes()
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing volumes: %w", err)
+	}
+
+	orphanVols := []drivers.Volume{}
+	for _, vol := range vols {
+		if vol.Type() == drivers.VolumeTypeImage && !dbVolumes[vol.Name()] {
+			orphanVols = append(orphanVols, vol)
+		}
+	}
+
+	removed := make([]string, 0, len(staleDBVolumes)+len(orphanVols))
+
+	for _, fingerprint := range staleDBVolumes {
+		if dryRun {
+			removed = append(removed, fingerprint)
+			continue
+		}
+
+		// Hold the EnsureImage lock so that an image being re-added concurrently isn't removed.
+		unlock, err := b.operationLock(drivers.OperationLockName("EnsureImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
+		if err != nil {
+			return removed, err
+		}
+
+		err = b.deleteImage(fingerprint, true, op)
+		unlock()
+		if err != nil {
+			return removed, fmt.Errorf("Failed deleting image volume %q: %w", fingerprint, err)
+		}
+
+		l.Info("Deleted image volume of missing image", logger.Ctx{"fingerprint": fingerprint})
+		removed = append(removed, fingerprint)
+	}
+
+	for _, vol := range orphanVols {
+		if dryRun {
+			removed = append(removed, vol.Name())
+			continue
+		}
+
+		unlock, err := b.operationLock(drivers.OperationLockName("EnsureImage", b.name, drivers.VolumeTypeImage, "", vol.Name()))
+		if err != nil {
+			return removed, err
+		}
+
+		// Check again now that the lock is held, the volume may have just finished being created.
+		_, err = VolumeDBGet(b, api.ProjectDefaultName, vol.Name(), drivers.VolumeTypeImage)
+		if err == nil {
+			unlock()
+			continue
+		}
+
+		if !response.IsNotFoundError(err) {
+			unlock()
+			return removed, err
+		}
+
+		err = b.driver.DeleteVolume(vol, op)
+		unlock()
+		if err != nil {
+			return removed, fmt.Errorf("Failed deleting left over image volume %q (%s): %w", vol.Name(), vol.ContentType(), err)
+		}
+
+		l.Info("Deleted left over image volume", logger.Ctx{"volName": vol.Name(), "contentType": vol.ContentType()})
+		removed = append(removed, vol.Name())
+	}
+
+	return removed, nil
+}
+
 // updateVolumeDescriptionOnly is a helper function used when handling update requests for volumes
 // that only allow their descriptions to be updated. If any config supplied differs from the
 // current volume's config then an error is returned.
@@ -4281,6 +6135,66 @@ func (b *backend) UpdateImage(fingerprint, newDesc string, newConfig map[string]
 	return b.updateVolumeDescriptionOnly(api.ProjectDefaultName, fingerprint, drivers.VolumeTypeImage, newDesc, newConfig, op)
 }
 
+// RecomputeImageVolumeSize measures the unpacked size of the image again and updates the volatile.rootfs.size
+// setting of its image volume if it differs from the recorded one. It returns whether the setting was changed.
+// Only virtual machine images record their unpacked size, so a setting found on other image volumes is removed.
+func (b *backend) RecomputeImageVolumeSize(fingerprint string, op *operations.Operation) (bool, error) {
+	l := b.logger.AddContext(logger.Ctx{"fingerprint": fingerprint})
+	l.Debug("RecomputeImageVolumeSize started")
+	defer l.Debug("RecomputeImageVolumeSize finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return false, err
+	}
+
+	// Lock the image volume so it isn't being created or regenerated while measuring it.
+	unlock, err := b.operationLock(drivers.OperationLockName("EnsureImage", b.name, drivers.VolumeTypeImage, "", fingerprint))
+	if err != nil {
+		return false, err
+	}
+
+	defer unlock()
+
+	imgDBVol, err := VolumeDBGet(b, api.ProjectDefaultName, fingerprint, drivers.VolumeTypeImage)
+	if err != nil {
+		return false, err
+	}
+
+	newSize := ""
+	if imgDBVol.ContentType == db.StoragePoolVolumeContentTypeNameBlock {
+		size, err := ImageRootDiskSize(internalUtil.VarPath("images", fingerprint), b.state.OS)
+		if err != nil {
+			return false, fmt.Errorf("Failed measuring image size: %w", err)
+		}
+
+		newSize = fmt.Sprintf("%d", size)
+	}
+
+	curSize := imgDBVol.Config["volatile.rootfs.size"]
+	if curSize == newSize {
+		return false, nil
+	}
+
+	newConfig := util.CloneMap(imgDBVol.Config)
+	if newSize == "" {
+		delete(newConfig, "volatile.rootfs.size")
+	} else {
+		newConfig["volatile.rootfs.size"] = newSize
+	}
+
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateStoragePoolVolume(ctx, api.ProjectDefaultName, fingerprint, db.StoragePoolVolumeTypeImage, b.id, imgDBVol.Description, newConfig)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	l.Info("Updated image volume size", logger.Ctx{"oldSize": curSize, "newSize": newSize})
+
+	return true, nil
+}
+
 // CreateBucket creates an object bucket.
 func (b *backend) CreateBucket(projectName string, bucket api.StorageBucketsPost, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": projectName, "bucketName": bucket.Name, "desc": bucket.Description, "config": bucket.Config})
@@ -4559,8 +6473,22 @@ func (b *backend) ImportBucket(projectName string, poolVol *backupConfig.Config,
 		StorageBucketPut: poolVol.Bucket.StorageBucketPut,
 	}
 
+	memberSpecific := !b.Driver().Info().Remote // Member specific if storage pool isn't remote.
+
+	// Check the bucket exists on remote storage before recording it.
+	if !memberSpecific {
+		bucketNames, err := b.driver.ListBuckets()
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(bucketNames, bucket.Name) {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Bucket %q doesn't exist on the storage", bucket.Name)
+		}
+	}
+
 	// Validate config and create database entry for restored bucket.
-	bucketID, err := BucketDBCreate(b.state.ShutdownCtx, b, projectName, true, bucket)
+	bucketID, err := BucketDBCreate(b.state.ShutdownCtx, b, projectName, memberSpecific, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -4576,10 +6504,33 @@ func (b *backend) ImportBucket(projectName string, poolVol *backupConfig.Config,
 		return nil, err
 	}
 
-	memberSpecific := !b.Driver().Info().Remote // Member specific if storage pool isn't remote.
-
+	// Local bucket keys are only stored in the database, remote ones are recreated from the storage.
 	if !memberSpecific {
-		return nil, errors.New("Importing buckets from a remote storage is not supported")
+		keys, err := b.driver.ListBucketKeys(storageBucket)
+		if err != nil {
+			return nil, fmt.Errorf("Failed listing bucket keys: %w", err)
+		}
+
+		err = b.state.DB.Cluster.Transaction(b.state.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+			for _, key := range keys {
+				_, err := tx.CreateStoragePoolBucketKey(ctx, bucketID, api.StorageBucketKeysPost{
+					Name: key.Name,
+					StorageBucketKeyPut: api.StorageBucketKeyPut{
+						Role:      key.Role,
+						AccessKey: key.AccessKey,
+						SecretKey: key.SecretKey,
+					},
+				})
+				if err != nil {
+					return fmt.Errorf("Failed recording bucket key %q: %w", key.Name, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	cleanup := reverter.Clone().Fail
@@ -4625,7 +6576,11 @@ func (b *backend) CreateBucketKey(projectName string, bucketName string, key api
 		SecretKey: key.SecretKey,
 	}
 
-	err = b.driver.ValidateBucketKey(key.Name, creds, key.Role)
+	if key.PathPrefix != "" && !memberSpecific {
+		return nil, errors.New("Key path prefixes are only supported on local buckets")
+	}
+
+	err = b.driver.ValidateBucketKey(key.Name, creds, key.Role, key.PathPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -4659,6 +6614,7 @@ func (b *backend) CreateBucketKey(projectName string, bucketName string, key api
 			Role:        key.Role,
 			AccessKey:   key.AccessKey,
 			SecretKey:   key.SecretKey,
+			PathPrefix:  key.PathPrefix,
 		},
 	}
 
@@ -4739,7 +6695,11 @@ func (b *backend) UpdateBucketKey(projectName string, bucketName string, keyName
 		SecretKey: newBucketKey.SecretKey,
 	}
 
-	err = b.driver.ValidateBucketKey(keyName, creds, key.Role)
+	if key.PathPrefix != "" && !memberSpecific {
+		return errors.New("Key path prefixes are only supported on local buckets")
+	}
+
+	err = b.driver.ValidateBucketKey(keyName, creds, key.Role, key.PathPrefix)
 	if err != nil {
 		return err
 	}
@@ -4834,127 +6794,416 @@ func (b *backend) DeleteBucketKey(projectName string, bucketName string, keyName
 	return nil
 }
 
-// MountLocalBucket mounts the local bucket volume and returns its mount path
-// along with an unmount function that the caller must invoke when finished.
-func (b *backend) MountLocalBucket(projectName string, bucketName string, op *operations.Operation) (string, func() error, error) {
+// ExportBucketKeys returns the keys of a bucket as recorded in the database, including their credentials.
+// The storage isn't accessed, so this can be used to audit the keys while the bucket isn't active.
+func (b *backend) ExportBucketKeys(projectName string, bucketName string) ([]api.StorageBucketKey, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "bucketName": bucketName})
+	l.Debug("ExportBucketKeys started")
+	defer l.Debug("ExportBucketKeys finished")
+
 	if !b.Driver().Info().Buckets {
-		return "", nil, errors.New("Storage pool does not support buckets")
+		return nil, errors.New("Storage pool does not support buckets")
 	}
 
-	if b.Driver().Info().Remote {
-		return "", nil, errors.New("Remote buckets cannot be mounted locally")
-	}
+	memberSpecific := !b.Driver().Info().Remote // Member specific if storage pool isn't remote.
 
-	bucketVolName := project.StorageVolume(projectName, bucketName)
-	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, nil)
+	var dbKeys []*db.StorageBucketKey
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err := tx.GetStoragePoolBucket(ctx, b.id, projectName, memberSpecific, bucketName)
+		if err != nil {
+			return err
+		}
 
-	err := b.driver.MountVolume(bucketVol, op)
+		dbKeys, err = tx.GetStoragePoolBucketKeys(ctx, bucket.ID)
+		return err
+	})
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
-	unmount := func() error {
-		_, err := b.driver.UnmountVolume(bucketVol, false, op)
-		if err != nil && !errors.Is(err, drivers.ErrInUse) {
-			return err
-		}
-
-		return nil
+	keys := make([]api.StorageBucketKey, 0, len(dbKeys))
+	for _, dbKey := range dbKeys {
+		keys = append(keys, dbKey.StorageBucketKey)
 	}
 
-	return bucketVol.MountPath(), unmount, nil
+	return keys, nil
 }
 
-// GetBucketURL returns S3 URL for bucket.
-func (b *backend) GetBucketURL(bucketName string) *url.URL {
+// ImportBucketKeysToStorage pushes the keys of a bucket recorded in the database to the storage, creating the
+// missing ones and updating those whose credentials or role differ. Local bucket keys are only stored in the
+// database, so there is nothing to do for them.
+func (b *backend) ImportBucketKeysToStorage(projectName string, bucketName string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "bucketName": bucketName})
+	l.Debug("ImportBucketKeysToStorage started")
+	defer l.Debug("ImportBucketKeysToStorage finished")
+
 	err := b.isStatusReady()
 	if err != nil {
-		return nil
+		return err
 	}
 
 	if !b.Driver().Info().Buckets {
-		return nil
+		return errors.New("Storage pool does not support buckets")
 	}
 
-	memberSpecific := !b.Driver().Info().Remote // Member specific if storage pool isn't remote.
-
-	if memberSpecific {
-		// Handle common MinIO implementation for local storage drivers.
+	if !b.Driver().Info().Remote {
+		return nil
+	}
 
-		// Check that the storage buckets listener is configured via core.storage_buckets_address.
-		storageBucketsAddress := b.state.Endpoints.StorageBucketsAddress()
-		if storageBucketsAddress == "" {
-			return nil
+	var bucket *db.StorageBucket
+	var dbKeys []*db.StorageBucketKey
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, false, bucketName)
+		if err != nil {
+			return err
 		}
 
-		return &api.NewURL().Scheme("https").Host(storageBucketsAddress).Path(bucketName).URL
+		dbKeys, err = tx.GetStoragePoolBucketKeys(ctx, bucket.ID)
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
-	// Handle per-driver implementation for remote storage drivers.
-	return b.driver.GetBucketURL(bucketName)
-}
-
-// CreateCustomVolume creates an empty custom volume.
-func (b *backend) CreateCustomVolume(projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "desc": desc, "config": config, "contentType": contentType})
-	l.Debug("CreateCustomVolume started")
-	defer l.Debug("CreateCustomVolume finished")
+	bucketVolName := project.StorageVolume(projectName, bucket.Name)
+	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, bucket.Config)
 
-	err := b.isStatusReady()
+	storageKeys, err := b.driver.ListBucketKeys(bucketVol)
 	if err != nil {
-		return err
+		return fmt.Errorf("Failed listing bucket keys: %w", err)
 	}
 
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, volName)
-	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, config)
-
-	storagePoolSupported := slices.Contains(b.Driver().Info().VolumeTypes, drivers.VolumeTypeCustom)
-	if !storagePoolSupported {
-		return errors.New("Storage pool does not support custom volume type")
+	existing := make(map[string]drivers.S3BucketKey, len(storageKeys))
+	for _, storageKey := range storageKeys {
+		existing[storageKey.Name] = storageKey
 	}
 
-	reverter := revert.New()
-	defer reverter.Fail()
+	for _, dbKey := range dbKeys {
+		creds := drivers.S3Credentials{
+			AccessKey: dbKey.AccessKey,
+			SecretKey: dbKey.SecretKey,
+		}
 
-	// Validate config and create database entry for new storage volume.
-	err = VolumeDBCreate(b, projectName, volName, desc, vol.Type(), false, vol.Config(), time.Now().UTC(), time.Time{}, vol.ContentType(), false, false)
-	if err != nil {
-		return err
-	}
+		storageKey, found := existing[dbKey.Name]
+		if !found {
+			l.Debug("Creating missing bucket key on storage", logger.Ctx{"keyName": dbKey.Name})
 
-	reverter.Add(func() { _ = VolumeDBDelete(b, projectName, volName, vol.Type()) })
+			_, err = b.driver.CreateBucketKey(bucketVol, dbKey.Name, creds, dbKey.Role, op)
+			if err != nil {
+				return fmt.Errorf("Failed creating bucket key %q: %w", dbKey.Name, err)
+			}
 
-	// Create the empty custom volume on the storage device.
-	err = b.driver.CreateVolume(vol, nil, op)
-	if err != nil {
-		return err
-	}
+			continue
+		}
 
-	eventCtx := logger.Ctx{"type": vol.Type()}
+		if storageKey.S3Credentials == creds && storageKey.Role == dbKey.Role {
+			continue
+		}
 
-	var location string
-	if b.state.ServerClustered && !b.Driver().Info().Remote {
-		eventCtx["location"] = b.state.ServerName
-		location = b.state.ServerName
-	}
+		l.Debug("Updating bucket key on storage", logger.Ctx{"keyName": dbKey.Name})
 
-	// Record new volume with authorizer.
-	err = b.state.Authorizer.AddStoragePoolVolume(b.state.ShutdownCtx, projectName, b.Name(), vol.Type().Singular(), volName, location)
-	if err != nil {
-		logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": volName, "type": vol.Type(), "pool": b.Name(), "project": projectName, "error": err})
+		_, err = b.driver.UpdateBucketKey(bucketVol, dbKey.Name, creds, dbKey.Role, op)
+		if err != nil {
+			return fmt.Errorf("Failed updating bucket key %q: %w", dbKey.Name, err)
+		}
 	}
 
-	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeCreated.Event(vol, string(vol.Type()), projectName, op, eventCtx))
-
-	reverter.Success()
 	return nil
 }
 
-// CreateCustomVolumeFromCopy creates a custom volume from an existing custom volume.
-// It copies the snapshots from the source volume by default, but can be disabled if requested.
-func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName string, volName string, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "srcProjectName": srcProjectName, "volName": volName, "desc": desc, "config": config, "srcPoolName": srcPoolName, "srcVolName": srcVolName, "snapshots": snapshots})
+// MountLocalBucket mounts the local bucket volume and returns its mount path
+// along with an unmount function that the caller must invoke when finished.
+func (b *backend) MountLocalBucket(projectName string, bucketName string, op *operations.Operation) (string, func() error, error) {
+	if !b.Driver().Info().Buckets {
+		return "", nil, errors.New("Storage pool does not support buckets")
+	}
+
+	if b.Driver().Info().Remote {
+		return "", nil, errors.New("Remote buckets cannot be mounted locally")
+	}
+
+	bucketVolName := project.StorageVolume(projectName, bucketName)
+	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, nil)
+
+	err := b.driver.MountVolume(bucketVol, op)
+	if err != nil {
+		return "", nil, err
+	}
+
+	bucketMountsMu.Lock()
+	if bucketMounts[b.name] == nil {
+		bucketMounts[b.name] = make(map[string]*bucketMount)
+	}
+
+	mount := bucketMounts[b.name][bucketVolName]
+	if mount == nil {
+		mount = &bucketMount{projectName: projectName, bucketName: bucketName}
+		bucketMounts[b.name][bucketVolName] = mount
+	}
+
+	mount.refs++
+	bucketMountsMu.Unlock()
+
+	var once sync.Once
+	unmount := func() error {
+		var err error
+
+		once.Do(func() {
+			// Skip the unmount if the mount was already released by StopBucketProcess.
+			bucketMountsMu.Lock()
+			if bucketMounts[b.name][bucketVolName] != mount || mount.refs == 0 {
+				bucketMountsMu.Unlock()
+				return
+			}
+
+			mount.refs--
+			if mount.refs == 0 {
+				delete(bucketMounts[b.name], bucketVolName)
+			}
+
+			bucketMountsMu.Unlock()
+
+			_, err = b.driver.UnmountVolume(bucketVol, false, op)
+			if errors.Is(err, drivers.ErrInUse) {
+				err = nil
+			}
+		})
+
+		return err
+	}
+
+	return bucketVol.MountPath(), unmount, nil
+}
+
+// ListActiveBuckets returns the local buckets whose volume is currently held mounted by in-flight S3 requests.
+func (b *backend) ListActiveBuckets() ([]ActiveBucket, error) {
+	bucketMountsMu.Lock()
+	defer bucketMountsMu.Unlock()
+
+	activeBuckets := make([]ActiveBucket, 0, len(bucketMounts[b.name]))
+	for _, mount := range bucketMounts[b.name] {
+		activeBuckets = append(activeBuckets, ActiveBucket{Project: mount.projectName, Name: mount.bucketName, References: mount.refs})
+	}
+
+	slices.SortFunc(activeBuckets, func(x ActiveBucket, y ActiveBucket) int {
+		return cmp.Or(strings.Compare(x.Project, y.Project), strings.Compare(x.Name, y.Name))
+	})
+
+	return activeBuckets, nil
+}
+
+// StopBucketProcess forcefully releases the mounts of a local bucket volume held by in-flight S3 requests,
+// so that a bucket left busy by a request that failed to finish can be updated or deleted again.
+// Local buckets are served in-process, so any request still running loses access to the bucket's data.
+func (b *backend) StopBucketProcess(projectName string, bucketName string) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "bucket": bucketName})
+	l.Debug("StopBucketProcess started")
+	defer l.Debug("StopBucketProcess finished")
+
+	bucketVolName := project.StorageVolume(projectName, bucketName)
+
+	bucketMountsMu.Lock()
+	mount := bucketMounts[b.name][bucketVolName]
+	if mount == nil {
+		bucketMountsMu.Unlock()
+		return api.StatusErrorf(http.StatusNotFound, "Storage bucket %q isn't active", bucketName)
+	}
+
+	refs := mount.refs
+	mount.refs = 0
+	delete(bucketMounts[b.name], bucketVolName)
+	bucketMountsMu.Unlock()
+
+	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, nil)
+
+	// Drop each reference taken by MountLocalBucket, only the last one actually unmounts the volume.
+	for range refs {
+		_, err := b.driver.UnmountVolume(bucketVol, false, nil)
+		if err != nil && !errors.Is(err, drivers.ErrInUse) {
+			return fmt.Errorf("Failed unmounting storage bucket %q: %w", bucketName, err)
+		}
+	}
+
+	l.Info("Released storage bucket mounts", logger.Ctx{"references": refs})
+
+	return nil
+}
+
+// GetBucketURL returns S3 URL for bucket.
+func (b *backend) GetBucketURL(bucketName string) *url.URL {
+	err := b.isStatusReady()
+	if err != nil {
+		return nil
+	}
+
+	if !b.Driver().Info().Buckets {
+		return nil
+	}
+
+	memberSpecific := !b.Driver().Info().Remote // Member specific if storage pool isn't remote.
+
+	if memberSpecific {
+		// Handle common MinIO implementation for local storage drivers.
+
+		// Check that the storage buckets listener is configured via core.storage_buckets_address.
+		storageBucketsAddress := b.state.Endpoints.StorageBucketsAddress()
+		if storageBucketsAddress == "" {
+			return nil
+		}
+
+		return &api.NewURL().Scheme("https").Host(storageBucketsAddress).Path(bucketName).URL
+	}
+
+	// Handle per-driver implementation for remote storage drivers.
+	return b.driver.GetBucketURL(bucketName)
+}
+
+// GetBucketUsage returns the number of objects and the disk space used by the bucket.
+func (b *backend) GetBucketUsage(projectName string, bucketName string) (*VolumeUsage, error) {
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	if !b.Driver().Info().Buckets {
+		return nil, errors.New("Storage pool does not support buckets")
+	}
+
+	memberSpecific := !b.Driver().Info().Remote // Member specific if storage pool isn't remote.
+
+	var bucket *db.StorageBucket
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, memberSpecific, bucketName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	val := VolumeUsage{}
+
+	var objects, size int64
+	if memberSpecific {
+		// Handle common implementation for local storage drivers.
+		mountPath, unmount, err := b.MountLocalBucket(projectName, bucketName, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		defer logger.WarnOnError(unmount, "Failed to unmount bucket")
+
+		objects, size, err = local.NewServer(mountPath, nil).Usage()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Handle per-driver implementation for remote storage drivers.
+		bucketVolName := project.StorageVolume(projectName, bucket.Name)
+		bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, bucket.Config)
+
+		objects, size, err = b.driver.GetBucketUsage(bucketVol)
+		if err != nil && !errors.Is(err, drivers.ErrNotSupported) {
+			return nil, err
+		}
+	}
+
+	if err != nil {
+		val.Used = -1
+		val.Objects = -1
+	} else {
+		val.Used = size
+		val.Objects = objects
+	}
+
+	// Get the total size.
+	sizeStr, ok := bucket.Config["size"]
+	if ok {
+		total, err := units.ParseByteSizeString(sizeStr)
+		if err != nil {
+			return nil, err
+		}
+
+		if total >= 0 {
+			val.Total = total
+		}
+	}
+
+	return &val, nil
+}
+
+// CreateCustomVolume creates an empty custom volume.
+// If template is set, the pool's named volume template is used as the base config and the supplied config is
+// applied on top of it, with an empty value removing the template's key.
+func (b *backend) CreateCustomVolume(projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, template string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "desc": desc, "config": config, "contentType": contentType, "template": template})
+	l.Debug("CreateCustomVolume started")
+	defer l.Debug("CreateCustomVolume finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	if template != "" {
+		config, err = VolumeTemplateConfig(b, template, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, config)
+
+	storagePoolSupported := slices.Contains(b.Driver().Info().VolumeTypes, drivers.VolumeTypeCustom)
+	if !storagePoolSupported {
+		return errors.New("Storage pool does not support custom volume type")
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Validate config and create database entry for new storage volume.
+	err = VolumeDBCreate(b, projectName, volName, desc, vol.Type(), false, vol.Config(), time.Now().UTC(), time.Time{}, vol.ContentType(), false, false)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = VolumeDBDelete(b, projectName, volName, vol.Type()) })
+
+	// Create the empty custom volume on the storage device.
+	err = b.driver.CreateVolume(vol, nil, op)
+	if err != nil {
+		return err
+	}
+
+	eventCtx := logger.Ctx{"type": vol.Type()}
+
+	var location string
+	if b.state.ServerClustered && !b.Driver().Info().Remote {
+		eventCtx["location"] = b.state.ServerName
+		location = b.state.ServerName
+	}
+
+	// Record new volume with authorizer.
+	err = b.state.Authorizer.AddStoragePoolVolume(b.state.ShutdownCtx, projectName, b.Name(), vol.Type().Singular(), volName, location)
+	if err != nil {
+		logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": volName, "type": vol.Type(), "pool": b.Name(), "project": projectName, "error": err})
+	}
+
+	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeCreated.Event(vol, string(vol.Type()), projectName, op, eventCtx))
+
+	reverter.Success()
+	return nil
+}
+
+// CreateCustomVolumeFromCopy creates a custom volume from an existing custom volume.
+// It copies the snapshots from the source volume by default, but can be disabled if requested.
+// If snapshots is true and onlySnapshots isn't empty, only the named source snapshots are copied.
+// If targetContentType is set and differs from the source, the volume content is converted during the copy.
+// If verify is set, copies between pools compare checksums of the source and new volume content once done.
+func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName string, volName string, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, onlySnapshots []string, targetContentType drivers.ContentType, verify bool, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "srcProjectName": srcProjectName, "volName": volName, "desc": desc, "config": config, "srcPoolName": srcPoolName, "srcVolName": srcVolName, "snapshots": snapshots, "onlySnapshots": onlySnapshots, "targetContentType": targetContentType, "verify": verify})
 	l.Debug("CreateCustomVolumeFromCopy started")
 	defer l.Debug("CreateCustomVolumeFromCopy finished")
 
@@ -4985,8 +7234,27 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 		return fmt.Errorf("Failed generating volume copy config: %w", err)
 	}
 
+	// Only keep the requested snapshots in the source config.
+	partialSnapshots := false
+	if snapshots && len(onlySnapshots) > 0 {
+		for _, snapName := range onlySnapshots {
+			if !slices.ContainsFunc(srcConfig.VolumeSnapshots, func(snap *api.StorageVolumeSnapshot) bool { return snap.Name == snapName }) {
+				return api.StatusErrorf(http.StatusNotFound, "Snapshot %q of source volume %q doesn't exist", snapName, srcVolName)
+			}
+		}
+
+		allSnapshotsCount := len(srcConfig.VolumeSnapshots)
+
+		srcConfig.VolumeSnapshots = slices.DeleteFunc(srcConfig.VolumeSnapshots, func(snap *api.StorageVolumeSnapshot) bool {
+			return !slices.Contains(onlySnapshots, snap.Name)
+		})
+
+		partialSnapshots = len(srcConfig.VolumeSnapshots) < allSnapshotsCount
+	}
+
 	// Use the source volume's config if not supplied.
-	if config == nil {
+	usingSrcConfig := config == nil
+	if usingSrcConfig {
 		config = srcConfig.Volume.Config
 	}
 
@@ -5028,38 +7296,87 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 	srcVolStorageName := project.StorageVolume(srcProjectName, srcVolName)
 	srcVol := srcPool.GetVolume(drivers.VolumeTypeCustom, contentType, srcVolStorageName, srcConfig.Volume.Config)
 
-	// If the source and target are in the same pool then use CreateVolumeFromCopy rather than
-	// migration system as it will be quicker.
-	if srcPool == b {
-		l.Debug("CreateCustomVolumeFromCopy same-pool mode detected")
+	// If a different content type is requested then perform a content-aware copy.
+	if targetContentType != "" && targetContentType != contentType {
+		l.Debug("CreateCustomVolumeFromCopy content type conversion mode detected")
+
+		if len(snapshotNames) > 0 {
+			return errors.New("Snapshots cannot be copied when converting the volume content type")
+		}
+
+		// When using the source volume's config, copy it so the source volume's config isn't modified.
+		if usingSrcConfig {
+			config = maps.Clone(config)
+		}
 
-		// Get the volume name on storage.
 		volStorageName := project.StorageVolume(projectName, volName)
-		vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, config)
+		vol := b.GetVolume(drivers.VolumeTypeCustom, targetContentType, volStorageName, config)
 
-		// Validate config and create database entry for new storage volume.
-		err = VolumeDBCreate(b, projectName, volName, desc, vol.Type(), false, vol.Config(), time.Now().UTC(), time.Time{}, vol.ContentType(), false, true)
+		err = VolumeDBCreate(b, projectName, volName, desc, vol.Type(), false, vol.Config(), time.Now().UTC(), time.Time{}, vol.ContentType(), usingSrcConfig, false)
 		if err != nil {
 			return err
 		}
 
 		reverter.Add(func() { _ = VolumeDBDelete(b, projectName, volName, vol.Type()) })
 
-		// Create database entries for new storage volume snapshots.
-		for i, snapName := range snapshotNames {
-			newSnapshotName := drivers.GetSnapshotVolumeName(volName, snapName)
-			var volumeSnapExpiryDate time.Time
-			if srcConfig.VolumeSnapshots[i].ExpiresAt != nil {
-				volumeSnapExpiryDate = *srcConfig.VolumeSnapshots[i].ExpiresAt
-			}
-
-			// Validate config and create database entry for new storage volume.
-			err = VolumeDBCreate(b, projectName, newSnapshotName, srcConfig.VolumeSnapshots[i].Description, vol.Type(), true, srcConfig.VolumeSnapshots[i].Config, srcConfig.VolumeSnapshots[i].CreatedAt, volumeSnapExpiryDate, vol.ContentType(), false, true)
-			if err != nil {
-				return err
-			}
-
-			reverter.Add(func() { _ = VolumeDBDelete(b, projectName, newSnapshotName, vol.Type()) })
+		err = b.copyCustomVolumeConvertContent(srcPool, srcVol, vol, op)
+		if err != nil {
+			return err
+		}
+
+		eventCtx := logger.Ctx{"type": vol.Type()}
+
+		var location string
+		if b.state.ServerClustered && !b.Driver().Info().Remote {
+			eventCtx["location"] = b.state.ServerName
+			location = b.state.ServerName
+		}
+
+		// Record new volume with authorizer.
+		err = b.state.Authorizer.AddStoragePoolVolume(b.state.ShutdownCtx, projectName, b.Name(), vol.Type().Singular(), volName, location)
+		if err != nil {
+			logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": volName, "type": vol.Type(), "pool": b.Name(), "project": projectName, "error": err})
+		}
+
+		b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeCreated.Event(vol, string(vol.Type()), projectName, op, eventCtx))
+
+		reverter.Success()
+		return nil
+	}
+
+	// If the source and target are in the same pool then use CreateVolumeFromCopy rather than
+	// migration system as it will be quicker. It duplicates all of the snapshots though, so copying
+	// a subset of them uses the migration system.
+	if srcPool == b && !partialSnapshots {
+		l.Debug("CreateCustomVolumeFromCopy same-pool mode detected")
+
+		// Get the volume name on storage.
+		volStorageName := project.StorageVolume(projectName, volName)
+		vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, config)
+
+		// Validate config and create database entry for new storage volume.
+		err = VolumeDBCreate(b, projectName, volName, desc, vol.Type(), false, vol.Config(), time.Now().UTC(), time.Time{}, vol.ContentType(), false, true)
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = VolumeDBDelete(b, projectName, volName, vol.Type()) })
+
+		// Create database entries for new storage volume snapshots.
+		for i, snapName := range snapshotNames {
+			newSnapshotName := drivers.GetSnapshotVolumeName(volName, snapName)
+			var volumeSnapExpiryDate time.Time
+			if srcConfig.VolumeSnapshots[i].ExpiresAt != nil {
+				volumeSnapExpiryDate = *srcConfig.VolumeSnapshots[i].ExpiresAt
+			}
+
+			// Validate config and create database entry for new storage volume.
+			err = VolumeDBCreate(b, projectName, newSnapshotName, srcConfig.VolumeSnapshots[i].Description, vol.Type(), true, srcConfig.VolumeSnapshots[i].Config, srcConfig.VolumeSnapshots[i].CreatedAt, volumeSnapExpiryDate, vol.ContentType(), false, true)
+			if err != nil {
+				return err
+			}
+
+			reverter.Add(func() { _ = VolumeDBDelete(b, projectName, newSnapshotName, vol.Type()) })
 		}
 
 		err = b.driver.CreateVolumeFromCopy(vol, srcVol, snapshots, false, op)
@@ -5087,10 +7404,14 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 		return nil
 	}
 
-	// We are copying volumes between storage pools so use migration system as it will be able
-	// to negotiate a common transfer method between pool types.
+	// We are copying volumes between storage pools (or only some of the snapshots) so use migration
+	// system as it will be able to negotiate a common transfer method between pool types.
 	l.Debug("CreateCustomVolumeFromCopy cross-pool mode detected")
 
+	if verify && srcVol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		return errors.New("Copy verification isn't supported for qcow2 volumes")
+	}
+
 	// Negotiate the migration type to use.
 	offeredTypes := srcPool.MigrationTypes(contentType, false, snapshots, false, true)
 	offerHeader := localMigration.TypesToHeader(offeredTypes...)
@@ -5136,6 +7457,22 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 		}
 	}
 
+	// Refuse an existing target volume up front rather than once the transfer has started.
+	_, err = VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err == nil {
+		return api.StatusErrorf(http.StatusConflict, "Volume %q already exists in project %q", volName, projectName)
+	} else if !response.IsNotFoundError(err) {
+		return err
+	}
+
+	// Bound the number of concurrent cross-pool transfers into the pool.
+	releaseTransfer, err := b.acquireTransferSlot(op)
+	if err != nil {
+		return err
+	}
+
+	defer releaseTransfer()
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Use in-memory pipe pair to simulate a connection between the sender and receiver.
@@ -5155,6 +7492,7 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 			Info:               &localMigration.Info{Config: srcConfig},
 			VolumeOnly:         !snapshots,
 			StorageMove:        true,
+			SparseBlocks:       true,
 		}, op)
 		if err != nil {
 			cancel()
@@ -5166,6 +7504,7 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 	go func() {
 		err := b.CreateCustomVolumeFromMigration(projectName, bEnd, localMigration.VolumeTargetArgs{
 			IndexHeaderVersion: localMigration.IndexHeaderVersion,
+			SparseBlocks:       true,
 			Name:               volName,
 			Description:        desc,
 			Config:             config,
@@ -5195,6 +7534,10 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 	bEndErr := <-bEndErrCh
 	if bEndErr != nil {
 		errs = append(errs, bEndErr)
+	} else {
+		// The receiver created the volume, its snapshots, DB records and authorizer record. Remove them if the
+		// sender or any later step fails, as the receiver only reverts its own failures.
+		reverter.Add(func() { _ = b.DeleteCustomVolume(projectName, volName, op) })
 	}
 
 	cancel()
@@ -5203,10 +7546,243 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 		return fmt.Errorf("Create custom volume from copy failed: %v", errs)
 	}
 
+	if verify {
+		dbVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+		if err != nil {
+			return err
+		}
+
+		vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, volName), dbVol.Config)
+
+		err = b.verifyCustomVolumeCopy(srcPool, srcVol, vol, volSize, op)
+		if err != nil {
+			return err
+		}
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// volumeChecksums returns the checksums used to verify the content of vol.
+// Filesystem volumes are hashed per-file, block volumes by sampling blocks over the first size bytes.
+func (b *backend) volumeChecksums(vol drivers.Volume, size int64, op *operations.Operation) (map[string]string, error) {
+	var sums map[string]string
+
+	err := vol.MountTask(func(mountPath string, op *operations.Operation) error {
+		var err error
+
+		if vol.ContentType() == drivers.ContentTypeFS {
+			sums, err = filesystemChecksums(mountPath)
+			return err
+		}
+
+		diskPath, err := b.driver.GetVolumeDiskPath(vol)
+		if err != nil {
+			return err
+		}
+
+		sums, err = blockChecksums(diskPath, size)
+		return err
+	}, op)
+	if err != nil {
+		return nil, fmt.Errorf("Failed computing checksums of volume %q: %w", vol.Name(), err)
+	}
+
+	return sums, nil
+}
+
+// verifyCustomVolumeCopy compares the content of vol to that of srcVol it was copied from.
+// The checksums of both volumes are computed concurrently. For block volumes size is the source disk size.
+func (b *backend) verifyCustomVolumeCopy(srcPool Pool, srcVol drivers.Volume, vol drivers.Volume, size int64, op *operations.Operation) error {
+	srcPoolBackend, ok := srcPool.(*backend)
+	if !ok {
+		return errors.New("Pool is not a backend")
+	}
+
+	// The on-disk content of qcow2 volumes differs from raw ones holding the same data.
+	if srcVol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 || vol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		return errors.New("Copy verification isn't supported for qcow2 volumes")
+	}
+
+	l := b.logger.AddContext(logger.Ctx{"srcVol": srcVol.Name(), "vol": vol.Name()})
+	l.Debug("Verifying volume copy")
+
+	var srcSums, sums map[string]string
+
+	g := errgroup.Group{}
+
+	g.Go(func() error {
+		var err error
+		srcSums, err = srcPoolBackend.volumeChecksums(srcVol, size, op)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		sums, err = b.volumeChecksums(vol, size, op)
+		return err
+	})
+
+	err := g.Wait()
+	if err != nil {
+		return fmt.Errorf("Failed verifying volume copy: %w", err)
+	}
+
+	mismatch := checksumsMismatch(srcSums, sums)
+	if mismatch != "" {
+		if vol.ContentType() == drivers.ContentTypeFS {
+			return fmt.Errorf("Copied volume content doesn't match the source at %q", mismatch)
+		}
+
+		return fmt.Errorf("Copied volume content doesn't match the source at offset %s", mismatch)
+	}
+
+	l.Debug("Verified volume copy", logger.Ctx{"checksums": len(sums)})
+
+	return nil
+}
+
+// copyCustomVolumeConvertContent creates vol and fills it with the content of srcVol, converting between
+// filesystem and block content types. Filesystem content is copied into a newly formatted block volume and
+// block volumes are expected to contain a filesystem whose content is copied into the new filesystem volume.
+func (b *backend) copyCustomVolumeConvertContent(srcPool Pool, srcVol drivers.Volume, vol drivers.Volume, op *operations.Operation) error {
+	srcPoolBackend, ok := srcPool.(*backend)
+	if !ok {
+		return errors.New("Pool is not a backend")
+	}
+
+	supportedContentTypes := []drivers.ContentType{drivers.ContentTypeFS, drivers.ContentTypeBlock}
+	if !slices.Contains(supportedContentTypes, srcVol.ContentType()) || !slices.Contains(supportedContentTypes, vol.ContentType()) {
+		return fmt.Errorf("Cannot convert volume content type from %q to %q", srcVol.ContentType(), vol.ContentType())
+	}
+
+	if srcVol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 || vol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		return errors.New("Content type conversion isn't supported for qcow2 volumes")
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	err := b.driver.CreateVolume(vol, nil, op)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = b.driver.DeleteVolume(vol, op) })
+
+	bwlimit := b.driver.Config()["rsync.bwlimit"]
+
+	// mountBlock mounts the filesystem on a block device into a temporary directory and runs the task.
+	mountBlock := func(devPath string, fsType string, flags uintptr, options string, task func(mountPath string) error) error {
+		tmpDir, err := os.MkdirTemp(internalUtil.VarPath("storage-pools", b.name), "convert_")
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = os.Remove(tmpDir) }()
+
+		err = drivers.TryMount(devPath, tmpDir, fsType, flags, options)
+		if err != nil {
+			return err
+		}
+
+		taskErr := task(tmpDir)
+
+		err = drivers.TryUnmount(tmpDir, 0)
+		if taskErr != nil {
+			return taskErr
+		}
+
+		return err
+	}
+
+	if vol.ContentType() == drivers.ContentTypeBlock {
+		// Copy the files into a new filesystem on the target block volume.
+		err = vol.MountTask(func(_ string, op *operations.Operation) error {
+			devPath, err := b.driver.GetVolumeDiskPath(vol)
+			if err != nil {
+				return err
+			}
+
+			err = drivers.FormatBlockFilesystem(devPath, drivers.DefaultFilesystem)
+			if err != nil {
+				return err
+			}
+
+			return srcVol.MountTask(func(srcMountPath string, op *operations.Operation) error {
+				return mountBlock(devPath, drivers.DefaultFilesystem, 0, "", func(mountPath string) error {
+					_, err := rsync.LocalCopy(srcMountPath, mountPath, bwlimit, true)
+					return err
+				})
+			}, op)
+		}, op)
+	} else {
+		// Copy the files from the filesystem found on the source block volume.
+		err = srcVol.MountTask(func(_ string, op *operations.Operation) error {
+			srcDevPath, err := srcPoolBackend.driver.GetVolumeDiskPath(srcVol)
+			if err != nil {
+				return err
+			}
+
+			fsType, err := drivers.BlockFilesystemType(srcDevPath)
+			if err != nil || fsType == "" {
+				return fmt.Errorf("Source block volume doesn't contain a recognized filesystem: %w", err)
+			}
+
+			// The content of the block volume is untrusted, so only mount the filesystems that can be
+			// created on block volumes, without replaying their journal, and prevent anything on them
+			// from being used on the host.
+			noReplayOptions := map[string]string{"btrfs": "rescue=nologreplay", "ext4": "noload", "xfs": "norecovery"}
+
+			options, ok := noReplayOptions[fsType]
+			if !ok {
+				return fmt.Errorf("Source block volume filesystem %q isn't supported", fsType)
+			}
+
+			return mountBlock(srcDevPath, fsType, unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, options, func(srcMountPath string) error {
+				return vol.MountTask(func(mountPath string, op *operations.Operation) error {
+					_, err := rsync.LocalCopy(srcMountPath, mountPath, bwlimit, true)
+					return err
+				}, op)
+			})
+		}, op)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed converting volume content from %q to %q: %w", srcVol.ContentType(), vol.ContentType(), err)
+	}
+
 	reverter.Success()
 	return nil
 }
 
+// migrationStatsReport logs a summary of the data transferred over a migration connection and records it in
+// the operation metadata.
+func (b *backend) migrationStatsReport(l logger.Logger, statsConn *migrationStatsConn, migrationType localMigration.Type, op *operations.Operation) error {
+	totalBytes := statsConn.bytesRead.Load() + statsConn.bytesWritten.Load()
+	duration := time.Since(statsConn.start)
+	optimized := migrationType.FSType != migration.MigrationFSType_RSYNC && migrationType.FSType != migration.MigrationFSType_BLOCK_AND_RSYNC
+
+	var rate float64
+	if duration > 0 {
+		rate = float64(totalBytes) / (1024 * 1024) / duration.Seconds()
+	}
+
+	l.Info("Migration transfer finished", logger.Ctx{"bytes": totalBytes, "duration": duration.Round(time.Millisecond), "rateMiBps": fmt.Sprintf("%.2f", rate), "optimized": optimized, "migrationType": migrationType.FSType.String()})
+
+	if op == nil {
+		return nil
+	}
+
+	return op.ExtendMetadata(map[string]any{
+		"migration_bytes":     totalBytes,
+		"migration_duration":  duration.Round(time.Millisecond).String(),
+		"migration_rate_mibs": fmt.Sprintf("%.2f", rate),
+		"migration_optimized": optimized,
+	})
+}
+
 // migrationIndexHeaderSend sends the migration index header to target and waits for confirmation of receipt.
 func (b *backend) migrationIndexHeaderSend(l logger.Logger, indexHeaderVersion uint32, conn io.ReadWriteCloser, info *localMigration.Info) (*localMigration.InfoResponse, error) {
 	infoResp := localMigration.InfoResponse{}
@@ -5293,71 +7869,300 @@ func (b *backend) migrationIndexHeaderReceive(l logger.Logger, indexHeaderVersio
 	return &info, nil
 }
 
-// MigrateCustomVolume sends a volume for migration.
-func (b *backend) MigrateCustomVolume(projectName string, conn io.ReadWriteCloser, args *localMigration.VolumeSourceArgs, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": args.Name, "args": fmt.Sprintf("%+v", args)})
-	l.Debug("MigrateCustomVolume started")
-	defer l.Debug("MigrateCustomVolume finished")
-
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, args.Name)
-
-	dbContentType, err := VolumeContentTypeNameToContentType(args.ContentType)
+// migrationCheckInstanceRootSize checks that an incoming volume of volumeSize fits the size set on the
+// instance's root disk. The pool's default volume size isn't enforced, as the volume is sized from the
+// migration source when the root disk doesn't set a size.
+func (b *backend) migrationCheckInstanceRootSize(inst instance.Instance, volumeSize int64) error {
+	_, rootDiskConf, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
 	if err != nil {
 		return err
 	}
 
-	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
+	if rootDiskConf["size"] == "" {
+		return nil
+	}
+
+	rootSize, err := units.ParseByteSizeString(rootDiskConf["size"])
 	if err != nil {
 		return err
 	}
 
-	if args.Info == nil {
-		return errors.New("Migration info required")
+	// Compare volume size with configured root size.
+	// Add a 4MiB allowed extra to account for round to nearest extent (16k on ZFS, 4MiB on LVM).
+	if volumeSize > (rootSize + (4 * 1024 * 1024)) {
+		return errors.New("The configured target instance root disk size is smaller than the migration source")
 	}
 
-	if args.Info.Config == nil || args.Info.Config.Volume == nil || args.Info.Config.Volume.Config == nil {
-		return errors.New("Volume config is required")
+	return nil
+}
+
+// migrationCheckSharedVolume checks that the instance volume of a cluster move on a remote pool is the one
+// already in use by the source member, along with all of the snapshots being moved.
+func (b *backend) migrationCheckSharedVolume(inst instance.Instance, dbVol *db.StorageVolume, volExists bool, srcInfo *localMigration.Info, snapshots []*migration.Snapshot) error {
+	if dbVol == nil || !volExists {
+		return fmt.Errorf("Volume for instance %q isn't shared with the source cluster member", inst.Name())
 	}
 
-	if len(args.Snapshots) != len(args.Info.Config.VolumeSnapshots) {
-		return fmt.Errorf("Requested snapshots count (%d) doesn't match volume snapshot config count (%d)", len(args.Snapshots), len(args.Info.Config.VolumeSnapshots))
+	// Make sure that the source and target are referring to the same volume.
+	if srcInfo != nil && srcInfo.Config != nil && srcInfo.Config.Volume != nil {
+		srcUUID := srcInfo.Config.Volume.Config["volatile.uuid"]
+		if srcUUID != "" && srcUUID != dbVol.Config["volatile.uuid"] {
+			return fmt.Errorf("Volume for instance %q doesn't match the volume on the source cluster member", inst.Name())
+		}
 	}
 
-	// Send migration index header frame with volume info and wait for receipt.
-	resp, err := b.migrationIndexHeaderSend(l, args.IndexHeaderVersion, conn, args.Info)
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	volType, err := InstanceTypeToVolumeType(inst.Type())
 	if err != nil {
 		return err
 	}
 
-	if resp.Refresh != nil {
-		args.Refresh = *resp.Refresh
+	dbSnapshots, err := VolumeDBSnapshotsGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return err
 	}
 
-	volConfig := args.Info.Config.Volume.Config
-	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, volConfig)
-	if volConfig["block.type"] == drivers.BlockVolumeTypeQcow2 && (!b.driver.Info().Remote || !args.ClusterMove || args.StorageMove) {
-		err = b.qcow2MigrateVolume(b.state, vol, projectName, conn, args, op)
-		if err != nil {
-			return err
-		}
-	} else {
-		err = b.driver.MigrateVolume(vol, conn, args, op)
-		if err != nil {
-			return err
+	snapNames := make(map[string]bool, len(dbSnapshots))
+	for _, dbSnapshot := range dbSnapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(dbSnapshot.Name)
+		snapNames[snapName] = true
+	}
+
+	for _, snapshot := range snapshots {
+		if !snapNames[snapshot.GetName()] {
+			return fmt.Errorf("Snapshot %q of instance %q isn't shared with the source cluster member", snapshot.GetName(), inst.Name())
 		}
 	}
 
 	return nil
 }
 
-// CreateCustomVolumeFromMigration receives a volume being migrated.
-func (b *backend) CreateCustomVolumeFromMigration(projectName string, conn io.ReadWriteCloser, args localMigration.VolumeTargetArgs, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": args.Name, "args": fmt.Sprintf("%+v", args)})
-	l.Debug("CreateCustomVolumeFromMigration started")
-	defer l.Debug("CreateCustomVolumeFromMigration finished")
+// migrationValidateConfigOverrides checks the target volume config overrides of an instance migration.
+// The overrides can only be applied to new volumes and can't change the size or format of the volume.
+func (b *backend) migrationValidateConfigOverrides(inst instance.Instance, volType drivers.VolumeType, contentType drivers.ContentType, args localMigration.VolumeTargetArgs) error {
+	if len(args.TargetConfigOverrides) == 0 {
+		return nil
+	}
 
-	err := b.isStatusReady()
+	if args.Refresh {
+		return errors.New("Target volume config overrides cannot be used when refreshing")
+	}
+
+	for k := range args.TargetConfigOverrides {
+		if strings.HasPrefix(k, "volatile.") || slices.Contains(migrationFixedVolumeFields, k) {
+			return fmt.Errorf("Config key %q cannot be overridden on the migration target", k)
+		}
+	}
+
+	vol := b.GetVolume(volType, contentType, project.Instance(inst.Project().Name, inst.Name()), args.TargetConfigOverrides)
+
+	err := b.driver.ValidateVolume(vol, false)
+	if err != nil {
+		return fmt.Errorf("Invalid target volume config overrides: %w", err)
+	}
+
+	return nil
+}
+
+// migrationVerifyInstanceTarget checks whether an incoming instance volume could be accepted by this pool.
+// Returns a list of non-fatal compatibility warnings, or an error if the migration cannot proceed.
+func (b *backend) migrationVerifyInstanceTarget(inst instance.Instance, volType drivers.VolumeType, contentType drivers.ContentType, args localMigration.VolumeTargetArgs, srcInfo *localMigration.Info) ([]string, error) {
+	warnings := []string{}
+
+	// Check the incoming volume fits within the configured root disk size.
+	err := b.migrationCheckInstanceRootSize(inst, args.VolumeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the volume doesn't conflict with an existing one.
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil && !response.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, nil)
+
+	volExists, err := b.driver.HasVolume(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbVol == nil && volExists {
+		return nil, errors.New("Volume already exists on storage but not in database")
+	}
+
+	if !args.Refresh && volExists && (args.ClusterMoveSourceName == "" || !b.driver.Info().Remote) {
+		return nil, errors.New("Cannot create volume, already exists on migration target storage")
+	}
+
+	if args.Refresh && !volExists {
+		return nil, errors.New("Cannot refresh volume, doesn't exist on migration target storage")
+	}
+
+	err = b.migrationValidateConfigOverrides(inst, volType, contentType, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the negotiated transport and the source driver.
+	if args.MigrationType.FSType == migration.MigrationFSType_RSYNC || args.MigrationType.FSType == migration.MigrationFSType_BLOCK_AND_RSYNC {
+		warnings = append(warnings, fmt.Sprintf("Optimized transfer not available, using %q", args.MigrationType.FSType.String()))
+	}
+
+	if srcInfo != nil && srcInfo.Config != nil {
+		if srcInfo.Config.Pool != nil && srcInfo.Config.Pool.Driver != b.driver.Info().Name {
+			warnings = append(warnings, fmt.Sprintf("Source storage driver %q differs from target storage driver %q", srcInfo.Config.Pool.Driver, b.driver.Info().Name))
+		}
+
+		// Report source volume config keys that this pool will not retain.
+		if srcInfo.Config.Volume != nil && dbVol == nil {
+			for key := range srcInfo.Config.Volume.Config {
+				srcVol := b.GetVolume(volType, contentType, volStorageName, map[string]string{key: srcInfo.Config.Volume.Config[key]})
+
+				err = b.driver.ValidateVolume(srcVol, false)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("Volume config key %q is not supported by the target and will be dropped", key))
+				}
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// migrationVerifyResultSend sends the result of a verify only migration to the source.
+func (b *backend) migrationVerifyResultSend(l logger.Logger, conn io.ReadWriteCloser, warnings []string, verifyErr error) error {
+	resp := localMigration.InfoResponse{StatusCode: http.StatusOK, Warnings: warnings}
+	if verifyErr != nil {
+		resp.StatusCode = http.StatusBadRequest
+		resp.Error = verifyErr.Error()
+	}
+
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("Failed encoding migration verification result: %w", err)
+	}
+
+	_, err = conn.Write(respJSON)
+	if err != nil {
+		return fmt.Errorf("Failed sending migration verification result: %w", err)
+	}
+
+	err = conn.Close() // End the frame.
+	if err != nil {
+		return fmt.Errorf("Failed closing migration verification result frame: %w", err)
+	}
+
+	l.Debug("Sent migration verification result", logger.Ctx{"result": fmt.Sprintf("%+v", resp)})
+
+	return nil
+}
+
+// migrationVerifyResultReceive waits for the result of a verify only migration from the target.
+// The negotiated migration type and any compatibility warnings are recorded in the operation metadata.
+func (b *backend) migrationVerifyResultReceive(l logger.Logger, conn io.ReadWriteCloser, migrationType localMigration.Type, op *operations.Operation) error {
+	respBuf, err := io.ReadAll(conn)
+	if err != nil {
+		return fmt.Errorf("Failed reading migration verification result: %w", err)
+	}
+
+	resp := localMigration.InfoResponse{}
+	err = json.Unmarshal(respBuf, &resp)
+	if err != nil {
+		return fmt.Errorf("Failed decoding migration verification result: %w", err)
+	}
+
+	l.Debug("Received migration verification result", logger.Ctx{"result": fmt.Sprintf("%+v", resp)})
+
+	err = resp.Err()
+	if err != nil {
+		return fmt.Errorf("Migration target rejected the volume: %w", err)
+	}
+
+	if op != nil {
+		err = op.ExtendMetadata(map[string]any{"migration_type": migrationType.FSType.String(), "migration_warnings": resp.Warnings})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateCustomVolume sends a volume for migration.
+func (b *backend) MigrateCustomVolume(projectName string, conn io.ReadWriteCloser, args *localMigration.VolumeSourceArgs, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": args.Name, "args": fmt.Sprintf("%+v", args)})
+	l.Debug("MigrateCustomVolume started")
+	defer l.Debug("MigrateCustomVolume finished")
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, args.Name)
+
+	dbContentType, err := VolumeContentTypeNameToContentType(args.ContentType)
+	if err != nil {
+		return err
+	}
+
+	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
+	if err != nil {
+		return err
+	}
+
+	if args.Info == nil {
+		return errors.New("Migration info required")
+	}
+
+	if args.Info.Config == nil || args.Info.Config.Volume == nil || args.Info.Config.Volume.Config == nil {
+		return errors.New("Volume config is required")
+	}
+
+	if len(args.Snapshots) != len(args.Info.Config.VolumeSnapshots) {
+		return fmt.Errorf("Requested snapshots count (%d) doesn't match volume snapshot config count (%d)", len(args.Snapshots), len(args.Info.Config.VolumeSnapshots))
+	}
+
+	// Track the amount of data sent and received over the migration connection.
+	statsConn := newMigrationStatsConn(conn)
+	conn = statsConn
+
+	// Send migration index header frame with volume info and wait for receipt.
+	resp, err := b.migrationIndexHeaderSend(l, args.IndexHeaderVersion, conn, args.Info)
+	if err != nil {
+		return err
+	}
+
+	if resp.Refresh != nil {
+		args.Refresh = *resp.Refresh
+	}
+
+	volConfig := args.Info.Config.Volume.Config
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, volConfig)
+	if volConfig["block.type"] == drivers.BlockVolumeTypeQcow2 && (!b.driver.Info().Remote || !args.ClusterMove || args.StorageMove) {
+		err = b.qcow2MigrateVolume(b.state, vol, projectName, conn, args, op)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = b.driver.MigrateVolume(vol, conn, args, op)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.migrationStatsReport(l, statsConn, args.MigrationType, op)
+}
+
+// CreateCustomVolumeFromMigration receives a volume being migrated.
+func (b *backend) CreateCustomVolumeFromMigration(projectName string, conn io.ReadWriteCloser, args localMigration.VolumeTargetArgs, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": args.Name, "args": fmt.Sprintf("%+v", args)})
+	l.Debug("CreateCustomVolumeFromMigration started")
+	defer l.Debug("CreateCustomVolumeFromMigration finished")
+
+	err := b.isStatusReady()
 	if err != nil {
 		return err
 	}
@@ -5506,6 +8311,12 @@ func (b *backend) CreateCustomVolumeFromMigration(projectName string, conn io.Re
 		logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": args.Name, "type": vol.Type(), "pool": b.Name(), "project": projectName, "error": err})
 	}
 
+	if !args.Refresh {
+		reverter.Add(func() {
+			_ = b.state.Authorizer.DeleteStoragePoolVolume(b.state.ShutdownCtx, projectName, b.Name(), vol.Type().Singular(), args.Name, location)
+		})
+	}
+
 	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeCreated.Event(vol, string(vol.Type()), projectName, op, eventCtx))
 
 	reverter.Success()
@@ -5609,6 +8420,8 @@ func (b *backend) RenameCustomVolume(projectName string, volName string, newVolN
 		return err
 	}
 
+	vol.ClearCachedDiskPath()
+
 	var location string
 	if b.state.ServerClustered && !b.Driver().Info().Remote {
 		location = b.state.ServerName
@@ -5626,145 +8439,334 @@ func (b *backend) RenameCustomVolume(projectName string, volName string, newVolN
 	return nil
 }
 
-// detectChangedConfig returns the config that has changed between current and new config maps.
-// Also returns a boolean indicating whether all of the changed keys start with "user.".
-// Deleted keys will be returned as having an empty string value.
-func (b *backend) detectChangedConfig(curConfig, newConfig map[string]string) (map[string]string, bool) {
-	// Diff the configurations.
-	changedConfig := make(map[string]string)
-	userOnly := true
-	for key := range curConfig {
-		if curConfig[key] != newConfig[key] {
-			if !strings.HasPrefix(key, "user.") {
-				userOnly = false
-			}
-
-			changedConfig[key] = newConfig[key] // Will be empty string on deleted keys.
-		}
+// ListCustomVolumeBackups returns the backups stored for a custom volume, oldest first.
+func (b *backend) ListCustomVolumeBackups(projectName string, volName string) ([]api.StorageVolumeBackup, error) {
+	// Check the volume exists so that a missing volume isn't reported as having no backups.
+	_, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return nil, err
 	}
 
-	for key := range newConfig {
-		if curConfig[key] != newConfig[key] {
-			if !strings.HasPrefix(key, "user.") {
-				userOnly = false
-			}
+	var backups []db.StoragePoolVolumeBackup
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		backups, err = tx.GetStoragePoolVolumeBackups(ctx, projectName, volName, b.ID())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			changedConfig[key] = newConfig[key]
-		}
+	result := make([]api.StorageVolumeBackup, 0, len(backups))
+	for _, br := range backups {
+		volBackup := backup.NewVolumeBackup(b.state, projectName, b.name, volName, br.ID, br.Name, br.CreationDate, br.ExpiryDate, br.VolumeOnly, br.OptimizedStorage)
+		result = append(result, *volBackup.Render())
 	}
 
-	return changedConfig, userOnly
+	return result, nil
 }
 
-// UpdateCustomVolume applies the supplied config to the custom volume.
-func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc string, newConfig map[string]string, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "newDesc": newDesc, "newConfig": newConfig})
-	l.Debug("UpdateCustomVolume started")
-	defer l.Debug("UpdateCustomVolume finished")
+// MoveCustomVolumeToProject moves a custom volume and its snapshots and backups to another project.
+func (b *backend) MoveCustomVolumeToProject(projectName string, volName string, targetProjectName string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "targetProject": targetProjectName})
+	l.Debug("MoveCustomVolumeToProject started")
+	defer l.Debug("MoveCustomVolumeToProject finished")
 
 	if internalInstance.IsSnapshot(volName) {
 		return errors.New("Volume name cannot be a snapshot")
 	}
 
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, volName)
-
-	// Get current config to compare what has changed.
-	curVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
-	if err != nil {
-		return err
+	if projectName == targetProjectName {
+		return errors.New("Project and target project are the same")
 	}
 
-	// Get content type.
-	dbContentType, err := VolumeContentTypeNameToContentType(curVol.ContentType)
+	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
 	if err != nil {
 		return err
 	}
 
-	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
-	if err != nil {
-		return err
+	// Check whether the target project allows the volume and find the project its custom volumes are stored in.
+	req := api.StorageVolumesPost{
+		Name: volName,
+		StorageVolumePut: api.StorageVolumePut{
+			Config: volume.Config,
+		},
 	}
 
-	// Validate config.
-	newVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, newConfig)
-	err = b.driver.ValidateVolume(newVol, false)
-	if err != nil {
-		return err
-	}
+	var targetStorageProjectName string
 
-	// Apply config changes if there are any.
-	changedConfig, userOnly := b.detectChangedConfig(curVol.Config, newConfig)
-	if len(changedConfig) != 0 {
-		// Forbid changing the config for ISO custom volumes as they are read-only.
-		if contentType == drivers.ContentTypeISO {
-			return errors.New("Custom ISO volume config cannot be changed")
+	err = b.state.DB.Cluster.Transaction(b.state.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), targetProjectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", targetProjectName, err)
 		}
 
-		// Check that the volume's block.filesystem property isn't being changed.
-		if changedConfig["block.filesystem"] != "" {
-			return errors.New(`Custom volume "block.filesystem" property cannot be changed`)
+		targetProject, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
 		}
 
-		// Check for config changing that is not allowed when running instances are using it.
-		if changedConfig["security.shifted"] != "" {
-			err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
-				inst, err := instance.Load(b.state, dbInst, project)
-				if err != nil {
-					return err
-				}
-
-				// Confirm that no running instances are using it when changing shifted state.
-				if inst.IsRunning() && changedConfig["security.shifted"] != "" {
-					return errors.New("Cannot modify shifting with running instances using the volume")
-				}
-
-				return nil
-			})
-			if err != nil {
-				return err
-			}
+		targetStorageProjectName, err = customVolumeMoveTargetProject(projectName, targetProject)
+		if err != nil {
+			return err
 		}
 
-		sharedVolume, ok := changedConfig["security.shared"]
-		if ok && util.IsFalseOrEmpty(sharedVolume) {
-			var usedByProfileDevices []api.Profile
-
-			err = VolumeUsedByProfileDevices(b.state, b.name, projectName, &curVol.StorageVolume, func(profileID int64, profile api.Profile, project api.Project, usedByDevices []string) error {
-				usedByProfileDevices = append(usedByProfileDevices, profile)
+		return project.AllowVolumeCreation(tx, targetProjectName, b.name, req)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed checking volume move allowed: %w", err)
+	}
 
-				return nil
-			})
-			if err != nil {
-				return err
-			}
+	targetProjectName = targetStorageProjectName
 
-			if len(usedByProfileDevices) > 0 {
-				return errors.New("Cannot un-share custom storage block volume if attached to profile")
-			}
+	// Check that the volume name isn't already in use in the target project.
+	_, err = VolumeDBGet(b, targetProjectName, volName, drivers.VolumeTypeCustom)
+	if err == nil {
+		return api.StatusErrorf(http.StatusConflict, "Volume %q already exists in project %q", volName, targetProjectName)
+	} else if !response.IsNotFoundError(err) {
+		return err
+	}
 
-			var usedByInstanceDevices []string
+	// Devices reference custom volumes by name within their project, so they can't follow the volume.
+	frag, err := VolumeUsedByDaemon(b.state, b.name, volName)
+	if err != nil {
+		return err
+	}
 
-			err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(inst db.InstanceArgs, project api.Project, usedByDevices []string) error {
-				usedByInstanceDevices = append(usedByInstanceDevices, inst.Name)
+	if frag != "" {
+		return errors.New("Volume is used by Incus itself and cannot be moved")
+	}
 
-				return nil
+	err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &volume.StorageVolume, true, func(inst db.InstanceArgs, p api.Project, usedByDevices []string) error {
+		return api.StatusErrorf(http.StatusBadRequest, "Volume is in use by instance %q in project %q", inst.Name, p.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = VolumeUsedByProfileDevices(b.state, b.name, projectName, &volume.StorageVolume, func(profileID int64, profile api.Profile, p api.Project, usedByDevices []string) error {
+		return api.StatusErrorf(http.StatusBadRequest, "Volume is in use by profile %q in project %q", profile.Name, p.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Snapshots and backups records reference the parent volume, so they follow it to the new project.
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.MoveStoragePoolVolumeToProject(ctx, projectName, volName, db.StoragePoolVolumeTypeCustom, b.ID(), targetProjectName)
+	})
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() {
+		_ = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.MoveStoragePoolVolumeToProject(ctx, targetProjectName, volName, db.StoragePoolVolumeTypeCustom, b.ID(), projectName)
+		})
+	})
+
+	// Move the backups directory.
+	backupsPath := internalUtil.VarPath("backups", "custom", b.name, project.StorageVolume(projectName, volName))
+	newBackupsPath := internalUtil.VarPath("backups", "custom", b.name, project.StorageVolume(targetProjectName, volName))
+	if util.PathExists(backupsPath) {
+		err = os.Rename(backupsPath, newBackupsPath)
+		if err != nil {
+			return fmt.Errorf("Failed moving backups directory: %w", err)
+		}
+
+		reverter.Add(func() { _ = os.Rename(newBackupsPath, backupsPath) })
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+	newVolStorageName := project.StorageVolume(targetProjectName, volName)
+
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
+
+	err = b.driver.RenameVolume(vol, newVolStorageName, op)
+	if err != nil {
+		return err
+	}
+
+	vol.ClearCachedDiskPath()
+
+	var location string
+	if b.state.ServerClustered && !b.Driver().Info().Remote {
+		location = b.state.ServerName
+	}
+
+	err = b.state.Authorizer.DeleteStoragePoolVolume(b.state.ShutdownCtx, projectName, b.Name(), vol.Type().Singular(), volName, location)
+	if err != nil {
+		logger.Error("Failed to remove storage volume from authorizer", logger.Ctx{"name": volName, "type": vol.Type(), "pool": b.Name(), "project": projectName, "error": err})
+	}
+
+	err = b.state.Authorizer.AddStoragePoolVolume(b.state.ShutdownCtx, targetProjectName, b.Name(), vol.Type().Singular(), volName, location)
+	if err != nil {
+		logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": volName, "type": vol.Type(), "pool": b.Name(), "project": targetProjectName, "error": err})
+	}
+
+	vol = b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), newVolStorageName, nil)
+	b.state.Events.SendLifecycle(targetProjectName, lifecycle.StorageVolumeRenamed.Event(vol, string(vol.Type()), targetProjectName, op, logger.Ctx{"old_project": projectName}))
+
+	reverter.Success()
+	return nil
+}
+
+// detectChangedConfig returns the config that has changed between current and new config maps.
+// Also returns a boolean indicating whether all of the changed keys start with "user.".
+// Deleted keys will be returned as having an empty string value.
+func (b *backend) detectChangedConfig(curConfig, newConfig map[string]string) (map[string]string, bool) {
+	// Diff the configurations.
+	changedConfig := make(map[string]string)
+	userOnly := true
+	for key := range curConfig {
+		if curConfig[key] != newConfig[key] {
+			if !strings.HasPrefix(key, "user.") {
+				userOnly = false
+			}
+
+			changedConfig[key] = newConfig[key] // Will be empty string on deleted keys.
+		}
+	}
+
+	for key := range newConfig {
+		if curConfig[key] != newConfig[key] {
+			if !strings.HasPrefix(key, "user.") {
+				userOnly = false
+			}
+
+			changedConfig[key] = newConfig[key]
+		}
+	}
+
+	return changedConfig, userOnly
+}
+
+// UpdateCustomVolume applies the supplied config to the custom volume.
+func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc string, newConfig map[string]string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "newDesc": newDesc, "newConfig": newConfig})
+	l.Debug("UpdateCustomVolume started")
+	defer l.Debug("UpdateCustomVolume finished")
+
+	if internalInstance.IsSnapshot(volName) {
+		return errors.New("Volume name cannot be a snapshot")
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+
+	// Get current config to compare what has changed.
+	curVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	// Get content type.
+	dbContentType, err := VolumeContentTypeNameToContentType(curVol.ContentType)
+	if err != nil {
+		return err
+	}
+
+	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
+	if err != nil {
+		return err
+	}
+
+	// Validate config.
+	newVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, newConfig)
+	err = b.driver.ValidateVolume(newVol, false)
+	if err != nil {
+		return err
+	}
+
+	// A description-only change doesn't involve the storage driver.
+	changedConfig, userOnly := b.detectChangedConfig(curVol.Config, newConfig)
+	if len(changedConfig) == 0 {
+		if newDesc != curVol.Description {
+			err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.UpdateStoragePoolVolume(ctx, projectName, volName, db.StoragePoolVolumeTypeCustom, b.ID(), newDesc, curVol.Config)
 			})
 			if err != nil {
 				return err
 			}
-
-			if len(usedByInstanceDevices) > 1 {
-				return errors.New("Cannot un-share custom storage block volume if attached to more than one instance")
-			}
 		}
 
-		curVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, curVol.Config)
-		if !userOnly {
-			err = b.driver.UpdateVolume(curVol, changedConfig)
+		b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), projectName, op, nil))
+
+		return nil
+	}
+
+	// Forbid changing the config for ISO custom volumes as they are read-only.
+	if contentType == drivers.ContentTypeISO {
+		return errors.New("Custom ISO volume config cannot be changed")
+	}
+
+	// Check that the volume's block.filesystem property isn't being changed.
+	if changedConfig["block.filesystem"] != "" {
+		return errors.New(`Custom volume "block.filesystem" property cannot be changed`)
+	}
+
+	// Check for config changing that is not allowed when running instances are using it.
+	if changedConfig["security.shifted"] != "" {
+		err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+			inst, err := instance.Load(b.state, dbInst, project)
 			if err != nil {
 				return err
 			}
+
+			// Confirm that no running instances are using it when changing shifted state.
+			if inst.IsRunning() && changedConfig["security.shifted"] != "" {
+				return errors.New("Cannot modify shifting with running instances using the volume")
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	sharedVolume, ok := changedConfig["security.shared"]
+	if ok && util.IsFalseOrEmpty(sharedVolume) {
+		var usedByProfileDevices []api.Profile
+
+		err = VolumeUsedByProfileDevices(b.state, b.name, projectName, &curVol.StorageVolume, func(profileID int64, profile api.Profile, project api.Project, usedByDevices []string) error {
+			usedByProfileDevices = append(usedByProfileDevices, profile)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(usedByProfileDevices) > 0 {
+			return errors.New("Cannot un-share custom storage block volume if attached to profile")
+		}
+
+		var usedByInstanceDevices []string
+
+		err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(inst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+			usedByInstanceDevices = append(usedByInstanceDevices, inst.Name)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(usedByInstanceDevices) > 1 {
+			return errors.New("Cannot un-share custom storage block volume if attached to more than one instance")
+		}
+	}
+
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, curVol.Config)
+	if !userOnly {
+		err = b.driver.UpdateVolume(vol, changedConfig)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -5848,14 +8850,12 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 		}
 	}
 
-	// Update the database if something changed.
-	if len(changedConfig) != 0 || newDesc != curVol.Description {
-		err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-			return tx.UpdateStoragePoolVolume(ctx, projectName, volName, db.StoragePoolVolumeTypeCustom, b.ID(), newDesc, newConfig)
-		})
-		if err != nil {
-			return err
-		}
+	// Update the database.
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateStoragePoolVolume(ctx, projectName, volName, db.StoragePoolVolumeTypeCustom, b.ID(), newDesc, newConfig)
+	})
+	if err != nil {
+		return err
 	}
 
 	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), projectName, op, nil))
@@ -5863,6 +8863,66 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 	return nil
 }
 
+// GetVolumeTags returns the tags of an instance or custom volume, keyed on the tag name.
+// Tags are stored as user.tags.<name> config keys and aren't interpreted by the storage drivers.
+func (b *backend) GetVolumeTags(projectName string, volName string, volType drivers.VolumeType) (map[string]string, error) {
+	if volType != drivers.VolumeTypeCustom && volType != drivers.VolumeTypeContainer && volType != drivers.VolumeTypeVM {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Volumes of type %q don't support tags", volType)
+	}
+
+	dbVol, err := VolumeDBGet(b, projectName, volName, volType)
+	if err != nil {
+		return nil, err
+	}
+
+	return VolumeTags(dbVol.Config), nil
+}
+
+// SetVolumeTags replaces the tags of an instance or custom volume.
+// As the tags are user config keys, the update doesn't involve the storage driver.
+func (b *backend) SetVolumeTags(projectName string, volName string, volType drivers.VolumeType, tags map[string]string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "volType": volType, "tags": tags})
+	l.Debug("SetVolumeTags started")
+	defer l.Debug("SetVolumeTags finished")
+
+	if volType != drivers.VolumeTypeCustom && volType != drivers.VolumeTypeContainer && volType != drivers.VolumeTypeVM {
+		return api.StatusErrorf(http.StatusBadRequest, "Volumes of type %q don't support tags", volType)
+	}
+
+	_, found := tags[""]
+	if found {
+		return api.StatusErrorf(http.StatusBadRequest, "Volume tag names can't be empty")
+	}
+
+	curVol, err := VolumeDBGet(b, projectName, volName, volType)
+	if err != nil {
+		return err
+	}
+
+	// Replace the existing tags, keeping the rest of the config as is.
+	newConfig := make(map[string]string, len(curVol.Config)+len(tags))
+	for k, v := range curVol.Config {
+		if !strings.HasPrefix(k, VolumeTagsConfigPrefix) {
+			newConfig[k] = v
+		}
+	}
+
+	for tagName, v := range tags {
+		newConfig[VolumeTagsConfigPrefix+tagName] = v
+	}
+
+	if volType == drivers.VolumeTypeCustom {
+		return b.UpdateCustomVolume(projectName, volName, curVol.Description, newConfig, op)
+	}
+
+	inst, err := instance.LoadByProjectAndName(b.state, projectName, volName)
+	if err != nil {
+		return err
+	}
+
+	return b.UpdateInstance(inst, curVol.Description, newConfig, op)
+}
+
 // UpdateCustomVolumeSnapshot updates the description of a custom volume snapshot.
 // Volume config is not allowed to be updated and will return an error.
 func (b *backend) UpdateCustomVolumeSnapshot(projectName string, volName string, newDesc string, newConfig map[string]string, newExpiryDate time.Time, op *operations.Operation) error {
@@ -5976,6 +9036,8 @@ func (b *backend) DeleteCustomVolume(projectName string, volName string, op *ope
 		if err != nil {
 			return err
 		}
+
+		vol.ClearCachedDiskPath()
 	}
 
 	// Remove backups directory for volume.
@@ -6087,6 +9149,8 @@ func (b *backend) RebuildCustomVolume(projectName string, volName string, op *op
 		if err != nil {
 			return err
 		}
+
+		vol.ClearCachedDiskPath()
 	}
 
 	// Re-create the empty custom volume on the storage device.
@@ -6113,7 +9177,7 @@ func (b *backend) GetCustomVolumeDisk(projectName, volName string) (string, erro
 	// There's no need to pass config as it's not needed when getting the volume usage.
 	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, nil)
 
-	return b.driver.GetVolumeDiskPath(vol)
+	return b.getVolumeDiskPath(vol)
 }
 
 // GetCustomVolumeUsage returns the disk space used by the custom volume.
@@ -6164,12 +9228,8 @@ func (b *backend) GetCustomVolumeUsage(projectName, volName string) (*VolumeUsag
 	return &val, nil
 }
 
-// MountCustomVolume mounts a custom volume.
-func (b *backend) MountCustomVolume(projectName, volName string, op *operations.Operation) (*MountInfo, error) {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName})
-	l.Debug("MountCustomVolume started")
-	defer l.Debug("MountCustomVolume finished")
-
+// GetCustomVolumeIOStats returns the cumulative IO counters of a custom volume.
+func (b *backend) GetCustomVolumeIOStats(projectName string, volName string) (*drivers.VolumeIOStats, error) {
 	err := b.isStatusReady()
 	if err != nil {
 		return nil, err
@@ -6180,39 +9240,195 @@ func (b *backend) MountCustomVolume(projectName, volName string, op *operations.
 		return nil, err
 	}
 
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, volName)
-	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
+	contentDBType, err := VolumeContentTypeNameToContentType(volume.ContentType)
+	if err != nil {
+		return nil, err
+	}
 
-	// Perform the mount.
-	mountInfo := &MountInfo{}
-	err = b.driver.MountVolume(vol, op)
+	contentType, err := VolumeDBContentTypeToContentType(contentDBType)
 	if err != nil {
 		return nil, err
 	}
 
-	backingPaths := []string{}
-	if vol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 {
-		// Get snapshots.
-		_, volName := project.StorageVolumeParts(vol.Name())
-		volSnaps, err := VolumeDBSnapshotsGet(b, projectName, volName, vol.Type())
-		if err != nil {
-			return nil, err
-		}
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, volName), volume.Config)
 
-		for _, snap := range volSnaps {
-			currentSnapVol := b.GetVolume(vol.Type(), vol.ContentType(), project.StorageVolume(projectName, snap.Name), vol.Config())
-			err = b.driver.MountVolumeSnapshot(currentSnapVol, op)
-			if err != nil {
-				return nil, err
-			}
-		}
+	return b.driver.GetVolumeIOStats(vol)
+}
 
-		// Get the location of the disk block device.
-		diskPath, err := b.driver.GetVolumeDiskPath(vol)
-		if err != nil {
-			return nil, err
-		}
+// ExportCustomVolumeData streams the contents of a custom volume to the writer.
+// Filesystem volumes are written as a tarball and block volumes as a raw disk image.
+func (b *backend) ExportCustomVolumeData(projectName string, volName string, w io.Writer, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName})
+	l.Debug("ExportCustomVolumeData started")
+	defer l.Debug("ExportCustomVolumeData finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	if internalInstance.IsSnapshot(volName) {
+		return errors.New("Volume name cannot be a snapshot")
+	}
+
+	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	volStorageName := project.StorageVolume(projectName, volName)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
+
+	var tracker *ioprogress.ProgressTracker
+	if op != nil {
+		metadata := make(map[string]any)
+		tracker = &ioprogress.ProgressTracker{
+			Handler: func(value, speed int64) {
+				if tracker.Length > 0 {
+					operations.SetProgressMetadata(metadata, "export_volume", "Exporting volume", value, 0, speed)
+				} else {
+					operations.SetProgressMetadata(metadata, "export_volume", "Exporting volume", 0, value, speed)
+				}
+
+				_ = op.UpdateMetadata(metadata)
+			},
+		}
+	}
+
+	return vol.MountTask(func(mountPath string, op *operations.Operation) error {
+		if drivers.IsContentBlock(vol.ContentType()) {
+			diskPath, err := b.driver.GetVolumeDiskPath(vol)
+			if err != nil {
+				return err
+			}
+
+			// Expose qcow2 volumes as raw images.
+			if vol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 {
+				diskPath, err = drivers.ConnectQemuNbd(diskPath, "qcow2", "", true)
+				if err != nil {
+					return err
+				}
+
+				defer func() { _ = drivers.DisconnectQemuNbd(diskPath) }()
+			}
+
+			size, err := drivers.BlockDiskSizeBytes(diskPath)
+			if err != nil {
+				return err
+			}
+
+			from, err := os.Open(diskPath)
+			if err != nil {
+				return fmt.Errorf("Error opening file for reading %q: %w", diskPath, err)
+			}
+
+			defer logger.WarnOnError(from.Close, "Failed to close file")
+
+			var reader io.Reader = from
+			if tracker != nil {
+				tracker.Length = size
+				reader = &ioprogress.ProgressReader{Reader: from, Tracker: tracker}
+			}
+
+			_, err = util.SafeCopy(w, reader)
+			if err != nil {
+				return fmt.Errorf("Error copying %q: %w", diskPath, err)
+			}
+
+			return nil
+		}
+
+		var writer io.WriteCloser = nopWriteCloser{w}
+		if tracker != nil {
+			writer = &ioprogress.ProgressWriter{WriteCloser: writer, Tracker: tracker}
+		}
+
+		// Read the files through a read-only bind mount so the export can't modify the volume.
+		roPath, cleanup, err := readOnlyBindMount(b.name, mountPath, "export_")
+		if err != nil {
+			return err
+		}
+
+		defer cleanup()
+
+		mountPath = roPath
+
+		tarWriter := instancewriter.NewInstanceTarWriter(writer, nil)
+
+		err = filepath.Walk(mountPath, func(srcPath string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if srcPath == mountPath {
+				return nil
+			}
+
+			name := strings.TrimPrefix(srcPath, internalUtil.AddSlash(mountPath))
+			err = tarWriter.WriteFile(name, srcPath, fi, false)
+			if err != nil {
+				return fmt.Errorf("Error adding %q as %q to tarball: %w", srcPath, name, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return tarWriter.Close()
+	}, op)
+}
+
+// MountCustomVolume mounts a custom volume.
+func (b *backend) MountCustomVolume(projectName, volName string, op *operations.Operation) (*MountInfo, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName})
+	l.Debug("MountCustomVolume started")
+	defer l.Debug("MountCustomVolume finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
+
+	// Perform the mount.
+	mountInfo := &MountInfo{}
+	err = b.driver.MountVolume(vol, op)
+	if err != nil {
+		return nil, err
+	}
+
+	backingPaths := []string{}
+	if vol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		// Get snapshots.
+		_, volName := project.StorageVolumeParts(vol.Name())
+		volSnaps, err := VolumeDBSnapshotsGet(b, projectName, volName, vol.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, snap := range volSnaps {
+			currentSnapVol := b.GetVolume(vol.Type(), vol.ContentType(), project.StorageVolume(projectName, snap.Name), vol.Config())
+			err = b.driver.MountVolumeSnapshot(currentSnapVol, op)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Get the location of the disk block device.
+		diskPath, err := b.driver.GetVolumeDiskPath(vol)
+		if err != nil {
+			return nil, err
+		}
 
 		// Fetch backing chain for a qcow2 formatted volume.
 		backingPaths, err = b.qcow2BackingPaths(vol, diskPath, projectName)
@@ -6295,12 +9511,13 @@ func (b *backend) ImportCustomVolume(projectName string, poolVol *backupConfig.C
 	volumeConfig := util.CloneMap(poolVol.Volume.Config)
 
 	// Validate config and create database entry for restored storage volume.
-	err := VolumeDBCreate(b, projectName, poolVol.Volume.Name, poolVol.Volume.Description, drivers.VolumeTypeCustom, false, volumeConfig, poolVol.Volume.CreatedAt, time.Time{}, drivers.ContentType(poolVol.Volume.ContentType), false, true)
+	// The write is queued if the pool is in a maintenance session.
+	err := volumeDBCreate(b, projectName, poolVol.Volume.Name, poolVol.Volume.Description, drivers.VolumeTypeCustom, false, volumeConfig, poolVol.Volume.CreatedAt, time.Time{}, drivers.ContentType(poolVol.Volume.ContentType), false, true, true)
 	if err != nil {
 		return nil, err
 	}
 
-	reverter.Add(func() { _ = VolumeDBDelete(b, projectName, poolVol.Volume.Name, drivers.VolumeTypeCustom) })
+	reverter.Add(func() { _ = volumeDBDelete(b, projectName, poolVol.Volume.Name, drivers.VolumeTypeCustom, true) })
 
 	// Create the storage volume snapshot DB records.
 	for _, poolVolSnap := range poolVol.VolumeSnapshots {
@@ -6311,14 +9528,33 @@ func (b *backend) ImportCustomVolume(projectName string, poolVol *backupConfig.C
 		snapVolumeConfig := util.CloneMap(poolVolSnap.Config)
 
 		// Validate config and create database entry for restored storage volume.
-		err = VolumeDBCreate(b, projectName, fullSnapName, poolVolSnap.Description, drivers.VolumeTypeCustom, true, snapVolumeConfig, poolVolSnap.CreatedAt, time.Time{}, drivers.ContentType(poolVolSnap.ContentType), false, true)
+		err = volumeDBCreate(b, projectName, fullSnapName, poolVolSnap.Description, drivers.VolumeTypeCustom, true, snapVolumeConfig, poolVolSnap.CreatedAt, time.Time{}, drivers.ContentType(poolVolSnap.ContentType), false, true, true)
 		if err != nil {
 			return nil, err
 		}
 
-		reverter.Add(func() { _ = VolumeDBDelete(b, projectName, fullSnapName, drivers.VolumeTypeCustom) })
+		reverter.Add(func() { _ = volumeDBDelete(b, projectName, fullSnapName, drivers.VolumeTypeCustom, true) })
+	}
+
+	var location string
+	if b.state.ServerClustered && !b.Driver().Info().Remote {
+		location = b.state.ServerName
+	}
+
+	// Record recovered volume with authorizer (deferred if the pool is in a maintenance session).
+	err = b.maintenanceAuthorizerUpdate(func(ctx context.Context) error {
+		return b.state.Authorizer.AddStoragePoolVolume(ctx, projectName, b.Name(), drivers.VolumeTypeCustom.Singular(), poolVol.Volume.Name, location)
+	})
+	if err != nil {
+		logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": poolVol.Volume.Name, "type": drivers.VolumeTypeCustom, "pool": b.Name(), "project": projectName, "error": err})
 	}
 
+	reverter.Add(func() {
+		_ = b.maintenanceAuthorizerUpdate(func(ctx context.Context) error {
+			return b.state.Authorizer.DeleteStoragePoolVolume(ctx, projectName, b.Name(), drivers.VolumeTypeCustom.Singular(), poolVol.Volume.Name, location)
+		})
+	})
+
 	// Get the volume name on storage.
 	volStorageName := project.StorageVolume(projectName, poolVol.Volume.Name)
 	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(poolVol.Volume.ContentType), volStorageName, volumeConfig)
@@ -6350,8 +9586,12 @@ func (b *backend) ImportCustomVolume(projectName string, poolVol *backupConfig.C
 }
 
 // CreateCustomVolumeSnapshot creates a snapshot of a custom volume.
-func (b *backend) CreateCustomVolumeSnapshot(projectName, volName string, newSnapshotName string, newExpiryDate time.Time, instanceStateful bool, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "newSnapshotName": newSnapshotName, "newExpiryDate": newExpiryDate})
+// If force is true, the pool's snapshots.reserve_percent check is skipped.
+// If auto is true, the snapshot is marked as created by the snapshot scheduler.
+// If quiesce is true, a filesystem volume used by a running instance is synced, and the instance frozen when
+// the driver requires it, before taking the snapshot.
+func (b *backend) CreateCustomVolumeSnapshot(projectName, volName string, newSnapshotName string, newExpiryDate time.Time, instanceStateful bool, force bool, auto bool, quiesce bool, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "newSnapshotName": newSnapshotName, "newExpiryDate": newExpiryDate, "auto": auto, "quiesce": quiesce})
 	l.Debug("CreateCustomVolumeSnapshot started")
 	defer l.Debug("CreateCustomVolumeSnapshot finished")
 
@@ -6373,339 +9613,952 @@ func (b *backend) CreateCustomVolumeSnapshot(projectName, volName string, newSna
 		return api.StatusErrorf(http.StatusConflict, "Snapshot by that name already exists")
 	}
 
-	// Load parent volume information and check it exists.
-	parentVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
-	if err != nil {
-		if response.IsNotFoundError(err) {
-			return api.StatusErrorf(http.StatusNotFound, "Parent volume doesn't exist")
+	// Load parent volume information and check it exists.
+	parentVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		if response.IsNotFoundError(err) {
+			return api.StatusErrorf(http.StatusNotFound, "Parent volume doesn't exist")
+		}
+
+		return err
+	}
+
+	volDBContentType, err := VolumeContentTypeNameToContentType(parentVol.ContentType)
+	if err != nil {
+		return err
+	}
+
+	contentType, err := VolumeDBContentTypeToContentType(volDBContentType)
+	if err != nil {
+		return err
+	}
+
+	if contentType != drivers.ContentTypeFS && contentType != drivers.ContentTypeBlock {
+		return fmt.Errorf("Volume of content type %q does not support snapshots", contentType)
+	}
+
+	if !force {
+		err = b.checkSnapshotReserve(projectName, volName, drivers.VolumeTypeCustom, contentType)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Lock this operation to ensure that the only one snapshot is made at the time.
+	// Other operations will wait for this one to finish.
+	unlock, err := locking.Lock(context.TODO(), drivers.OperationLockName("CreateCustomVolumeSnapshot", b.name, drivers.VolumeTypeCustom, contentType, volName))
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	var pruneSnapshotNames []string
+
+	if !isRestoreSafetySnapshot(newSnapshotName) {
+		pruneSnapshotNames, err = b.checkSnapshotLimit(projectName, volName, drivers.VolumeTypeCustom, parentVol.Config)
+		if err != nil {
+			return err
+		}
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Copy volume config from parent.
+	snapConfig := util.CloneMap(parentVol.Config)
+	if auto {
+		snapConfig["snapshots.auto"] = "true"
+	}
+
+	// Validate config and create database entry for new storage volume.
+	err = VolumeDBCreate(b, projectName, fullSnapshotName, parentVol.Description, drivers.VolumeTypeCustom, true, snapConfig, time.Now().UTC(), newExpiryDate, drivers.ContentType(parentVol.ContentType), false, true)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = VolumeDBDelete(b, projectName, fullSnapshotName, drivers.VolumeTypeCustom) })
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, fullSnapshotName)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, parentVol.Config)
+
+	// Quiesce a filesystem volume in use by a running instance, like when copying running instances.
+	if quiesce && contentType == drivers.ContentTypeFS {
+		inst, _, err := b.volumeUsedByRunningInstance(parentVol, projectName)
+		if err != nil {
+			return err
+		}
+
+		if inst != nil {
+			// Some driver backing stores require that running instances be frozen during snapshot.
+			if b.driver.Info().RunningCopyFreeze && !inst.IsFrozen() {
+				l.Info("Freezing instance for consistent snapshot", logger.Ctx{"instance": inst.Name()})
+				err = inst.Freeze()
+				if err != nil {
+					return err
+				}
+
+				defer logger.WarnOnError(inst.Unfreeze, "Failed to unfreeze instance")
+			}
+
+			// Attempt to sync the filesystem.
+			parentVolume := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, volName), parentVol.Config)
+			_ = linux.SyncFS(parentVolume.MountPath())
+		}
+	}
+
+	// Create the snapshot on the storage device.
+	err = b.driver.CreateVolumeSnapshot(vol, op)
+	if err != nil {
+		return err
+	}
+
+	if parentVol.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		// Get the parent volume.
+		volStorageParentName := project.StorageVolume(projectName, volName)
+		parentVolume := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageParentName, parentVol.Config)
+
+		reverter.Add(func() { _ = b.driver.Qcow2DeletionCleanup(vol, volStorageParentName) })
+
+		inst, devName, err := b.volumeUsedByRunningInstance(parentVol, projectName)
+		if err != nil {
+			return err
+		}
+
+		// parentVol should already be prepared as an overlay by CreateVolumeSnapshot.
+		// vol will be used as the base.
+		err = b.qcow2CreateSnapshot(parentVolume, vol, projectName, inst, devName, instanceStateful, op)
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = b.qcow2DeleteSnapshot(parentVolume, vol, projectName, inst, devName, nil) })
+	}
+
+	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeSnapshotCreated.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"type": vol.Type()}))
+
+	reverter.Success()
+
+	// Prune the snapshots beyond the limit only once the new snapshot exists.
+	b.pruneSnapshots(projectName, volName, drivers.VolumeTypeCustom, pruneSnapshotNames, op)
+
+	return nil
+}
+
+// RenameCustomVolumeSnapshot renames a custom volume.
+func (b *backend) RenameCustomVolumeSnapshot(projectName, volName string, newSnapshotName string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "newSnapshotName": newSnapshotName})
+	l.Debug("RenameCustomVolumeSnapshot started")
+	defer l.Debug("RenameCustomVolumeSnapshot finished")
+
+	parentName, oldSnapshotName, isSnap := api.GetParentAndSnapshotName(volName)
+	if !isSnap {
+		return errors.New("Volume name must be a snapshot")
+	}
+
+	if internalInstance.IsSnapshot(newSnapshotName) {
+		return errors.New("Invalid new snapshot name")
+	}
+
+	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	// Get the parent volume.
+	parentVolume, err := VolumeDBGet(b, projectName, parentName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
+	newVolName := drivers.GetSnapshotVolumeName(parentName, newSnapshotName)
+
+	if parentVolume.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		parentVol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(parentVolume.ContentType), project.StorageVolume(projectName, parentName), parentVolume.Config)
+		err = b.qcow2RenameSnapshot(parentVol, vol, newVolName, projectName, op)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = b.driver.RenameVolumeSnapshot(vol, newSnapshotName, op)
+	if err != nil {
+		return err
+	}
+
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.RenameStoragePoolVolume(ctx, projectName, volName, newVolName, db.StoragePoolVolumeTypeCustom, b.ID())
+	})
+	if err != nil {
+		// Get the volume name on storage.
+		newVolStorageName := project.StorageVolume(projectName, newVolName)
+
+		// Revert rename.
+		newVol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), newVolStorageName, nil)
+		_ = b.driver.RenameVolumeSnapshot(newVol, oldSnapshotName, op)
+		return err
+	}
+
+	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeSnapshotRenamed.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"old_name": oldSnapshotName}))
+
+	return nil
+}
+
+// DeleteCustomVolumeSnapshot removes a custom volume snapshot.
+func (b *backend) DeleteCustomVolumeSnapshot(projectName, volName string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName})
+	l.Debug("DeleteCustomVolumeSnapshot started")
+	defer l.Debug("DeleteCustomVolumeSnapshot finished")
+
+	parentName, _, isSnap := api.GetParentAndSnapshotName(volName)
+
+	if !isSnap {
+		return errors.New("Volume name must be a snapshot")
+	}
+
+	// Get the volume.
+	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	// Get the parent volume.
+	parentVolume, err := VolumeDBGet(b, projectName, parentName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	// Get the content type.
+	dbContentType, err := VolumeContentTypeNameToContentType(volume.ContentType)
+	if err != nil {
+		return err
+	}
+
+	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
+	if err != nil {
+		return err
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, volume.Config)
+
+	// Delete the snapshot from the storage device.
+	// Must come before DB VolumeDBDelete so that the volume ID is still available.
+	volExists, err := b.driver.HasVolume(vol)
+	if err != nil {
+		return err
+	}
+
+	if volExists {
+		if parentVolume.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
+			inst, devName, err := b.volumeUsedByRunningInstance(parentVolume, projectName)
+			if err != nil {
+				return err
+			}
+
+			parentVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, parentName), parentVolume.Config)
+			err = b.qcow2DeleteSnapshot(parentVol, vol, projectName, inst, devName, op)
+			if err != nil {
+				return err
+			}
+		} else {
+			err := b.driver.DeleteVolumeSnapshot(vol, op)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Remove the snapshot volume record from the database.
+	err = VolumeDBDelete(b, projectName, volName, vol.Type())
+	if err != nil {
+		return err
+	}
+
+	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeSnapshotDeleted.Event(vol, string(vol.Type()), projectName, op, nil))
+
+	return nil
+}
+
+// ApplyRetentionPolicy deletes the oldest snapshots of a volume beyond the count set in its snapshots.retain
+// config key, starting with scheduled ones. It returns the number of snapshots deleted.
+func (b *backend) ApplyRetentionPolicy(projectName string, volName string, volType drivers.VolumeType, op *operations.Operation) (int, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "volType": volType})
+	l.Debug("ApplyRetentionPolicy started")
+	defer l.Debug("ApplyRetentionPolicy finished")
+
+	dbVol, err := VolumeDBGet(b, projectName, volName, volType)
+	if err != nil {
+		return 0, err
+	}
+
+	if dbVol.Config["snapshots.retain"] == "" {
+		return 0, nil
+	}
+
+	retain, err := strconv.Atoi(dbVol.Config["snapshots.retain"])
+	if err != nil {
+		return 0, fmt.Errorf("Invalid snapshots.retain value: %w", err)
+	}
+
+	if retain <= 0 {
+		return 0, nil
+	}
+
+	// Snapshots are returned oldest first.
+	dbSnapshots, err := VolumeDBSnapshotsGet(b, projectName, volName, volType)
+	if err != nil {
+		return 0, err
+	}
+
+	// Pre-restore snapshots are kept until removed by the user, so they neither count nor get pruned.
+	snapshots := withoutRestoreSafetySnapshots(dbSnapshots)
+	if len(snapshots) <= retain {
+		return 0, nil
+	}
+
+	// Prefer pruning the snapshots created by the snapshot scheduler over the ones created manually.
+	slices.SortStableFunc(snapshots, func(a db.StorageVolumeArgs, b db.StorageVolumeArgs) int {
+		aAuto := util.IsTrue(a.Config["snapshots.auto"])
+		bAuto := util.IsTrue(b.Config["snapshots.auto"])
+
+		if aAuto == bAuto {
+			return 0
+		}
+
+		if aAuto {
+			return -1
+		}
+
+		return 1
+	})
+
+	deleted := 0
+	for _, snapshot := range snapshots[:len(snapshots)-retain] {
+		err = b.deleteSnapshotByName(projectName, snapshot.Name, volType, op)
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	l.Debug("Deleted snapshots beyond retention count", logger.Ctx{"retain": retain, "deleted": deleted})
+
+	return deleted, nil
+}
+
+// deleteSnapshotByName deletes an instance or custom volume snapshot using the same path as a user request.
+func (b *backend) deleteSnapshotByName(projectName string, snapshotName string, volType drivers.VolumeType, op *operations.Operation) error {
+	if volType == drivers.VolumeTypeCustom {
+		err := b.DeleteCustomVolumeSnapshot(projectName, snapshotName, op)
+		if err != nil {
+			return fmt.Errorf("Failed deleting snapshot %q: %w", snapshotName, err)
+		}
+
+		return nil
+	}
+
+	// Instance snapshots are removed through the instance so that their records are cleaned up too.
+	snap, err := instance.LoadByProjectAndName(b.state, projectName, snapshotName)
+	if err != nil {
+		return fmt.Errorf("Failed loading snapshot %q: %w", snapshotName, err)
+	}
+
+	err = snap.Delete(true, true)
+	if err != nil {
+		return fmt.Errorf("Failed deleting snapshot %q: %w", snapshotName, err)
+	}
+
+	return nil
+}
+
+// checkSnapshotLimit checks the snapshot count of a volume against its snapshots.max config key before a new
+// snapshot is created. Once the limit is reached, the new snapshot is refused unless snapshots.max.policy is set
+// to "prune", in which case the names of the oldest snapshots to delete once the new one exists are returned.
+// This must be called with the volume's snapshot lock held, so that the count can't change until the pruning.
+func (b *backend) checkSnapshotLimit(projectName string, volName string, volType drivers.VolumeType, volConfig map[string]string) ([]string, error) {
+	if volConfig["snapshots.max"] == "" {
+		return nil, nil
+	}
+
+	// Snapshots are returned oldest first.
+	snapshots, err := VolumeDBSnapshotsGet(b, projectName, volName, volType)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshotsBeyondLimit(volName, volConfig, snapshots)
+}
+
+// pruneSnapshots deletes the snapshots returned by checkSnapshotLimit once the new snapshot has been created.
+// The new snapshot is kept if this fails, the limit is enforced again on the next snapshot.
+func (b *backend) pruneSnapshots(projectName string, volName string, volType drivers.VolumeType, snapshotNames []string, op *operations.Operation) {
+	for _, snapshotName := range snapshotNames {
+		err := b.deleteSnapshotByName(projectName, snapshotName, volType, op)
+		if err != nil {
+			b.logger.Warn("Failed pruning snapshot beyond snapshot limit", logger.Ctx{"project": projectName, "volName": volName, "err": err})
+			return
+		}
+	}
+
+	if len(snapshotNames) > 0 {
+		b.logger.Debug("Deleted oldest snapshots to stay within snapshot limit", logger.Ctx{"project": projectName, "volName": volName, "deleted": len(snapshotNames)})
+	}
+}
+
+// DeleteCustomVolumeSnapshots deletes the given custom volume snapshots, running up to workers deletions
+// concurrently. If workers isn't positive, the pool's snapshots.delete_workers setting is used instead.
+// Snapshots of volumes that must have their snapshots deleted in order are deleted one at a time, in the order
+// they were given. Deletion continues past failures and all errors are returned together.
+func (b *backend) DeleteCustomVolumeSnapshots(projectName string, snapshotNames []string, workers int, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "count": len(snapshotNames), "workers": workers})
+	l.Debug("DeleteCustomVolumeSnapshots started")
+	defer l.Debug("DeleteCustomVolumeSnapshots finished")
+
+	if workers <= 0 {
+		workers = 1
+
+		if b.db.Config["snapshots.delete_workers"] != "" {
+			var err error
+
+			workers, err = strconv.Atoi(b.db.Config["snapshots.delete_workers"])
+			if err != nil {
+				return fmt.Errorf("Invalid snapshots.delete_workers value: %w", err)
+			}
+		}
+	}
+
+	var errs []error
+	var errsMu sync.Mutex
+
+	addError := func(snapName string, err error) {
+		errsMu.Lock()
+		errs = append(errs, fmt.Errorf("Failed deleting snapshot %q: %w", snapName, err))
+		errsMu.Unlock()
+	}
+
+	// Split the snapshots into jobs. Snapshots that must be deleted in order share a job with the other
+	// snapshots of their volume (or of the whole pool if the driver requires it).
+	var jobs [][]string
+	serialJobs := make(map[string]int)
+
+	for _, snapName := range snapshotNames {
+		parentName, _, isSnap := api.GetParentAndSnapshotName(snapName)
+		if !isSnap {
+			addError(snapName, errors.New("Volume isn't a snapshot"))
+			continue
+		}
+
+		serialKey := ""
+		if !b.driver.Info().SerialSnapshotDeletes {
+			parentVol, err := VolumeDBGet(b, projectName, parentName, drivers.VolumeTypeCustom)
+			if err != nil {
+				addError(snapName, err)
+				continue
+			}
+
+			// Qcow2 snapshots are chained, so their deletion order matters.
+			if parentVol.Config["block.type"] != drivers.BlockVolumeTypeQcow2 {
+				jobs = append(jobs, []string{snapName})
+				continue
+			}
+
+			serialKey = parentName
+		}
+
+		jobIndex, found := serialJobs[serialKey]
+		if !found {
+			jobIndex = len(jobs)
+			serialJobs[serialKey] = jobIndex
+			jobs = append(jobs, nil)
+		}
+
+		jobs[jobIndex] = append(jobs[jobIndex], snapName)
+	}
+
+	g := errgroup.Group{}
+	g.SetLimit(workers)
+
+	for _, job := range jobs {
+		g.Go(func() error {
+			for _, snapName := range job {
+				err := b.DeleteCustomVolumeSnapshot(projectName, snapName, op)
+				if err != nil {
+					// Don't go on with the newer snapshots when deleting in order.
+					addError(snapName, err)
+					return nil
+				}
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	return errors.Join(errs...)
+}
+
+// RestoreCustomVolume restores a custom volume from a snapshot.
+// If safetySnapshot is true, a snapshot of the current state is taken first and its name returned. That snapshot
+// is kept even if the restore fails and isn't subject to the snapshots.max limit.
+func (b *backend) RestoreCustomVolume(projectName, volName string, snapshotName string, safetySnapshot bool, op *operations.Operation) (string, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "snapshotName": snapshotName})
+	l.Debug("RestoreCustomVolume started")
+	defer l.Debug("RestoreCustomVolume finished")
+
+	// Quick checks.
+	if internalInstance.IsSnapshot(volName) {
+		return "", errors.New("Volume cannot be snapshot")
+	}
+
+	if internalInstance.IsSnapshot(snapshotName) {
+		return "", errors.New("Invalid snapshot name")
+	}
+
+	// Get current volume.
+	curVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return "", err
+	}
+
+	// Check that the volume isn't in use by running instances.
+	err = VolumeUsedByInstanceDevices(b.state, b.Name(), projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+		inst, err := instance.Load(b.state, dbInst, project)
+		if err != nil {
+			return err
+		}
+
+		if inst.IsRunning() {
+			return errors.New("Cannot restore custom volume used by running instances")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	dbContentType, err := VolumeContentTypeNameToContentType(curVol.ContentType)
+	if err != nil {
+		return "", err
+	}
+
+	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
+	if err != nil {
+		return "", err
+	}
+
+	// Take a snapshot of the current state first if requested, it is kept even if the restore fails.
+	var safetySnapName string
+	if safetySnapshot {
+		safetySnapName = restoreSafetySnapshotName()
+		err = b.CreateCustomVolumeSnapshot(projectName, volName, safetySnapName, time.Time{}, false, false, false, false, op)
+		if err != nil {
+			return "", fmt.Errorf("Failed creating pre-restore snapshot: %w", err)
+		}
+
+		l.Info("Created pre-restore snapshot", logger.Ctx{"snapshot": safetySnapName})
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, curVol.Config)
+
+	deleteSnapshots := func(snapshots []string) error {
+		if safetySnapName != "" && slices.Contains(snapshots, safetySnapName) {
+			return fmt.Errorf("Restoring snapshot %q would delete the pre-restore snapshot %q", snapshotName, safetySnapName)
+		}
+
+		for _, snapName := range snapshots {
+			err := b.DeleteCustomVolumeSnapshot(projectName, fmt.Sprintf("%s/%s", volName, snapName), op)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if curVol.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		fullSnapName := fmt.Sprintf("%s/%s", volName, snapshotName)
+		snapVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, fullSnapName), curVol.Config)
+		err = b.qcow2RestoreSnapshot(vol, snapVol, projectName, op)
+		if err != nil {
+			var snapErr drivers.ErrDeleteSnapshots
+			if errors.As(err, &snapErr) {
+				err = deleteSnapshots(snapErr.Snapshots)
+				if err != nil {
+					return "", err
+				}
+
+				// Now try again.
+				err = b.qcow2RestoreSnapshot(vol, snapVol, projectName, op)
+				if err != nil {
+					return "", err
+				}
+
+				return safetySnapName, nil
+			}
+
+			return "", err
+		}
+
+		return safetySnapName, nil
+	}
+
+	// Rolling back to the snapshot would delete the newer pre-restore snapshot on drivers which can only roll
+	// back to their latest snapshot, so copy the snapshot's content onto the volume instead.
+	if safetySnapName != "" && b.driver.CanRestoreVolume(vol, snapshotName) != nil {
+		fullSnapName := drivers.GetSnapshotVolumeName(volName, snapshotName)
+		snapVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, fullSnapName), curVol.Config)
+		err = b.restoreVolumeByCopy(vol, snapVol, op)
+		if err != nil {
+			return "", err
+		}
+
+		b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeRestored.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"snapshot": snapshotName}))
+
+		return safetySnapName, nil
+	}
+
+	err = b.restoreVolume(vol, snapshotName, op)
+	if err != nil {
+		var snapErr drivers.ErrDeleteSnapshots
+		if errors.As(err, &snapErr) {
+			err = deleteSnapshots(snapErr.Snapshots)
+			if err != nil {
+				return "", err
+			}
+
+			// Now try again.
+			err = b.restoreVolume(vol, snapshotName, op)
+			if err != nil {
+				return "", err
+			}
+
+			return safetySnapName, nil
 		}
 
-		return err
+		return "", err
 	}
 
-	volDBContentType, err := VolumeContentTypeNameToContentType(parentVol.ContentType)
+	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeRestored.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"snapshot": snapshotName}))
+
+	return safetySnapName, nil
+}
+
+// RestoreCustomVolumeFiles restores the given paths of a filesystem custom volume from one of its snapshots.
+func (b *backend) RestoreCustomVolumeFiles(projectName string, volName string, snapshotName string, paths []string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "snapshotName": snapshotName, "paths": paths})
+	l.Debug("RestoreCustomVolumeFiles started")
+	defer l.Debug("RestoreCustomVolumeFiles finished")
+
+	err := b.isStatusReady()
 	if err != nil {
 		return err
 	}
 
-	contentType, err := VolumeDBContentTypeToContentType(volDBContentType)
-	if err != nil {
-		return err
+	// Quick checks.
+	if internalInstance.IsSnapshot(volName) {
+		return errors.New("Volume cannot be snapshot")
 	}
 
-	if contentType != drivers.ContentTypeFS && contentType != drivers.ContentTypeBlock {
-		return fmt.Errorf("Volume of content type %q does not support snapshots", contentType)
+	if internalInstance.IsSnapshot(snapshotName) {
+		return errors.New("Invalid snapshot name")
 	}
 
-	reverter := revert.New()
-	defer reverter.Fail()
+	if len(paths) == 0 {
+		return errors.New("No paths to restore provided")
+	}
 
-	// Validate config and create database entry for new storage volume.
-	// Copy volume config from parent.
-	err = VolumeDBCreate(b, projectName, fullSnapshotName, parentVol.Description, drivers.VolumeTypeCustom, true, parentVol.Config, time.Now().UTC(), newExpiryDate, drivers.ContentType(parentVol.ContentType), false, true)
+	// Get current volume.
+	curVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
 	if err != nil {
 		return err
 	}
 
-	reverter.Add(func() { _ = VolumeDBDelete(b, projectName, fullSnapshotName, drivers.VolumeTypeCustom) })
-
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, fullSnapshotName)
-	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, parentVol.Config)
-
-	// Lock this operation to ensure that the only one snapshot is made at the time.
-	// Other operations will wait for this one to finish.
-	unlock, err := locking.Lock(context.TODO(), drivers.OperationLockName("CreateCustomVolumeSnapshot", b.name, vol.Type(), contentType, volName))
-	if err != nil {
-		return err
+	if curVol.ContentType != db.StoragePoolVolumeContentTypeNameFS {
+		return errors.New("Restoring individual files is only supported for filesystem volumes")
 	}
 
-	defer unlock()
+	fullSnapName := drivers.GetSnapshotVolumeName(volName, snapshotName)
 
-	// Create the snapshot on the storage device.
-	err = b.driver.CreateVolumeSnapshot(vol, op)
+	snapVolume, err := VolumeDBGet(b, projectName, fullSnapName, drivers.VolumeTypeCustom)
 	if err != nil {
 		return err
 	}
 
-	if parentVol.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
-		// Get the parent volume.
-		volStorageParentName := project.StorageVolume(projectName, volName)
-		parentVolume := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageParentName, parentVol.Config)
-
-		reverter.Add(func() { _ = b.driver.Qcow2DeletionCleanup(vol, volStorageParentName) })
-
-		inst, devName, err := b.volumeUsedByRunningInstance(parentVol, projectName)
+	// Check that the volume isn't in use by running instances.
+	err = VolumeUsedByInstanceDevices(b.state, b.Name(), projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+		inst, err := instance.Load(b.state, dbInst, project)
 		if err != nil {
 			return err
 		}
 
-		// parentVol should already be prepared as an overlay by CreateVolumeSnapshot.
-		// vol will be used as the base.
-		err = b.qcow2CreateSnapshot(parentVolume, vol, projectName, inst, devName, instanceStateful, op)
+		if inst.IsRunning() {
+			return errors.New("Cannot restore files of custom volume used by running instances")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Validate the paths, they must stay within the volume.
+	relPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relPath, err := restoreFilesRelPath(path)
 		if err != nil {
-			return err
+			return api.StatusErrorf(http.StatusBadRequest, "%w", err)
 		}
 
-		reverter.Add(func() { _ = b.qcow2DeleteSnapshot(parentVolume, vol, projectName, inst, devName, nil) })
+		relPaths = append(relPaths, relPath)
 	}
 
-	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeSnapshotCreated.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"type": vol.Type()}))
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentTypeFS, project.StorageVolume(projectName, volName), curVol.Config)
+	snapVol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentTypeFS, project.StorageVolume(projectName, fullSnapName), snapVolume.Config)
+	bwlimit := b.driver.Config()["rsync.bwlimit"]
 
-	reverter.Success()
-	return nil
-}
+	err = vol.MountTask(func(mountPath string, op *operations.Operation) error {
+		return snapVol.MountTask(func(snapMountPath string, op *operations.Operation) error {
+			// Read from a read-only bind mount so the restore can't modify the snapshot.
+			snapPath, cleanup, err := readOnlyBindMount(b.name, snapMountPath, "restore_")
+			if err != nil {
+				return err
+			}
 
-// RenameCustomVolumeSnapshot renames a custom volume.
-func (b *backend) RenameCustomVolumeSnapshot(projectName, volName string, newSnapshotName string, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "newSnapshotName": newSnapshotName})
-	l.Debug("RenameCustomVolumeSnapshot started")
-	defer l.Debug("RenameCustomVolumeSnapshot finished")
+			defer cleanup()
 
-	parentName, oldSnapshotName, isSnap := api.GetParentAndSnapshotName(volName)
-	if !isSnap {
-		return errors.New("Volume name must be a snapshot")
-	}
+			for _, relPath := range relPaths {
+				// Resolve the parent directories within the snapshot and the volume, so that symlinks
+				// can't point the restore outside of them.
+				srcDir, err := securejoin.SecureJoin(snapPath, filepath.Dir(relPath))
+				if err != nil {
+					return fmt.Errorf("Failed resolving %q in snapshot: %w", relPath, err)
+				}
 
-	if internalInstance.IsSnapshot(newSnapshotName) {
-		return errors.New("Invalid new snapshot name")
-	}
+				dstDir, err := securejoin.SecureJoin(mountPath, filepath.Dir(relPath))
+				if err != nil {
+					return fmt.Errorf("Failed resolving %q in volume: %w", relPath, err)
+				}
 
-	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
-	if err != nil {
-		return err
-	}
+				_, err = os.Lstat(filepath.Join(srcDir, filepath.Base(relPath)))
+				if err != nil {
+					return fmt.Errorf("Failed accessing %q in snapshot: %w", relPath, err)
+				}
 
-	// Get the parent volume.
-	parentVolume, err := VolumeDBGet(b, projectName, parentName, drivers.VolumeTypeCustom)
+				// Sync only the requested entry (and its content) of the parent directory.
+				pattern := "/" + rsyncEscapePattern(filepath.Base(relPath))
+				_, err = rsync.LocalCopy(srcDir, dstDir, bwlimit, true, "--include="+pattern, "--include="+pattern+"/***", "--exclude=*")
+				if err != nil {
+					return fmt.Errorf("Failed restoring %q from snapshot: %w", relPath, err)
+				}
+			}
+
+			return nil
+		}, op)
+	}, op)
 	if err != nil {
 		return err
 	}
 
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, volName)
+	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeRestored.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"snapshot": snapshotName, "paths": relPaths}))
 
-	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
-	newVolName := drivers.GetSnapshotVolumeName(parentName, newSnapshotName)
+	return nil
+}
 
-	if parentVolume.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
-		parentVol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(parentVolume.ContentType), project.StorageVolume(projectName, parentName), parentVolume.Config)
-		err = b.qcow2RenameSnapshot(parentVol, vol, newVolName, projectName, op)
-		if err != nil {
-			return err
-		}
-	}
+// PromoteCustomVolumeSnapshot creates a new independent custom volume from a snapshot, optionally
+// deleting the snapshot afterwards. It returns whether the driver could do this without a full copy.
+func (b *backend) PromoteCustomVolumeSnapshot(projectName string, volName string, snapshotName string, newVolName string, deleteSnapshot bool, op *operations.Operation) (bool, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "snapshotName": snapshotName, "newVolName": newVolName, "deleteSnapshot": deleteSnapshot})
+	l.Debug("PromoteCustomVolumeSnapshot started")
+	defer l.Debug("PromoteCustomVolumeSnapshot finished")
 
-	err = b.driver.RenameVolumeSnapshot(vol, newSnapshotName, op)
+	err := b.isStatusReady()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		return tx.RenameStoragePoolVolume(ctx, projectName, volName, newVolName, db.StoragePoolVolumeTypeCustom, b.ID())
-	})
-	if err != nil {
-		// Get the volume name on storage.
-		newVolStorageName := project.StorageVolume(projectName, newVolName)
-
-		// Revert rename.
-		newVol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), newVolStorageName, nil)
-		_ = b.driver.RenameVolumeSnapshot(newVol, oldSnapshotName, op)
-		return err
+	// Quick checks.
+	if internalInstance.IsSnapshot(volName) {
+		return false, errors.New("Volume cannot be snapshot")
 	}
 
-	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeSnapshotRenamed.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"old_name": oldSnapshotName}))
+	if internalInstance.IsSnapshot(snapshotName) {
+		return false, errors.New("Invalid snapshot name")
+	}
 
-	return nil
-}
+	if internalInstance.IsSnapshot(newVolName) {
+		return false, errors.New("New volume name cannot be a snapshot name")
+	}
 
-// DeleteCustomVolumeSnapshot removes a custom volume snapshot.
-func (b *backend) DeleteCustomVolumeSnapshot(projectName, volName string, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName})
-	l.Debug("DeleteCustomVolumeSnapshot started")
-	defer l.Debug("DeleteCustomVolumeSnapshot finished")
+	fullSnapName := drivers.GetSnapshotVolumeName(volName, snapshotName)
 
-	parentName, _, isSnap := api.GetParentAndSnapshotName(volName)
+	snapVolume, err := VolumeDBGet(b, projectName, fullSnapName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return false, err
+	}
 
-	if !isSnap {
-		return errors.New("Volume name must be a snapshot")
+	// Check the new volume doesn't exist already.
+	newVolume, err := VolumeDBGet(b, projectName, newVolName, drivers.VolumeTypeCustom)
+	if err != nil && !response.IsNotFoundError(err) {
+		return false, err
+	} else if newVolume != nil {
+		return false, api.StatusErrorf(http.StatusConflict, "Volume by that name already exists")
 	}
 
-	// Get the volume.
-	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	contentDBType, err := VolumeContentTypeNameToContentType(snapVolume.ContentType)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	// Get the parent volume.
-	parentVolume, err := VolumeDBGet(b, projectName, parentName, drivers.VolumeTypeCustom)
+	contentType, err := VolumeDBContentTypeToContentType(contentDBType)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	// Get the content type.
-	dbContentType, err := VolumeContentTypeNameToContentType(volume.ContentType)
-	if err != nil {
-		return err
+	if contentType != drivers.ContentTypeFS && contentType != drivers.ContentTypeBlock {
+		return false, fmt.Errorf("Promoting snapshots of %q volumes isn't supported", contentType)
 	}
 
-	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
-	if err != nil {
-		return err
+	optimized := b.driver.Info().IndependentSnapshotCopies
+	if !optimized && snapVolume.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		return false, errors.New("Promoting snapshots of qcow2 volumes isn't supported by this storage driver")
 	}
 
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, volName)
+	reverter := revert.New()
+	defer reverter.Fail()
 
-	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, volume.Config)
+	config := maps.Clone(snapVolume.Config)
 
-	// Delete the snapshot from the storage device.
-	// Must come before DB VolumeDBDelete so that the volume ID is still available.
-	volExists, err := b.driver.HasVolume(vol)
+	err = VolumeDBCreate(b, projectName, newVolName, snapVolume.Description, drivers.VolumeTypeCustom, false, config, time.Now().UTC(), time.Time{}, contentType, false, true)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	if volExists {
-		if parentVolume.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
-			inst, devName, err := b.volumeUsedByRunningInstance(parentVolume, projectName)
-			if err != nil {
-				return err
-			}
+	reverter.Add(func() { _ = VolumeDBDelete(b, projectName, newVolName, drivers.VolumeTypeCustom) })
 
-			parentVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, parentName), parentVolume.Config)
-			err = b.qcow2DeleteSnapshot(parentVol, vol, projectName, inst, devName, op)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := b.driver.DeleteVolumeSnapshot(vol, op)
-			if err != nil {
-				return err
-			}
-		}
+	snapVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, fullSnapName), snapVolume.Config)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, newVolName), config)
+
+	if optimized {
+		// The driver's copy of a snapshot doesn't keep any dependency on it.
+		err = b.driver.CreateVolumeFromCopy(vol, snapVol, false, false, op)
+	} else {
+		err = b.copyVolumeFull(snapVol, vol, op)
 	}
 
-	// Remove the snapshot volume record from the database.
-	err = VolumeDBDelete(b, projectName, volName, vol.Type())
 	if err != nil {
-		return err
+		return false, fmt.Errorf("Failed promoting snapshot %q: %w", snapshotName, err)
 	}
 
-	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeSnapshotDeleted.Event(vol, string(vol.Type()), projectName, op, nil))
-
-	return nil
-}
-
-// RestoreCustomVolume restores a custom volume from a snapshot.
-func (b *backend) RestoreCustomVolume(projectName, volName string, snapshotName string, op *operations.Operation) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "snapshotName": snapshotName})
-	l.Debug("RestoreCustomVolume started")
-	defer l.Debug("RestoreCustomVolume finished")
-
-	// Quick checks.
-	if internalInstance.IsSnapshot(volName) {
-		return errors.New("Volume cannot be snapshot")
-	}
+	reverter.Add(func() { _ = b.driver.DeleteVolume(vol, op) })
 
-	if internalInstance.IsSnapshot(snapshotName) {
-		return errors.New("Invalid snapshot name")
-	}
+	eventCtx := logger.Ctx{"type": vol.Type(), "snapshot": fullSnapName, "optimized": optimized}
 
-	// Get current volume.
-	curVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
-	if err != nil {
-		return err
+	var location string
+	if b.state.ServerClustered && !b.Driver().Info().Remote {
+		eventCtx["location"] = b.state.ServerName
+		location = b.state.ServerName
 	}
 
-	// Check that the volume isn't in use by running instances.
-	err = VolumeUsedByInstanceDevices(b.state, b.Name(), projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
-		inst, err := instance.Load(b.state, dbInst, project)
+	if deleteSnapshot {
+		err = b.DeleteCustomVolumeSnapshot(projectName, fullSnapName, op)
 		if err != nil {
-			return err
-		}
-
-		if inst.IsRunning() {
-			return errors.New("Cannot restore custom volume used by running instances")
+			return false, fmt.Errorf("Failed deleting promoted snapshot %q: %w", snapshotName, err)
 		}
-
-		return nil
-	})
-	if err != nil {
-		return err
 	}
 
-	dbContentType, err := VolumeContentTypeNameToContentType(curVol.ContentType)
+	// Record new volume with authorizer.
+	err = b.state.Authorizer.AddStoragePoolVolume(b.state.ShutdownCtx, projectName, b.Name(), vol.Type().Singular(), newVolName, location)
 	if err != nil {
-		return err
+		logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": newVolName, "type": vol.Type(), "pool": b.Name(), "project": projectName, "error": err})
 	}
 
-	contentType, err := VolumeDBContentTypeToContentType(dbContentType)
-	if err != nil {
-		return err
+	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeCreated.Event(vol, string(vol.Type()), projectName, op, eventCtx))
+
+	if op != nil {
+		err = op.ExtendMetadata(map[string]any{"promote_optimized": optimized})
+		if err != nil {
+			l.Warn("Failed updating operation metadata", logger.Ctx{"err": err})
+		}
 	}
 
-	// Get the volume name on storage.
-	volStorageName := project.StorageVolume(projectName, volName)
-	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, curVol.Config)
+	reverter.Success()
+	return optimized, nil
+}
 
-	deleteSnapshots := func(snapshots []string) error {
-		for _, snapName := range snapshots {
-			err := b.DeleteCustomVolumeSnapshot(projectName, fmt.Sprintf("%s/%s", volName, snapName), op)
+// copyVolumeFull creates vol and copies the full content of srcVol into it, without relying on any
+// driver-side sharing of data between the two volumes.
+func (b *backend) copyVolumeFull(srcVol drivers.Volume, vol drivers.Volume, op *operations.Operation) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Make sure a block target is at least as large as the source.
+	if vol.ContentType() == drivers.ContentTypeBlock {
+		err := srcVol.MountTask(func(_ string, op *operations.Operation) error {
+			srcDevPath, err := b.driver.GetVolumeDiskPath(srcVol)
 			if err != nil {
 				return err
 			}
-		}
-
-		return nil
-	}
 
-	if curVol.Config["block.type"] == drivers.BlockVolumeTypeQcow2 {
-		fullSnapName := fmt.Sprintf("%s/%s", volName, snapshotName)
-		snapVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, project.StorageVolume(projectName, fullSnapName), curVol.Config)
-		err = b.qcow2RestoreSnapshot(vol, snapVol, projectName, op)
-		if err != nil {
-			var snapErr drivers.ErrDeleteSnapshots
-			if errors.As(err, &snapErr) {
-				err = deleteSnapshots(snapErr.Snapshots)
-				if err != nil {
-					return err
-				}
-
-				// Now try again.
-				err = b.qcow2RestoreSnapshot(vol, snapVol, projectName, op)
-				if err != nil {
-					return err
-				}
+			srcSize, err := drivers.BlockDiskSizeBytes(srcDevPath)
+			if err != nil {
+				return err
 			}
 
+			vol.SetConfigSize(fmt.Sprintf("%d", srcSize))
+			return nil
+		}, op)
+		if err != nil {
 			return err
 		}
-
-		return nil
 	}
 
-	err = b.driver.RestoreVolume(vol, snapshotName, op)
+	err := b.driver.CreateVolume(vol, nil, op)
 	if err != nil {
-		var snapErr drivers.ErrDeleteSnapshots
-		if errors.As(err, &snapErr) {
-			err = deleteSnapshots(snapErr.Snapshots)
+		return err
+	}
+
+	reverter.Add(func() { _ = b.driver.DeleteVolume(vol, op) })
+
+	bwlimit := b.driver.Config()["rsync.bwlimit"]
+
+	err = vol.MountTask(func(mountPath string, op *operations.Operation) error {
+		return srcVol.MountTask(func(srcMountPath string, op *operations.Operation) error {
+			if vol.ContentType() != drivers.ContentTypeBlock {
+				_, err := rsync.LocalCopy(srcMountPath, mountPath, bwlimit, true)
+				return err
+			}
+
+			srcDevPath, err := b.driver.GetVolumeDiskPath(srcVol)
 			if err != nil {
 				return err
 			}
 
-			// Now try again.
-			err = b.driver.RestoreVolume(vol, snapshotName, op)
+			devPath, err := b.driver.GetVolumeDiskPath(vol)
 			if err != nil {
 				return err
 			}
-		}
 
+			return drivers.CopyDevice(srcDevPath, devPath)
+		}, op)
+	}, op)
+	if err != nil {
 		return err
 	}
 
-	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeRestored.Event(vol, string(vol.Type()), projectName, op, logger.Ctx{"snapshot": snapshotName}))
-
+	reverter.Success()
 	return nil
 }
 
@@ -6764,12 +10617,20 @@ func (b *backend) GenerateCustomVolumeBackupConfig(projectName string, volName s
 		Volume: &vol.StorageVolume,
 	}
 
-	if snapshots {
-		dbVolSnaps, err := VolumeDBSnapshotsGet(b, projectName, vol.Name, drivers.VolumeTypeCustom)
-		if err != nil {
-			return nil, err
-		}
+	dbVolSnaps, err := VolumeDBSnapshotsGet(b, projectName, vol.Name, drivers.VolumeTypeCustom)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fall back to the oldest snapshot for volume records without a creation date.
+	var oldestSnapCreatedAt time.Time
+	if len(dbVolSnaps) > 0 {
+		oldestSnapCreatedAt = dbVolSnaps[0].CreationDate
+	}
 
+	config.Volume.CreatedAt = backupVolumeCreationDate(config.Volume.CreatedAt, oldestSnapCreatedAt)
+
+	if snapshots {
 		config.VolumeSnapshots = make([]*api.StorageVolumeSnapshot, 0, len(dbVolSnaps))
 		for i := range dbVolSnaps {
 			_, snapName, _ := api.GetParentAndSnapshotName(dbVolSnaps[i].Name)
@@ -6816,6 +10677,9 @@ func (b *backend) GenerateInstanceBackupConfig(inst instance.Instance, snapshots
 		Volume: &volume.StorageVolume,
 	}
 
+	// Fall back to the instance for volume records without a creation date.
+	config.Volume.CreatedAt = backupVolumeCreationDate(config.Volume.CreatedAt, inst.CreationDate())
+
 	// Add profiles from instance.
 	instProfiles := inst.Profiles()
 	config.Profiles = make([]*api.Profile, len(instProfiles))
@@ -6823,97 +10687,385 @@ func (b *backend) GenerateInstanceBackupConfig(inst instance.Instance, snapshots
 		config.Profiles[i] = &instProfiles[i]
 	}
 
-	if dependentVolumes {
-		config.DependentVolumes = []*backupConfig.Config{}
-		err = inst.ForEachDependentDiskType(func(dev deviceConfig.DeviceNamed) error {
-			// Load the pool for the disk.
-			diskPool, err := LoadByName(b.state, dev.Config["pool"])
+	if dependentVolumes {
+		config.DependentVolumes = []*backupConfig.Config{}
+		err = inst.ForEachDependentDiskType(func(dev deviceConfig.DeviceNamed) error {
+			// Load the pool for the disk.
+			diskPool, err := LoadByName(b.state, dev.Config["pool"])
+			if err != nil {
+				return fmt.Errorf("Failed loading storage pool: %w", err)
+			}
+
+			diskConfig, err := diskPool.GenerateCustomVolumeBackupConfig(inst.Project().Name, dev.Config["source"], snapshots, op)
+			if err != nil {
+				return err
+			}
+
+			poolDB := diskPool.ToAPI()
+			diskConfig.Pool = &poolDB
+
+			config.DependentVolumes = append(config.DependentVolumes, diskConfig)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Only populate Container field for non-snapshot instances.
+	if !inst.IsSnapshot() {
+		apiInst, ok := ci.(*api.Instance)
+		if !ok {
+			return nil, fmt.Errorf("Unexpected instance type %T for non-snapshot instance", ci)
+		}
+
+		config.Container = apiInst
+
+		if snapshots {
+			snapshots, err := inst.Snapshots()
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get snapshots: %w", err)
+			}
+
+			config.Snapshots = make([]*api.InstanceSnapshot, 0, len(snapshots))
+			for _, s := range snapshots {
+				si, _, err := s.Render()
+				if err != nil {
+					return nil, err
+				}
+
+				config.Snapshots = append(config.Snapshots, si.(*api.InstanceSnapshot))
+			}
+
+			dbVolSnaps, err := VolumeDBSnapshotsGet(b, inst.Project().Name, inst.Name(), volType)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(snapshots) != len(dbVolSnaps) {
+				return nil, errors.New("Instance snapshot record count doesn't match instance snapshot volume record count")
+			}
+
+			config.VolumeSnapshots = make([]*api.StorageVolumeSnapshot, 0, len(dbVolSnaps))
+			for i := range dbVolSnaps {
+				foundInstanceSnapshot := false
+				for _, snap := range snapshots {
+					if snap.Name() == dbVolSnaps[i].Name {
+						foundInstanceSnapshot = true
+						break
+					}
+				}
+
+				if !foundInstanceSnapshot {
+					return nil, fmt.Errorf("Instance snapshot record missing for %q", dbVolSnaps[i].Name)
+				}
+
+				_, snapName, _ := api.GetParentAndSnapshotName(dbVolSnaps[i].Name)
+
+				config.VolumeSnapshots = append(config.VolumeSnapshots, &api.StorageVolumeSnapshot{
+					StorageVolumeSnapshotPut: api.StorageVolumeSnapshotPut{
+						Description: dbVolSnaps[i].Description,
+						ExpiresAt:   &dbVolSnaps[i].ExpiryDate,
+					},
+					Name:        snapName,
+					Config:      dbVolSnaps[i].Config,
+					ContentType: dbVolSnaps[i].ContentType,
+					CreatedAt:   dbVolSnaps[i].CreationDate,
+				})
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// CheckInstanceSnapshotConsistency compares the snapshot records of an instance with the snapshot records of its
+// storage volume and the snapshots found on storage, and reports the differences between them.
+func (b *backend) CheckInstanceSnapshotConsistency(inst instance.Instance) (*api.SnapshotConsistencyReport, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	l.Debug("CheckInstanceSnapshotConsistency started")
+	defer l.Debug("CheckInstanceSnapshotConsistency finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	if inst.IsSnapshot() {
+		return nil, errors.New("Instance must not be a snapshot")
+	}
+
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &api.SnapshotConsistencyReport{
+		InstanceSnapshots:      []string{},
+		VolumeSnapshots:        []string{},
+		MissingInstanceRecords: []string{},
+		MissingVolumeRecords:   []string{},
+		MissingOnStorage:       []string{},
+		UnknownOnStorage:       []string{},
+	}
+
+	instSnaps, err := inst.Snapshots()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get instance snapshots: %w", err)
+	}
+
+	for _, instSnap := range instSnaps {
+		_, snapName, _ := api.GetParentAndSnapshotName(instSnap.Name())
+		report.InstanceSnapshots = append(report.InstanceSnapshots, snapName)
+	}
+
+	dbVolSnaps, err := VolumeDBSnapshotsGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbVolSnap := range dbVolSnaps {
+		_, snapName, _ := api.GetParentAndSnapshotName(dbVolSnap.Name)
+		report.VolumeSnapshots = append(report.VolumeSnapshots, snapName)
+	}
+
+	for _, snapName := range report.InstanceSnapshots {
+		if !slices.Contains(report.VolumeSnapshots, snapName) {
+			report.MissingVolumeRecords = append(report.MissingVolumeRecords, snapName)
+		}
+	}
+
+	for _, snapName := range report.VolumeSnapshots {
+		if !slices.Contains(report.InstanceSnapshots, snapName) {
+			report.MissingInstanceRecords = append(report.MissingInstanceRecords, snapName)
+		}
+	}
+
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
+
+	// Drivers that can't list the snapshots on storage only get their records compared.
+	storageSnaps, err := b.driver.VolumeSnapshots(vol, nil)
+	if err != nil && !errors.Is(err, drivers.ErrNotSupported) {
+		return nil, fmt.Errorf("Failed to list snapshots on storage: %w", err)
+	}
+
+	if err == nil {
+		report.StorageSnapshots = storageSnaps
+
+		for _, snapName := range report.InstanceSnapshots {
+			if !slices.Contains(storageSnaps, snapName) {
+				report.MissingOnStorage = append(report.MissingOnStorage, snapName)
+			}
+		}
+
+		for _, snapName := range report.MissingInstanceRecords {
+			if !slices.Contains(storageSnaps, snapName) {
+				report.MissingOnStorage = append(report.MissingOnStorage, snapName)
+			}
+		}
+
+		for _, snapName := range storageSnaps {
+			if !slices.Contains(report.InstanceSnapshots, snapName) && !slices.Contains(report.VolumeSnapshots, snapName) {
+				report.UnknownOnStorage = append(report.UnknownOnStorage, snapName)
+			}
+		}
+	}
+
+	report.Consistent = len(report.MissingInstanceRecords) == 0 && len(report.MissingVolumeRecords) == 0 && len(report.MissingOnStorage) == 0 && len(report.UnknownOnStorage) == 0
+
+	return report, nil
+}
+
+// ExportPoolManifest returns the pool configuration along with the records of all instances, custom volumes,
+// image volumes and buckets stored on the pool on this server, including their snapshots but without any data.
+func (b *backend) ExportPoolManifest(op *operations.Operation) (*api.StoragePoolManifest, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("ExportPoolManifest started")
+	defer l.Debug("ExportPoolManifest finished")
+
+	var dbVolumes []*db.StorageVolume
+	var dbBuckets []*db.StorageBucket
+
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		dbVolumes, err = tx.GetStoragePoolVolumes(ctx, b.ID(), true)
+		if err != nil {
+			return fmt.Errorf("Failed loading storage volumes: %w", err)
+		}
+
+		poolID := b.ID()
+		dbBuckets, err = tx.GetStoragePoolBuckets(ctx, true, db.StorageBucketFilter{PoolID: &poolID})
+		if err != nil {
+			return fmt.Errorf("Failed loading storage buckets: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &api.StoragePoolManifest{
+		Pool:          b.ToAPI(),
+		Instances:     []api.StoragePoolManifestInstance{},
+		CustomVolumes: []api.StoragePoolManifestVolume{},
+		Images:        []api.StorageVolume{},
+		Buckets:       []api.StorageBucket{},
+	}
+
+	for _, dbVol := range dbVolumes {
+		// Snapshots are included with their parent volume.
+		if internalInstance.IsSnapshot(dbVol.Name) {
+			continue
+		}
+
+		switch dbVol.Type {
+		case db.StoragePoolVolumeTypeNameContainer, db.StoragePoolVolumeTypeNameVM:
+			inst, err := instance.LoadByProjectAndName(b.state, dbVol.Project, dbVol.Name)
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading instance %q in project %q: %w", dbVol.Name, dbVol.Project, err)
+			}
+
+			config, err := b.GenerateInstanceBackupConfig(inst, true, false, op)
 			if err != nil {
-				return fmt.Errorf("Failed loading storage pool: %w", err)
+				return nil, fmt.Errorf("Failed generating config for instance %q in project %q: %w", dbVol.Name, dbVol.Project, err)
 			}
 
-			diskConfig, err := diskPool.GenerateCustomVolumeBackupConfig(inst.Project().Name, dev.Config["source"], snapshots, op)
+			entry := api.StoragePoolManifestInstance{
+				Instance:        *config.Container,
+				Snapshots:       make([]api.InstanceSnapshot, 0, len(config.Snapshots)),
+				Volume:          *config.Volume,
+				VolumeSnapshots: make([]api.StorageVolumeSnapshot, 0, len(config.VolumeSnapshots)),
+			}
+
+			for _, snap := range config.Snapshots {
+				entry.Snapshots = append(entry.Snapshots, *snap)
+			}
+
+			for _, volSnap := range config.VolumeSnapshots {
+				entry.VolumeSnapshots = append(entry.VolumeSnapshots, *volSnap)
+			}
+
+			manifest.Instances = append(manifest.Instances, entry)
+
+		case db.StoragePoolVolumeTypeNameCustom:
+			config, err := b.GenerateCustomVolumeBackupConfig(dbVol.Project, dbVol.Name, true, op)
 			if err != nil {
-				return err
+				return nil, fmt.Errorf("Failed generating config for custom volume %q in project %q: %w", dbVol.Name, dbVol.Project, err)
 			}
 
-			poolDB := diskPool.ToAPI()
-			diskConfig.Pool = &poolDB
+			entry := api.StoragePoolManifestVolume{
+				Volume:    *config.Volume,
+				Snapshots: make([]api.StorageVolumeSnapshot, 0, len(config.VolumeSnapshots)),
+			}
 
-			config.DependentVolumes = append(config.DependentVolumes, diskConfig)
+			for _, volSnap := range config.VolumeSnapshots {
+				entry.Snapshots = append(entry.Snapshots, *volSnap)
+			}
 
-			return nil
-		})
+			manifest.CustomVolumes = append(manifest.CustomVolumes, entry)
+
+		case db.StoragePoolVolumeTypeNameImage:
+			manifest.Images = append(manifest.Images, dbVol.StorageVolume)
+		}
+	}
+
+	for _, dbBucket := range dbBuckets {
+		manifest.Buckets = append(manifest.Buckets, dbBucket.StorageBucket)
+	}
+
+	return manifest, nil
+}
+
+// ReconcileAuthorizerVolumes brings the storage volumes of the pool known to the authorizer back in line with the
+// database. Volumes missing from the authorizer are added to it and unknown ones are removed from it.
+func (b *backend) ReconcileAuthorizerVolumes(op *operations.Operation) error {
+	l := b.logger.AddContext(nil)
+	l.Debug("ReconcileAuthorizerVolumes started")
+	defer l.Debug("ReconcileAuthorizerVolumes finished")
+
+	// Get the volumes of all cluster members, as the authorizer is shared by the whole cluster.
+	var dbVolumes []*db.StorageVolume
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		dbVolumes, err = tx.GetStoragePoolVolumes(ctx, b.ID(), false)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("Failed loading storage volumes: %w", err)
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Only populate Container field for non-snapshot instances.
-	if !inst.IsSnapshot() {
-		apiInst, ok := ci.(*api.Instance)
-		if !ok {
-			return nil, fmt.Errorf("Unexpected instance type %T for non-snapshot instance", ci)
+	authVolumes, err := b.state.Authorizer.GetStoragePoolVolumes(b.state.ShutdownCtx, b.Name())
+	if err != nil {
+		return fmt.Errorf("Failed loading storage volumes from authorizer: %w", err)
+	}
+
+	var errs []error
+	dbObjects := make([]auth.Object, 0, len(dbVolumes))
+
+	for _, dbVol := range dbVolumes {
+		// Snapshots aren't recorded with the authorizer.
+		if internalInstance.IsSnapshot(dbVol.Name) {
+			continue
 		}
 
-		config.Container = apiInst
+		var location string
+		if b.state.ServerClustered && dbVol.Type != db.StoragePoolVolumeTypeNameContainer && dbVol.Type != db.StoragePoolVolumeTypeNameVM {
+			location = dbVol.Location
+		}
 
-		if snapshots {
-			snapshots, err := inst.Snapshots()
-			if err != nil {
-				return nil, fmt.Errorf("Failed to get snapshots: %w", err)
-			}
+		object := auth.ObjectStorageVolume(dbVol.Project, b.Name(), dbVol.Type, dbVol.Name, location)
+		dbObjects = append(dbObjects, object)
 
-			config.Snapshots = make([]*api.InstanceSnapshot, 0, len(snapshots))
-			for _, s := range snapshots {
-				si, _, err := s.Render()
-				if err != nil {
-					return nil, err
-				}
+		if slices.Contains(authVolumes, object) {
+			continue
+		}
 
-				config.Snapshots = append(config.Snapshots, si.(*api.InstanceSnapshot))
-			}
+		l.Info("Adding missing storage volume to authorizer", logger.Ctx{"project": dbVol.Project, "type": dbVol.Type, "name": dbVol.Name, "location": location})
 
-			dbVolSnaps, err := VolumeDBSnapshotsGet(b, inst.Project().Name, inst.Name(), volType)
-			if err != nil {
-				return nil, err
-			}
+		err = b.state.Authorizer.AddStoragePoolVolume(b.state.ShutdownCtx, dbVol.Project, b.Name(), dbVol.Type, dbVol.Name, location)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed adding storage volume %q to authorizer: %w", object, err))
+		}
+	}
 
-			if len(snapshots) != len(dbVolSnaps) {
-				return nil, errors.New("Instance snapshot record count doesn't match instance snapshot volume record count")
-			}
+	for _, object := range authVolumes {
+		if slices.Contains(dbObjects, object) {
+			continue
+		}
 
-			config.VolumeSnapshots = make([]*api.StorageVolumeSnapshot, 0, len(dbVolSnaps))
-			for i := range dbVolSnaps {
-				foundInstanceSnapshot := false
-				for _, snap := range snapshots {
-					if snap.Name() == dbVolSnaps[i].Name {
-						foundInstanceSnapshot = true
-						break
-					}
-				}
+		// Elements are the pool, the volume type, the volume name and optionally the location.
+		elements := object.Elements()
 
-				if !foundInstanceSnapshot {
-					return nil, fmt.Errorf("Instance snapshot record missing for %q", dbVolSnaps[i].Name)
-				}
+		var location string
+		if len(elements) > 3 {
+			location = elements[3]
+		}
 
-				_, snapName, _ := api.GetParentAndSnapshotName(dbVolSnaps[i].Name)
+		l.Info("Removing unknown storage volume from authorizer", logger.Ctx{"project": object.Project(), "type": elements[1], "name": elements[2], "location": location})
 
-				config.VolumeSnapshots = append(config.VolumeSnapshots, &api.StorageVolumeSnapshot{
-					StorageVolumeSnapshotPut: api.StorageVolumeSnapshotPut{
-						Description: dbVolSnaps[i].Description,
-						ExpiresAt:   &dbVolSnaps[i].ExpiryDate,
-					},
-					Name:        snapName,
-					Config:      dbVolSnaps[i].Config,
-					ContentType: dbVolSnaps[i].ContentType,
-					CreatedAt:   dbVolSnaps[i].CreationDate,
-				})
-			}
+		err = b.state.Authorizer.DeleteStoragePoolVolume(b.state.ShutdownCtx, object.Project(), b.Name(), elements[1], elements[2], location)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed removing storage volume %q from authorizer: %w", object, err))
 		}
 	}
 
-	return config, nil
+	return errors.Join(errs...)
 }
 
 // UpdateInstanceBackupFile writes the instance's config to the backup.yaml file on the storage device.
@@ -6982,6 +11134,109 @@ func (b *backend) UpdateInstanceBackupFile(inst instance.Instance, snapshots boo
 	return err
 }
 
+// DetectStaleBackupFiles compares the backup.yaml file of each instance of the pool located on this member with the
+// config generated from the database and returns the instances whose file is missing or out of date.
+// The files aren't modified.
+func (b *backend) DetectStaleBackupFiles(op *operations.Operation) ([]StaleBackupFile, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("DetectStaleBackupFiles started")
+	defer l.Debug("DetectStaleBackupFiles finished")
+
+	var dbVolumes []*db.StorageVolume
+
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		dbVolumes, err = tx.GetStoragePoolVolumes(ctx, b.ID(), true)
+		if err != nil {
+			return fmt.Errorf("Failed loading storage volumes: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stale := []StaleBackupFile{}
+
+	for _, dbVol := range dbVolumes {
+		if internalInstance.IsSnapshot(dbVol.Name) {
+			continue
+		}
+
+		if dbVol.Type != db.StoragePoolVolumeTypeNameContainer && dbVol.Type != db.StoragePoolVolumeTypeNameVM {
+			continue
+		}
+
+		inst, err := instance.LoadByProjectAndName(b.state, dbVol.Project, dbVol.Name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading instance %q in project %q: %w", dbVol.Name, dbVol.Project, err)
+		}
+
+		// Volumes on remote pools are listed on all members, only check the instances located on this member
+		// so that volumes in use on other members aren't mounted here.
+		if inst.Location() != b.state.ServerName {
+			continue
+		}
+
+		config, err := b.GenerateInstanceBackupConfig(inst, true, true, op)
+		if err != nil {
+			return nil, fmt.Errorf("Failed generating config for instance %q in project %q: %w", dbVol.Name, dbVol.Project, err)
+		}
+
+		expected, err := yaml.Dump(config, yaml.WithV2Defaults())
+		if err != nil {
+			return nil, err
+		}
+
+		volType, err := InstanceTypeToVolumeType(inst.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		vol := b.GetVolume(volType, InstanceContentType(inst), project.Instance(inst.Project().Name, inst.Name()), dbVol.Config)
+
+		// Only need to activate and mount the VM's config volume.
+		if inst.Type() == instancetype.VM {
+			vol = vol.NewVMBlockFilesystemVolume()
+		}
+
+		backupYamlPath := filepath.Join(vol.MountPath(), "backup.yaml")
+		var backupConf *backupConfig.Config
+
+		// Parse the file directly if it's already accessible to avoid disturbing the mount count.
+		if util.PathExists(backupYamlPath) {
+			backupConf, err = backup.ParseConfigYamlFile(backupYamlPath)
+		} else {
+			err = vol.MountTask(func(_ string, _ *operations.Operation) error {
+				backupConf, err = backup.ParseConfigYamlFile(backupYamlPath)
+				return err
+			}, op)
+		}
+
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("Failed parsing backup file %q: %w", backupYamlPath, err)
+			}
+
+			stale = append(stale, StaleBackupFile{Project: dbVol.Project, Instance: dbVol.Name, Reason: "Backup file is missing"})
+			continue
+		}
+
+		current, err := yaml.Dump(backupConf, yaml.WithV2Defaults())
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(current, expected) {
+			stale = append(stale, StaleBackupFile{Project: dbVol.Project, Instance: dbVol.Name, Reason: "Backup file doesn't match the instance config"})
+		}
+	}
+
+	return stale, nil
+}
+
 // CheckInstanceBackupFileSnapshots compares the snapshots on the storage device to those defined in the backup
 // config supplied and returns an error if they do not match (if deleteMissing argument is false).
 // If deleteMissing argument is true, then any snapshots that exist on the storage device but not in the backup
@@ -7085,9 +11340,67 @@ func (b *backend) CheckInstanceBackupFileSnapshots(backupConf *backupConfig.Conf
 	return existingSnapshots, nil
 }
 
+// volumeDBKey identifies a storage volume database record within a pool.
+type volumeDBKey struct {
+	projectName string
+	volType     int
+	name        string
+}
+
+// volumeDBIndex holds the storage volume database records of a pool (including snapshots), so that the existence
+// of many volumes can be checked without a database query per volume.
+type volumeDBIndex map[volumeDBKey][]*db.StorageVolume
+
+// loadVolumeDBIndex loads the storage volume database records of the pool visible to this member.
+func (b *backend) loadVolumeDBIndex() (volumeDBIndex, error) {
+	var dbVols []*db.StorageVolume
+
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		dbVols, err = tx.GetStoragePoolVolumes(ctx, b.ID(), true)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading storage volume records: %w", err)
+	}
+
+	index := make(volumeDBIndex, len(dbVols))
+	for _, dbVol := range dbVols {
+		volDBType, err := VolumeTypeNameToDBType(dbVol.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		key := volumeDBKey{projectName: dbVol.Project, volType: volDBType, name: dbVol.Name}
+		index[key] = append(index[key], dbVol)
+	}
+
+	return index, nil
+}
+
+// get returns the database record of the volume, or nil if there is none.
+func (index volumeDBIndex) get(projectName string, volName string, volType drivers.VolumeType) (*db.StorageVolume, error) {
+	volDBType, err := VolumeTypeToDBType(volType)
+	if err != nil {
+		return nil, err
+	}
+
+	dbVols := index[volumeDBKey{projectName: projectName, volType: volDBType, name: volName}]
+	if len(dbVols) == 0 {
+		return nil, nil
+	} else if len(dbVols) > 1 {
+		return nil, api.StatusErrorf(http.StatusConflict, "Storage volume found on more than one cluster member. Please target a specific member")
+	}
+
+	return dbVols[0], nil
+}
+
 // ListUnknownVolumes returns volumes that exist on the storage pool but don't have records in the database.
 // Returns the unknown volumes parsed/generated backup config in a slice (keyed on project name).
-func (b *backend) ListUnknownVolumes(op *operations.Operation) (map[string][]*backupConfig.Config, error) {
+// If bestEffort is true, virtual machine disks without a backup file are recovered with a reconstructed config.
+func (b *backend) ListUnknownVolumes(bestEffort bool, op *operations.Operation) (map[string][]*backupConfig.Config, error) {
 	// Get a list of volumes on the storage pool. We only expect to get 1 volume per logical Incus volume.
 	// So for VMs we only expect to get the block volume for a VM and not its filesystem one too. This way we
 	// can operate on the volume using the existing storage pool functions and let the pool then handle the
@@ -7097,6 +11410,12 @@ func (b *backend) ListUnknownVolumes(op *operations.Operation) (map[string][]*ba
 		return nil, fmt.Errorf("Failed getting pool volumes: %w", err)
 	}
 
+	// Load the volume records once rather than querying the database for each volume.
+	dbVols, err := b.loadVolumeDBIndex()
+	if err != nil {
+		return nil, err
+	}
+
 	projectVols := make(map[string][]*backupConfig.Config)
 
 	for _, poolVol := range poolVols {
@@ -7109,13 +11428,13 @@ func (b *backend) ListUnknownVolumes(op *operations.Operation) (map[string][]*ba
 
 		switch volType {
 		case drivers.VolumeTypeVM, drivers.VolumeTypeContainer:
-			err = b.detectUnknownInstanceVolume(&poolVol, projectVols, op)
+			err = b.detectUnknownInstanceVolume(&poolVol, projectVols, dbVols, bestEffort, op)
 			if err != nil {
 				return nil, err
 			}
 
 		case drivers.VolumeTypeCustom:
-			err = b.detectUnknownCustomVolume(&poolVol, projectVols, op)
+			err = b.detectUnknownCustomVolume(&poolVol, projectVols, dbVols, op)
 			if err != nil {
 				return nil, err
 			}
@@ -7131,10 +11450,28 @@ func (b *backend) ListUnknownVolumes(op *operations.Operation) (map[string][]*ba
 	return projectVols, nil
 }
 
+// RecoveryPlanYAML returns the unknown volumes found by ListUnknownVolumes serialized as YAML, keyed on project
+// name. Each entry uses the same format as the backup.yaml file, including any detected filesystem and size, so
+// that the plan can be reviewed before the volumes are imported.
+func (b *backend) RecoveryPlanYAML(op *operations.Operation) ([]byte, error) {
+	projectVols, err := b.ListUnknownVolumes(false, op)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.Marshal(projectVols)
+	if err != nil {
+		return nil, fmt.Errorf("Failed serializing recovery plan: %w", err)
+	}
+
+	return data, nil
+}
+
 // detectUnknownInstanceVolume detects if a volume is unknown and if so attempts to mount the volume and parse the
 // backup stored on it. It then runs a series of consistency checks that compare the contents of the backup file to
 // the state of the volume on disk, and if all checks out, it adds the parsed backup file contents to projectVols.
-func (b *backend) detectUnknownInstanceVolume(vol *drivers.Volume, projectVols map[string][]*backupConfig.Config, op *operations.Operation) error {
+// If bestEffort is true and a virtual machine volume has no backup file, a minimal config is reconstructed instead.
+func (b *backend) detectUnknownInstanceVolume(vol *drivers.Volume, projectVols map[string][]*backupConfig.Config, dbVols volumeDBIndex, bestEffort bool, op *operations.Operation) error {
 	volType := vol.Type()
 
 	projectName, instName := project.InstanceParts(vol.Name())
@@ -7164,8 +11501,8 @@ func (b *backend) detectUnknownInstanceVolume(vol *drivers.Volume, projectVols m
 
 	// Check if any entry for the instance volume already exists in the DB.
 	// This will return no record for any temporary pool structs being used (as ID is -1).
-	volume, err := VolumeDBGet(b, projectName, instName, volType)
-	if err != nil && !response.IsNotFoundError(err) {
+	volume, err := dbVols.get(projectName, instName, volType)
+	if err != nil {
 		return err
 	}
 
@@ -7177,6 +11514,15 @@ func (b *backend) detectUnknownInstanceVolume(vol *drivers.Volume, projectVols m
 		return fmt.Errorf("Instance %q in project %q already has storage DB record", instName, projectName)
 	}
 
+	// Check both halves of a virtual machine volume are present before trying to read its backup file.
+	// In best effort mode, a missing config filesystem volume is handled by reconstructing the config below.
+	if !bestEffort {
+		err = b.checkVMVolumePair(*vol)
+		if err != nil {
+			return fmt.Errorf("Failed checking volumes of instance %q in project %q: %w", instName, projectName, err)
+		}
+	}
+
 	backupYamlPath := filepath.Join(vol.MountPath(), "backup.yaml")
 	var backupConf *backupConfig.Config
 
@@ -7200,7 +11546,14 @@ func (b *backend) detectUnknownInstanceVolume(vol *drivers.Volume, projectVols m
 			return nil
 		}, op)
 		if err != nil {
-			return err
+			if !bestEffort || volType != drivers.VolumeTypeVM || !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			backupConf, err = b.reconstructVMBackupConfig(vol, projectName, instName, op)
+			if err != nil {
+				return fmt.Errorf("Failed reconstructing config of instance %q in project %q from its disk: %w", instName, projectName, err)
+			}
 		}
 	}
 
@@ -7278,29 +11631,95 @@ func (b *backend) detectUnknownInstanceVolume(vol *drivers.Volume, projectVols m
 
 		// Check if any entry for the instance snapshot volume already exists in the DB.
 		// This will return no record for any temporary pool structs being used (as ID is -1).
-		volume, err := VolumeDBGet(b, projectName, fullSnapshotName, volType)
-		if err != nil && !response.IsNotFoundError(err) {
+		volume, err := dbVols.get(projectName, fullSnapshotName, volType)
+		if err != nil {
 			return err
 		} else if volume != nil {
 			return fmt.Errorf("Instance %q snapshot %q in project %q already has storage DB record", instName, snapshot.Name, projectName)
 		}
 	}
 
-	return nil
+	return nil
+}
+
+// reconstructVMBackupConfig generates a minimal backup config for a virtual machine volume that has no backup
+// file, after checking that its disk has a partition table. The generated config must be reviewed by the operator.
+func (b *backend) reconstructVMBackupConfig(vol *drivers.Volume, projectName string, instName string, op *operations.Operation) (*backupConfig.Config, error) {
+	var partitionTable string
+
+	err := vol.MountTask(func(_ string, _ *operations.Operation) error {
+		diskPath, err := b.driver.GetVolumeDiskPath(*vol)
+		if err != nil {
+			return err
+		}
+
+		partitionTable, err = drivers.BlockPartitionTableType(diskPath)
+		if err != nil {
+			return fmt.Errorf("Failed probing partition table of %q: %w", diskPath, err)
+		}
+
+		return nil
+	}, op)
+	if err != nil {
+		return nil, err
+	}
+
+	if partitionTable == "" {
+		return nil, errors.New("Disk has no partition table")
+	}
+
+	if len(b.state.OS.Architectures) == 0 {
+		return nil, errors.New("Failed detecting the host architecture")
+	}
+
+	arch, err := osarch.ArchitectureName(b.state.OS.Architectures[0])
+	if err != nil {
+		return nil, err
+	}
+
+	instConfig := map[string]string{
+		"user.recovery.unconfirmed": "true",
+	}
+
+	// Legacy MBR disks can only be booted through CSM.
+	if partitionTable == "dos" {
+		instConfig["security.csm"] = "true"
+		instConfig["security.secureboot"] = "false"
+	}
+
+	b.logger.Warn("Reconstructed config of virtual machine without backup file, operator confirmation required", logger.Ctx{"project": projectName, "instance": instName, "partitionTable": partitionTable})
+
+	return &backupConfig.Config{
+		Container: &api.Instance{
+			Name:         instName,
+			Project:      projectName,
+			Type:         string(api.InstanceTypeVM),
+			Architecture: arch,
+			Description:  "Recovered from a disk without backup file, configuration requires operator confirmation",
+			Config:       instConfig,
+			Devices:      map[string]map[string]string{},
+		},
+		Volume: &api.StorageVolume{
+			Name:        instName,
+			Type:        db.StoragePoolVolumeTypeNameVM,
+			ContentType: db.StoragePoolVolumeContentTypeNameBlock,
+			Config:      vol.Config(),
+		},
+	}, nil
 }
 
 // detectUnknownCustomVolume detects if a volume is unknown and if so attempts to discover the filesystem of the
 // volume (for filesystem volumes). It then runs a series of consistency checks, and if all checks out, it adds
 // generates a simulated backup config for the custom volume and adds it to projectVols.
-func (b *backend) detectUnknownCustomVolume(vol *drivers.Volume, projectVols map[string][]*backupConfig.Config, op *operations.Operation) error {
+func (b *backend) detectUnknownCustomVolume(vol *drivers.Volume, projectVols map[string][]*backupConfig.Config, dbVols volumeDBIndex, op *operations.Operation) error {
 	volType := vol.Type()
 
 	projectName, volName := project.StorageVolumeParts(vol.Name())
 
 	// Check if any entry for the custom volume already exists in the DB.
 	// This will return no record for any temporary pool structs being used (as ID is -1).
-	volume, err := VolumeDBGet(b, projectName, volName, volType)
-	if err != nil && !response.IsNotFoundError(err) {
+	volume, err := dbVols.get(projectName, volName, volType)
+	if err != nil {
 		return err
 	} else if volume != nil {
 		return nil // Storage record already exists in DB, no recovery needed.
@@ -7404,7 +11823,7 @@ func (b *backend) detectUnknownBuckets(vol *drivers.Volume, projectVols map[stri
 	projectName, bucketName := project.StorageVolumeParts(vol.Name())
 
 	// Check if any entry for the bucket already exists in the DB.
-	bucket, err := BucketDBGet(b, projectName, bucketName, true)
+	bucket, err := BucketDBGet(b, projectName, bucketName, !b.Driver().Info().Remote)
 	if err != nil && !response.IsNotFoundError(err) {
 		return err
 	} else if bucket != nil {
@@ -7493,12 +11912,13 @@ func (b *backend) ImportInstance(inst instance.Instance, poolVol *backupConfig.C
 		}
 
 		// Validate config and create database entry for recovered storage volume.
-		err = VolumeDBCreate(b, inst.Project().Name, inst.Name(), "", volType, false, volumeConfig, creationDate, time.Time{}, contentType, false, true)
+		// The write is queued if the pool is in a maintenance session.
+		err = volumeDBCreate(b, inst.Project().Name, inst.Name(), "", volType, false, volumeConfig, creationDate, time.Time{}, contentType, false, true, true)
 		if err != nil {
 			return nil, err
 		}
 
-		reverter.Add(func() { _ = VolumeDBDelete(b, inst.Project().Name, inst.Name(), volType) })
+		reverter.Add(func() { _ = volumeDBDelete(b, inst.Project().Name, inst.Name(), volType, true) })
 
 		if len(snapshots) > 0 && len(poolVol.VolumeSnapshots) > 0 {
 			// Create storage volume snapshot DB records from the entries in the backup file config.
@@ -7510,12 +11930,12 @@ func (b *backend) ImportInstance(inst instance.Instance, poolVol *backupConfig.C
 				snapVolumeConfig := util.CloneMap(poolVolSnap.Config)
 
 				// Validate config and create database entry for recovered storage volume.
-				err = VolumeDBCreate(b, inst.Project().Name, fullSnapName, poolVolSnap.Description, volType, true, snapVolumeConfig, poolVolSnap.CreatedAt, time.Time{}, contentType, false, true)
+				err = volumeDBCreate(b, inst.Project().Name, fullSnapName, poolVolSnap.Description, volType, true, snapVolumeConfig, poolVolSnap.CreatedAt, time.Time{}, contentType, false, true, true)
 				if err != nil {
 					return nil, err
 				}
 
-				reverter.Add(func() { _ = VolumeDBDelete(b, inst.Project().Name, fullSnapName, volType) })
+				reverter.Add(func() { _ = volumeDBDelete(b, inst.Project().Name, fullSnapName, volType, true) })
 			}
 		} else {
 			b.logger.Warn("Missing volume snapshot info in backup config, using parent volume config")
@@ -7529,14 +11949,28 @@ func (b *backend) ImportInstance(inst instance.Instance, poolVol *backupConfig.C
 
 				// Validate config and create database entry for new storage volume.
 				// Use parent volume config.
-				err = VolumeDBCreate(b, inst.Project().Name, fullSnapName, "", volType, true, volumeConfig, time.Time{}, time.Time{}, contentType, false, true)
+				err = volumeDBCreate(b, inst.Project().Name, fullSnapName, "", volType, true, volumeConfig, time.Time{}, time.Time{}, contentType, false, true, true)
 				if err != nil {
 					return nil, err
 				}
 
-				reverter.Add(func() { _ = VolumeDBDelete(b, inst.Project().Name, fullSnapName, volType) })
+				reverter.Add(func() { _ = volumeDBDelete(b, inst.Project().Name, fullSnapName, volType, true) })
 			}
 		}
+
+		// Record recovered volume with authorizer (deferred if the pool is in a maintenance session).
+		err = b.maintenanceAuthorizerUpdate(func(ctx context.Context) error {
+			return b.state.Authorizer.AddStoragePoolVolume(ctx, inst.Project().Name, b.Name(), volType.Singular(), inst.Name(), "")
+		})
+		if err != nil {
+			logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": inst.Name(), "type": volType, "pool": b.Name(), "project": inst.Project().Name, "error": err})
+		}
+
+		reverter.Add(func() {
+			_ = b.maintenanceAuthorizerUpdate(func(ctx context.Context) error {
+				return b.state.Authorizer.DeleteStoragePoolVolume(ctx, inst.Project().Name, b.Name(), volType.Singular(), inst.Name(), "")
+			})
+		})
 	}
 
 	// Generate the effective root device volume for instance.
@@ -7547,6 +11981,14 @@ func (b *backend) ImportInstance(inst instance.Instance, poolVol *backupConfig.C
 		return nil, err
 	}
 
+	// Check both halves of a virtual machine volume are present where the volume is accessible.
+	if inst.Location() == b.state.ServerName || b.driver.Info().Remote {
+		err = b.checkVMVolumePair(vol)
+		if err != nil {
+			return nil, fmt.Errorf("Failed checking volumes of instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+		}
+	}
+
 	err = vol.EnsureMountPath(false)
 	if err != nil {
 		return nil, err
@@ -7556,6 +11998,12 @@ func (b *backend) ImportInstance(inst instance.Instance, poolVol *backupConfig.C
 	if inst.Location() == b.state.ServerName {
 		l.Debug("Restoring local instance mount status")
 
+		// Mounting reads back the volume records, so write those queued by a maintenance session.
+		err = b.maintenanceFlush()
+		if err != nil {
+			return nil, err
+		}
+
 		if inst.IsRunning() {
 			// If the instance is running then this implies the volume is mounted, but if the Incus
 			// daemon has been restarted since the DB records were removed then there will be no mount
@@ -7762,6 +12210,271 @@ func (b *backend) CreateCustomVolumeFromISO(projectName string, volName string,
 	return nil
 }
 
+// ImportForeignVolume registers a disk created outside of Incus as a storage volume on this pool.
+// If spec.Copy is set the source is copied (and converted if needed) into a new volume, otherwise the volume
+// must already exist on the pool under its Incus storage name and is adopted as is.
+// For instance volumes the instance record must already exist, its volume record and backup file are created here.
+func (b *backend) ImportForeignVolume(spec ForeignVolume, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": spec.Project, "volume": spec.Name, "type": spec.Type, "source": spec.Source, "copy": spec.Copy})
+	l.Debug("ImportForeignVolume started")
+	defer l.Debug("ImportForeignVolume finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	var inst instance.Instance
+	var volStorageName string
+	contentType := spec.ContentType
+	volConfig := map[string]string{}
+
+	switch spec.Type {
+	case drivers.VolumeTypeCustom:
+		if contentType != drivers.ContentTypeFS && contentType != drivers.ContentTypeBlock {
+			return fmt.Errorf("Unsupported content type %q for a foreign volume", contentType)
+		}
+
+		volStorageName = project.StorageVolume(spec.Project, spec.Name)
+	case drivers.VolumeTypeContainer, drivers.VolumeTypeVM:
+		inst, err = instance.LoadByProjectAndName(b.state, spec.Project, spec.Name)
+		if err != nil {
+			return fmt.Errorf("Failed loading instance: %w", err)
+		}
+
+		volType, err := InstanceTypeToVolumeType(inst.Type())
+		if err != nil {
+			return err
+		}
+
+		if volType != spec.Type {
+			return fmt.Errorf("Instance %q doesn't use volumes of type %q", inst.Name(), spec.Type)
+		}
+
+		if contentType == "" {
+			contentType = InstanceContentType(inst)
+		}
+
+		if contentType != InstanceContentType(inst) {
+			return fmt.Errorf("Instance %q doesn't use volumes of content type %q", inst.Name(), contentType)
+		}
+
+		// Adopting a virtual machine would also need its config filesystem volume to be present.
+		if spec.Type == drivers.VolumeTypeVM && !spec.Copy {
+			return errors.New("Virtual machine volumes can only be imported by copying")
+		}
+
+		err = b.applyInstanceRootDiskInitialValues(inst, volConfig)
+		if err != nil {
+			return err
+		}
+
+		volStorageName = project.Instance(spec.Project, spec.Name)
+	default:
+		return fmt.Errorf("Unsupported volume type %q for a foreign volume", spec.Type)
+	}
+
+	if !spec.Copy && spec.Source != "" {
+		return errors.New("A source can only be used when copying the volume")
+	}
+
+	// Validate the source and work out the size needed to hold it.
+	var srcFormat string
+	size := spec.Size
+	if spec.Copy {
+		fi, err := os.Stat(spec.Source)
+		if err != nil {
+			return fmt.Errorf("Failed accessing source: %w", err)
+		}
+
+		if contentType == drivers.ContentTypeFS {
+			if !fi.IsDir() {
+				return fmt.Errorf("Source %q must be a directory for a filesystem volume", spec.Source)
+			}
+
+			if inst != nil && !util.PathExists(filepath.Join(spec.Source, "rootfs")) {
+				return fmt.Errorf("Source %q is missing a rootfs", spec.Source)
+			}
+		} else {
+			if fi.IsDir() {
+				return fmt.Errorf("Source %q must be a disk image or block device for a block volume", spec.Source)
+			}
+
+			srcFormat, err = ForeignVolumeFormat(spec.Source)
+			if err != nil {
+				return err
+			}
+
+			srcSize, err := ForeignVolumeSize(b.state.OS, spec.Source, srcFormat)
+			if err != nil {
+				return err
+			}
+
+			if size == 0 {
+				size = srcSize
+			}
+
+			if size < srcSize {
+				return fmt.Errorf("Volume size %d is smaller than the %s source disk size %d", size, srcFormat, srcSize)
+			}
+		}
+	}
+
+	if size > 0 {
+		volConfig["size"] = fmt.Sprintf("%d", size)
+	}
+
+	// Check whether we are allowed to create volumes.
+	if inst == nil {
+		req := api.StorageVolumesPost{
+			Name: spec.Name,
+			StorageVolumePut: api.StorageVolumePut{
+				Config: volConfig,
+			},
+		}
+
+		err = b.state.DB.Cluster.Transaction(b.state.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return project.AllowVolumeCreation(tx, spec.Project, b.name, req)
+		})
+		if err != nil {
+			return fmt.Errorf("Failed checking volume creation allowed: %w", err)
+		}
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	vol := b.GetVolume(spec.Type, contentType, volStorageName, volConfig)
+
+	volExists, err := b.driver.HasVolume(vol)
+	if err != nil {
+		return err
+	}
+
+	if spec.Copy && volExists {
+		return errors.New("Cannot import volume, already exists on target storage")
+	}
+
+	if !spec.Copy && !volExists {
+		return errors.New("Cannot adopt volume, not found on target storage")
+	}
+
+	creationDate := time.Now()
+	if inst != nil {
+		creationDate = inst.CreationDate()
+	}
+
+	// Validate config and create database entry for the imported storage volume.
+	err = VolumeDBCreate(b, spec.Project, spec.Name, "", vol.Type(), false, vol.Config(), creationDate, time.Time{}, vol.ContentType(), true, spec.Copy)
+	if err != nil {
+		return fmt.Errorf("Failed creating database entry for imported volume: %w", err)
+	}
+
+	reverter.Add(func() { _ = VolumeDBDelete(b, spec.Project, spec.Name, vol.Type()) })
+
+	if inst != nil {
+		err = b.applyInstanceRootDiskOverrides(inst, &vol)
+		if err != nil {
+			return err
+		}
+	}
+
+	if spec.Copy {
+		volFiller := drivers.VolumeFiller{
+			Fill: b.foreignFiller(spec.Source, srcFormat, op),
+		}
+
+		err = b.driver.CreateVolume(vol, &volFiller, op)
+		if err != nil {
+			return fmt.Errorf("Failed creating volume: %w", err)
+		}
+
+		reverter.Add(func() { _ = b.driver.DeleteVolume(vol, op) })
+	} else {
+		err = vol.EnsureMountPath(false)
+		if err != nil {
+			return err
+		}
+
+		// Check the volume is in a format the driver can use as is.
+		err = vol.MountTask(func(mountPath string, op *operations.Operation) error {
+			if vol.ContentType() == drivers.ContentTypeFS {
+				if inst != nil && !util.PathExists(filepath.Join(mountPath, "rootfs")) {
+					return errors.New("Container volume is missing a rootfs")
+				}
+
+				return nil
+			}
+
+			diskPath, err := b.driver.GetVolumeDiskPath(vol)
+			if err != nil {
+				return err
+			}
+
+			format, err := ForeignVolumeFormat(diskPath)
+			if err != nil {
+				return err
+			}
+
+			targetFormat := b.driver.Info().TargetFormat
+			if targetFormat == "" {
+				targetFormat = drivers.BlockVolumeTypeRaw
+			}
+
+			if format != targetFormat {
+				return fmt.Errorf("Volume is in %s format but the storage driver expects %s", format, targetFormat)
+			}
+
+			return nil
+		}, op)
+		if err != nil {
+			return err
+		}
+	}
+
+	var location string
+	if b.state.ServerClustered && !b.Driver().Info().Remote && inst == nil {
+		location = b.state.ServerName
+	}
+
+	// Record new volume with authorizer.
+	err = b.state.Authorizer.AddStoragePoolVolume(b.state.ShutdownCtx, spec.Project, b.Name(), vol.Type().Singular(), spec.Name, location)
+	if err != nil {
+		logger.Error("Failed to add storage volume to authorizer", logger.Ctx{"name": spec.Name, "type": vol.Type(), "pool": b.Name(), "project": spec.Project, "error": err})
+	}
+
+	reverter.Add(func() {
+		_ = b.state.Authorizer.DeleteStoragePoolVolume(b.state.ShutdownCtx, spec.Project, b.Name(), vol.Type().Singular(), spec.Name, location)
+	})
+
+	if inst != nil {
+		err = b.ensureInstanceSymlink(inst.Type(), inst.Project().Name, inst.Name(), vol.MountPath())
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = b.removeInstanceSymlink(inst.Type(), inst.Project().Name, inst.Name()) })
+
+		err = b.UpdateInstanceBackupFile(inst, true, op)
+		if err != nil {
+			return err
+		}
+
+		reverter.Success()
+		return nil
+	}
+
+	eventCtx := logger.Ctx{"type": vol.Type()}
+	if !b.Driver().Info().Remote {
+		eventCtx["location"] = b.state.ServerName
+	}
+
+	b.state.Events.SendLifecycle(spec.Project, lifecycle.StorageVolumeCreated.Event(vol, string(vol.Type()), spec.Project, op, eventCtx))
+
+	reverter.Success()
+	return nil
+}
+
 // CreateCustomVolumeFromBackup creates a custom volume from a backup.
 func (b *backend) CreateCustomVolumeFromBackup(srcBackup backup.Info, srcData io.ReadSeeker, basePrefix string, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": srcBackup.Project, "volume": srcBackup.Name, "snapshots": srcBackup.Snapshots, "optimizedStorage": *srcBackup.OptimizedStorage})
@@ -8019,6 +12732,91 @@ func (b *backend) CreateBucketFromBackup(srcBackup backup.Info, srcData io.ReadS
 	return nil
 }
 
+// ExportBucket writes a bucket's config and keys, and optionally its objects, to a tarball in the backup format.
+func (b *backend) ExportBucket(projectName string, bucketName string, writer io.Writer, includeObjects bool, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "bucket": bucketName, "includeObjects": includeObjects})
+	l.Debug("ExportBucket started")
+	defer l.Debug("ExportBucket finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	if !b.Driver().Info().Buckets {
+		return errors.New("Storage pool does not support buckets")
+	}
+
+	config, err := b.GenerateBucketBackupConfig(projectName, bucketName, op)
+	if err != nil {
+		return fmt.Errorf("Failed generating bucket export config: %w", err)
+	}
+
+	indexInfo := backup.Info{
+		Name:    config.Bucket.Name,
+		Pool:    b.Name(),
+		Backend: b.Driver().Info().Name,
+		Type:    backup.TypeBucket,
+		Config:  config,
+	}
+
+	indexData, err := yaml.Dump(indexInfo, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	indexFileInfo := instancewriter.FileInfo{
+		FileName:    "backup/index.yaml",
+		FileSize:    int64(len(indexData)),
+		FileMode:    0o644,
+		FileModTime: time.Now(),
+	}
+
+	tarWriter := instancewriter.NewInstanceTarWriter(writer, nil)
+
+	err = tarWriter.WriteFileFromReader(bytes.NewReader(indexData), &indexFileInfo)
+	if err != nil {
+		return err
+	}
+
+	if includeObjects {
+		err = b.BackupBucket(projectName, bucketName, tarWriter, op)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tarWriter.Close()
+}
+
+// ImportBucketFromExport recreates a bucket, its keys and any included objects from a tarball made by ExportBucket.
+func (b *backend) ImportBucketFromExport(projectName string, srcData io.ReadSeeker, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName})
+	l.Debug("ImportBucketFromExport started")
+	defer l.Debug("ImportBucketFromExport finished")
+
+	tmpPath, err := os.MkdirTemp("", "incus_bucket_import_*")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.RemoveAll(tmpPath) }()
+
+	bInfo, err := backup.GetInfo(srcData, b.state.OS, tmpPath)
+	if err != nil {
+		return err
+	}
+
+	if bInfo.Type != backup.TypeBucket {
+		return fmt.Errorf("Export is not a bucket export (type %q)", bInfo.Type)
+	}
+
+	bInfo.Project = projectName
+	bInfo.Pool = b.Name()
+
+	return b.CreateBucketFromBackup(*bInfo, srcData, op)
+}
+
 func (b *backend) getFirstReadStorageBucketPoolKey(bucketID int64) (*db.StorageBucketKey, error) {
 	var backupKey *db.StorageBucketKey
 
@@ -9292,7 +14090,7 @@ func (b *backend) qcow2UnpackVolume(vol drivers.Volume, snapshots []string, srcD
 
 	if len(snapshots) > 0 {
 		// Create new snapshots directory.
-		err := drivers.CreateParentSnapshotDirIfMissing(b.driver.Name(), vol.Type(), vol.Name())
+		err := drivers.CreateParentSnapshotDirIfMissing(b.driver.Name(), b.driver.Config(), vol.Type(), vol.Name())
 		if err != nil {
 			return nil, nil, err
 		}
@@ -9517,19 +14315,22 @@ func (b *backend) createDependentVolumesFromBackup(srcBackup backup.Info, srcDat
 			return err
 		}
 
-		// Check if the backup is optimized that the source pool driver matches the target pool driver.
-		if *bInfo.OptimizedStorage && pool.Driver().Info().Name != bInfo.Backend {
-			return fmt.Errorf("Optimized backup storage driver %q differs from the target storage pool driver %q", bInfo.Backend, pool.Driver().Info().Name)
-		}
-
-		// Dump tarball to storage.
 		devKey := fmt.Sprintf("%s/%s", disk.Pool.Name, disk.Volume.Name)
 		devName, ok := devicesMap[devKey]
 		if !ok {
 			return fmt.Errorf("Requested volume %s on pool %s is not attached to the instance", disk.Volume.Name, disk.Pool.Name)
 		}
 
-		err = pool.CreateCustomVolumeFromBackup(bInfo, srcData, filepath.Join(backup.DefaultBackupPrefix, devName), op)
+		prefix := filepath.Join(backup.DefaultBackupPrefix, devName)
+
+		// Fall back to the generic data of optimized backups made by a different driver.
+		err = bInfo.AdaptToDriver(pool.Driver().Info().Name, srcData, b.state.OS, internalUtil.VarPath("backups"), prefix)
+		if err != nil {
+			return err
+		}
+
+		// Dump tarball to storage.
+		err = pool.CreateCustomVolumeFromBackup(bInfo, srcData, prefix, op)
 		if err != nil {
 			return fmt.Errorf("Create custom volume from backup: %w", err)
 		}
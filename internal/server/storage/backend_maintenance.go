@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lxc/incus/v7/internal/server/db"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// maintenanceSession holds the database writes and authorizer updates queued during pool maintenance.
+type maintenanceSession struct {
+	deferAuthorizer bool
+	dbOps           []func(ctx context.Context, tx *db.ClusterTx) error
+	authorizerOps   []func(ctx context.Context) error
+}
+
+var (
+	maintenanceSessions   = make(map[string]*maintenanceSession)
+	maintenanceSessionsMu = sync.Mutex{}
+)
+
+// BeginMaintenanceSession starts a maintenance session on the pool.
+// While the session is active, the volume database records created or deleted by the participating methods
+// (ImportInstance and ImportCustomVolume) are queued and written in a single transaction when the
+// session is committed. If deferAuthorizer is true then authorizer updates are also held until the commit.
+// Records queued during a session aren't visible in the database until it is committed, so normal concurrent
+// operations on the pool should be paused for the duration of the session.
+func (b *backend) BeginMaintenanceSession(deferAuthorizer bool) error {
+	maintenanceSessionsMu.Lock()
+	defer maintenanceSessionsMu.Unlock()
+
+	_, found := maintenanceSessions[b.name]
+	if found {
+		return fmt.Errorf("A maintenance session is already active on storage pool %q", b.name)
+	}
+
+	maintenanceSessions[b.name] = &maintenanceSession{deferAuthorizer: deferAuthorizer}
+	b.logger.Debug("Maintenance session started", logger.Ctx{"deferAuthorizer": deferAuthorizer})
+
+	return nil
+}
+
+// CommitMaintenanceSession ends the maintenance session on the pool, writing the queued database records in a
+// single transaction and then applying any deferred authorizer updates.
+func (b *backend) CommitMaintenanceSession() error {
+	maintenanceSessionsMu.Lock()
+	session, found := maintenanceSessions[b.name]
+	delete(maintenanceSessions, b.name)
+	maintenanceSessionsMu.Unlock()
+
+	if !found {
+		return fmt.Errorf("No maintenance session is active on storage pool %q", b.name)
+	}
+
+	l := b.logger.AddContext(logger.Ctx{"dbOps": len(session.dbOps), "authorizerOps": len(session.authorizerOps)})
+	l.Debug("Maintenance session commit started")
+	defer l.Debug("Maintenance session commit finished")
+
+	err := b.runMaintenanceDBOps(session.dbOps)
+	if err != nil {
+		return err
+	}
+
+	// Authorizer failures are only logged, like when the updates are applied directly.
+	for _, authorizerOp := range session.authorizerOps {
+		err := authorizerOp(b.state.ShutdownCtx)
+		if err != nil {
+			l.Error("Failed applying deferred authorizer update", logger.Ctx{"err": err})
+		}
+	}
+
+	return nil
+}
+
+// AbortMaintenanceSession ends the maintenance session on the pool, discarding any queued operations.
+func (b *backend) AbortMaintenanceSession() {
+	maintenanceSessionsMu.Lock()
+	defer maintenanceSessionsMu.Unlock()
+
+	delete(maintenanceSessions, b.name)
+	b.logger.Debug("Maintenance session aborted")
+}
+
+// maintenanceDBWrite runs the database write directly, or queues it if deferred is true and a maintenance session
+// is active. Only the methods taking part in maintenance sessions defer their writes, so that other operations
+// running on the pool at the same time still see their own records.
+func (b *backend) maintenanceDBWrite(deferred bool, f func(ctx context.Context, tx *db.ClusterTx) error) error {
+	if !deferred {
+		return b.state.DB.Cluster.Transaction(context.TODO(), f)
+	}
+
+	maintenanceSessionsMu.Lock()
+	session := maintenanceSessions[b.name]
+	if session != nil {
+		session.dbOps = append(session.dbOps, f)
+		maintenanceSessionsMu.Unlock()
+		return nil
+	}
+
+	maintenanceSessionsMu.Unlock()
+
+	return b.state.DB.Cluster.Transaction(context.TODO(), f)
+}
+
+// maintenanceFlush writes the database records queued so far by the active maintenance session, if any.
+// It is used before operations that need to read back the queued records.
+func (b *backend) maintenanceFlush() error {
+	maintenanceSessionsMu.Lock()
+	session := maintenanceSessions[b.name]
+	if session == nil {
+		maintenanceSessionsMu.Unlock()
+		return nil
+	}
+
+	dbOps := session.dbOps
+	session.dbOps = nil
+	maintenanceSessionsMu.Unlock()
+
+	return b.runMaintenanceDBOps(dbOps)
+}
+
+// maintenanceAuthorizerUpdate applies the authorizer update directly, or queues it if the active maintenance
+// session defers authorizer updates.
+func (b *backend) maintenanceAuthorizerUpdate(f func(ctx context.Context) error) error {
+	maintenanceSessionsMu.Lock()
+	session := maintenanceSessions[b.name]
+	if session != nil && session.deferAuthorizer {
+		session.authorizerOps = append(session.authorizerOps, f)
+		maintenanceSessionsMu.Unlock()
+		return nil
+	}
+
+	maintenanceSessionsMu.Unlock()
+
+	return f(b.state.ShutdownCtx)
+}
+
+// runMaintenanceDBOps runs the queued database writes in a single transaction.
+func (b *backend) runMaintenanceDBOps(dbOps []func(ctx context.Context, tx *db.ClusterTx) error) error {
+	if len(dbOps) == 0 {
+		return nil
+	}
+
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		for _, dbOp := range dbOps {
+			err := dbOp(ctx, tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed writing maintenance session database records: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"io"
 	"net"
 	"net/url"
@@ -67,6 +68,22 @@ func (b *mockBackend) Driver() drivers.Driver {
 	return b.driver
 }
 
+// Capabilities returns the features supported by the storage pool.
+func (b *mockBackend) Capabilities() Capabilities {
+	info := b.driver.Info()
+
+	return Capabilities{
+		Buckets:           info.Buckets,
+		Snapshots:         info.Snapshots,
+		OptimizedImages:   info.OptimizedImages,
+		Remote:            info.Remote,
+		Shrinkable:        !info.BlockBacking,
+		RunningCopyFreeze: info.RunningCopyFreeze,
+		Delegation:        info.Delegation,
+		Encryption:        info.Encryption,
+	}
+}
+
 // MigrationTypes returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (b *mockBackend) MigrationTypes(contentType drivers.ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []migration.Type {
 	return []migration.Type{
@@ -77,6 +94,11 @@ func (b *mockBackend) MigrationTypes(contentType drivers.ContentType, refresh bo
 	}
 }
 
+// EffectiveMigrationTypes returns a description of the migration type used when copying from srcPool.
+func (b *mockBackend) EffectiveMigrationTypes(srcPool Pool, contentType drivers.ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) (string, error) {
+	return MigrationTypeDescription(b.MigrationTypes(contentType, refresh, copySnapshots, clusterMove, storageMove)[0], refresh), nil
+}
+
 // GetResources returns the resource usage of the storage pool.
 func (b *mockBackend) GetResources() (*api.ResourcesStoragePool, error) {
 	return nil, nil
@@ -88,7 +110,7 @@ func (b *mockBackend) IsUsed() (bool, error) {
 }
 
 // Delete removes the storage pool.
-func (b *mockBackend) Delete(clientType request.ClientType, op *operations.Operation) error {
+func (b *mockBackend) Delete(clientType request.ClientType, force bool, op *operations.Operation) error {
 	return nil
 }
 
@@ -97,6 +119,16 @@ func (b *mockBackend) Update(clientType request.ClientType, newDescription strin
 	return nil
 }
 
+// SetDescription updates the storage pool description.
+func (b *mockBackend) SetDescription(newDesc string, op *operations.Operation) error {
+	return nil
+}
+
+// PreviewUpdate reports what updating the storage pool would do.
+func (b *mockBackend) PreviewUpdate(newDesc string, newConfig map[string]string) (*api.StoragePoolUpdatePreview, error) {
+	return &api.StoragePoolUpdatePreview{}, nil
+}
+
 // Create creates the storage pool.
 func (b *mockBackend) Create(clientType request.ClientType, op *operations.Operation) error {
 	return nil
@@ -112,16 +144,65 @@ func (b *mockBackend) Unmount() (bool, error) {
 	return true, nil
 }
 
+// CleanupSnapshotWritableClones deletes leftover writable snapshot clones.
+func (b *mockBackend) CleanupSnapshotWritableClones() error {
+	return nil
+}
+
+// WaitUntilReady waits for the storage pool to become ready.
+func (b *mockBackend) WaitUntilReady(ctx context.Context) error {
+	return nil
+}
+
+// HealthCheck checks whether the storage pool is usable.
+func (b *mockBackend) HealthCheck(ctx context.Context) (*HealthCheckResult, error) {
+	return &HealthCheckResult{Healthy: true}, nil
+}
+
+// ExportPoolManifest returns the logical contents of the storage pool.
+func (b *mockBackend) ExportPoolManifest(op *operations.Operation) (*api.StoragePoolManifest, error) {
+	return nil, nil
+}
+
+// ReconcileAuthorizerVolumes reconciles the storage volumes known to the authorizer.
+func (b *mockBackend) ReconcileAuthorizerVolumes(op *operations.Operation) error {
+	return nil
+}
+
+// BeginMaintenanceSession starts a maintenance session on the pool.
+func (b *mockBackend) BeginMaintenanceSession(deferAuthorizer bool) error {
+	return nil
+}
+
+// CommitMaintenanceSession ends the maintenance session on the pool and applies the queued operations.
+func (b *mockBackend) CommitMaintenanceSession() error {
+	return nil
+}
+
+// AbortMaintenanceSession ends the maintenance session on the pool and discards the queued operations.
+func (b *mockBackend) AbortMaintenanceSession() {
+}
+
 // ApplyPatch applies a storage pool patch.
 func (b *mockBackend) ApplyPatch(name string) error {
 	return nil
 }
 
+// ListPatches returns the storage patches known to the pool.
+func (b *mockBackend) ListPatches() ([]PatchInfo, error) {
+	return nil, nil
+}
+
 // GetVolume returns a drivers.Volume for the given parameters.
 func (b *mockBackend) GetVolume(volType drivers.VolumeType, contentType drivers.ContentType, volName string, volConfig map[string]string) drivers.Volume {
 	return drivers.Volume{}
 }
 
+// VolumeConfigDefaults returns the effective config a new volume would get.
+func (b *mockBackend) VolumeConfigDefaults(volType drivers.VolumeType, contentType drivers.ContentType) (map[string]string, error) {
+	return nil, nil
+}
+
 // CreateInstance creates an empty instance volume.
 func (b *mockBackend) CreateInstance(inst instance.Instance, op *operations.Operation) error {
 	return nil
@@ -138,7 +219,7 @@ func (b *mockBackend) CreateInstanceFromCopy(inst instance.Instance, src instanc
 }
 
 // CreateInstanceFromImage creates an instance volume from an image.
-func (b *mockBackend) CreateInstanceFromImage(inst instance.Instance, fingerprint string, op *operations.Operation) error {
+func (b *mockBackend) CreateInstanceFromImage(inst instance.Instance, fingerprint string, forceNonOptimized bool, op *operations.Operation) error {
 	return nil
 }
 
@@ -153,7 +234,7 @@ func (b *mockBackend) RenameInstance(inst instance.Instance, newName string, op
 }
 
 // DeleteInstance removes an instance volume.
-func (b *mockBackend) DeleteInstance(inst instance.Instance, op *operations.Operation) error {
+func (b *mockBackend) DeleteInstance(inst instance.Instance, force bool, op *operations.Operation) error {
 	return nil
 }
 
@@ -172,18 +253,33 @@ func (b *mockBackend) GenerateInstanceBackupConfig(inst instance.Instance, snaps
 	return nil, nil
 }
 
+// CheckInstanceSnapshotConsistency reports the differences between the snapshot records and storage of an instance.
+func (b *mockBackend) CheckInstanceSnapshotConsistency(inst instance.Instance) (*api.SnapshotConsistencyReport, error) {
+	return nil, nil
+}
+
 // UpdateInstanceBackupFile updates the backup file for an instance volume.
 func (b *mockBackend) UpdateInstanceBackupFile(inst instance.Instance, snapshot bool, op *operations.Operation) error {
 	return nil
 }
 
+// DetectStaleBackupFiles lists the instances whose backup file is stale.
+func (b *mockBackend) DetectStaleBackupFiles(op *operations.Operation) ([]StaleBackupFile, error) {
+	return nil, nil
+}
+
 // CheckInstanceBackupFileSnapshots checks the snapshots in an instance backup file.
 func (b *mockBackend) CheckInstanceBackupFileSnapshots(backupConf *backupConfig.Config, projectName string, deleteMissing bool, op *operations.Operation) ([]*api.InstanceSnapshot, error) {
 	return nil, nil
 }
 
 // ListUnknownVolumes returns the volumes on the pool that are not known to the database.
-func (b *mockBackend) ListUnknownVolumes(op *operations.Operation) (map[string][]*backupConfig.Config, error) {
+func (b *mockBackend) ListUnknownVolumes(bestEffort bool, op *operations.Operation) (map[string][]*backupConfig.Config, error) {
+	return nil, nil
+}
+
+// RecoveryPlanYAML returns the unknown volumes on the pool serialized as YAML.
+func (b *mockBackend) RecoveryPlanYAML(op *operations.Operation) ([]byte, error) {
 	return nil, nil
 }
 
@@ -197,6 +293,11 @@ func (b *mockBackend) MigrateInstance(inst instance.Instance, conn io.ReadWriteC
 	return nil
 }
 
+// MoveInstanceToMember moves an instance to another cluster member.
+func (b *mockBackend) MoveInstanceToMember(inst instance.Instance, targetMember string, op *operations.Operation) error {
+	return nil
+}
+
 // CleanupInstancePaths removes leftover instance volume paths.
 func (b *mockBackend) CleanupInstancePaths(inst instance.Instance, op *operations.Operation) error {
 	return nil
@@ -207,13 +308,18 @@ func (b *mockBackend) RefreshCustomVolume(projectName string, srcProjectName str
 	return nil
 }
 
+// RefreshOrCreateCustomVolume refreshes a custom volume, creating it if missing.
+func (b *mockBackend) RefreshOrCreateCustomVolume(projectName string, srcProjectName string, volName string, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, onlySnapshots []string, excludeOlder bool, op *operations.Operation) (bool, error) {
+	return false, nil
+}
+
 // RefreshInstance refreshes an instance volume from a source instance.
-func (b *mockBackend) RefreshInstance(inst instance.Instance, src instance.Instance, srcSnapshots []instance.Instance, allowInconsistent bool, op *operations.Operation) error {
+func (b *mockBackend) RefreshInstance(inst instance.Instance, src instance.Instance, srcSnapshots []instance.Instance, allowInconsistent bool, configOnly bool, op *operations.Operation) error {
 	return nil
 }
 
 // BackupInstance creates an instance backup.
-func (b *mockBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, dependentVolumes bool, op *operations.Operation) error {
+func (b *mockBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, optimizedStrict bool, snapshots bool, dependentVolumes bool, checksum bool, op *operations.Operation) error {
 	return nil
 }
 
@@ -222,11 +328,26 @@ func (b *mockBackend) GetInstanceUsage(inst instance.Instance) (*VolumeUsage, er
 	return nil, nil
 }
 
+// GetInstanceIOStats returns the IO counters of an instance volume.
+func (b *mockBackend) GetInstanceIOStats(inst instance.Instance) (*drivers.VolumeIOStats, error) {
+	return nil, nil
+}
+
+// EffectiveInstanceRootSize returns the effective size of an instance root volume.
+func (b *mockBackend) EffectiveInstanceRootSize(inst instance.Instance) (int64, error) {
+	return 0, nil
+}
+
 // SetInstanceQuota sets the size quota on an instance volume.
 func (b *mockBackend) SetInstanceQuota(inst instance.Instance, size string, vmStateSize string, op *operations.Operation) error {
 	return nil
 }
 
+// RepairVMConfigVolume recreates the config filesystem volume of a VM if missing.
+func (b *mockBackend) RepairVMConfigVolume(inst instance.Instance, op *operations.Operation) (bool, error) {
+	return false, nil
+}
+
 // MountInstance mounts an instance volume.
 func (b *mockBackend) MountInstance(inst instance.Instance, op *operations.Operation) (*MountInfo, error) {
 	return &MountInfo{}, nil
@@ -237,8 +358,13 @@ func (b *mockBackend) UnmountInstance(inst instance.Instance, op *operations.Ope
 	return nil
 }
 
+// InstanceMountPath returns the mount path of the instance's volume.
+func (b *mockBackend) InstanceMountPath(inst instance.Instance) (string, error) {
+	return "", nil
+}
+
 // CreateInstanceSnapshot creates a snapshot of an instance volume.
-func (b *mockBackend) CreateInstanceSnapshot(i instance.Instance, src instance.Instance, op *operations.Operation) error {
+func (b *mockBackend) CreateInstanceSnapshot(i instance.Instance, src instance.Instance, force bool, nowait bool, configOverrides map[string]string, op *operations.Operation) error {
 	return nil
 }
 
@@ -258,8 +384,8 @@ func (b *mockBackend) CanRestoreInstanceSnapshot(inst instance.Instance, src ins
 }
 
 // RestoreInstanceSnapshot restores an instance volume from a snapshot.
-func (b *mockBackend) RestoreInstanceSnapshot(inst instance.Instance, src instance.Instance, op *operations.Operation) error {
-	return nil
+func (b *mockBackend) RestoreInstanceSnapshot(inst instance.Instance, src instance.Instance, safetySnapshot bool, op *operations.Operation) (string, error) {
+	return "", nil
 }
 
 // MountInstanceSnapshot mounts an instance volume snapshot.
@@ -272,26 +398,46 @@ func (b *mockBackend) UnmountInstanceSnapshot(inst instance.Instance, op *operat
 	return nil
 }
 
+// MountInstanceSnapshotWritableClone mounts a writable clone of an instance volume snapshot.
+func (b *mockBackend) MountInstanceSnapshotWritableClone(inst instance.Instance, op *operations.Operation) (*MountInfo, func() error, error) {
+	return nil, nil, drivers.ErrNotSupported
+}
+
+// GetInstanceSnapshotUsage returns the disk usage of an instance snapshot volume.
+func (b *mockBackend) GetInstanceSnapshotUsage(inst instance.Instance) (int64, error) {
+	return 0, nil
+}
+
 // UpdateInstanceSnapshot applies new config to an instance volume snapshot.
 func (b *mockBackend) UpdateInstanceSnapshot(inst instance.Instance, newDesc string, newConfig map[string]string, op *operations.Operation) error {
 	return nil
 }
 
 // EnsureImage ensures an image volume exists on the pool.
-func (b *mockBackend) EnsureImage(fingerprint string, op *operations.Operation) error {
-	return nil
+func (b *mockBackend) EnsureImage(fingerprint string, op *operations.Operation) (EnsureImageResult, error) {
+	return EnsureImageNone, nil
 }
 
 // DeleteImage removes an image volume from the pool.
-func (b *mockBackend) DeleteImage(fingerprint string, op *operations.Operation) error {
+func (b *mockBackend) DeleteImage(fingerprint string, force bool, op *operations.Operation) error {
 	return nil
 }
 
+// GarbageCollectImages removes stale image volumes from the pool.
+func (b *mockBackend) GarbageCollectImages(dryRun bool, op *operations.Operation) ([]string, error) {
+	return nil, nil
+}
+
 // UpdateImage applies new config to an image volume.
 func (b *mockBackend) UpdateImage(fingerprint, newDesc string, newConfig map[string]string, op *operations.Operation) error {
 	return nil
 }
 
+// RecomputeImageVolumeSize updates the recorded unpacked size of an image volume.
+func (b *mockBackend) RecomputeImageVolumeSize(fingerprint string, op *operations.Operation) (bool, error) {
+	return false, nil
+}
+
 // CreateBucket creates a storage bucket.
 func (b *mockBackend) CreateBucket(projectName string, bucket api.StorageBucketsPost, op *operations.Operation) error {
 	return nil
@@ -327,24 +473,49 @@ func (b *mockBackend) DeleteBucketKey(projectName string, bucketName string, key
 	return nil
 }
 
+// ExportBucketKeys returns the keys of a storage bucket from the database.
+func (b *mockBackend) ExportBucketKeys(projectName string, bucketName string) ([]api.StorageBucketKey, error) {
+	return nil, nil
+}
+
+// ImportBucketKeysToStorage pushes the keys of a storage bucket to the storage.
+func (b *mockBackend) ImportBucketKeysToStorage(projectName string, bucketName string, op *operations.Operation) error {
+	return nil
+}
+
 // MountLocalBucket mounts the local bucket volume and returns its mount path
 // along with an unmount function that the caller must invoke when finished.
 func (b *mockBackend) MountLocalBucket(projectName string, bucketName string, op *operations.Operation) (string, func() error, error) {
 	return "", func() error { return nil }, nil
 }
 
+// ListActiveBuckets returns the local buckets held mounted by in-flight S3 requests.
+func (b *mockBackend) ListActiveBuckets() ([]ActiveBucket, error) {
+	return nil, nil
+}
+
+// StopBucketProcess forcefully releases the mounts of a local bucket volume.
+func (b *mockBackend) StopBucketProcess(projectName string, bucketName string) error {
+	return nil
+}
+
 // GetBucketURL returns the URL of a storage bucket.
 func (b *mockBackend) GetBucketURL(bucketName string) *url.URL {
 	return nil
 }
 
+// GetBucketUsage returns the usage of a bucket.
+func (b *mockBackend) GetBucketUsage(projectName string, bucketName string) (*VolumeUsage, error) {
+	return nil, nil
+}
+
 // CreateCustomVolume creates an empty custom volume.
-func (b *mockBackend) CreateCustomVolume(projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, op *operations.Operation) error {
+func (b *mockBackend) CreateCustomVolume(projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, template string, op *operations.Operation) error {
 	return nil
 }
 
 // CreateCustomVolumeFromCopy creates a custom volume by copying another volume.
-func (b *mockBackend) CreateCustomVolumeFromCopy(projectName string, srcProjectName string, volName string, desc string, config map[string]string, srcPoolName string, srcVolName string, srcVolOnly bool, op *operations.Operation) error {
+func (b *mockBackend) CreateCustomVolumeFromCopy(projectName string, srcProjectName string, volName string, desc string, config map[string]string, srcPoolName string, srcVolName string, srcVolOnly bool, onlySnapshots []string, targetContentType drivers.ContentType, verify bool, op *operations.Operation) error {
 	return nil
 }
 
@@ -353,11 +524,31 @@ func (b *mockBackend) RenameCustomVolume(projectName string, volName string, new
 	return nil
 }
 
+// ListCustomVolumeBackups returns the backups of a custom volume.
+func (b *mockBackend) ListCustomVolumeBackups(projectName string, volName string) ([]api.StorageVolumeBackup, error) {
+	return nil, nil
+}
+
+// MoveCustomVolumeToProject moves a custom volume to another project.
+func (b *mockBackend) MoveCustomVolumeToProject(projectName string, volName string, targetProjectName string, op *operations.Operation) error {
+	return nil
+}
+
 // UpdateCustomVolume applies new config to a custom volume.
 func (b *mockBackend) UpdateCustomVolume(projectName string, volName string, newDesc string, newConfig map[string]string, op *operations.Operation) error {
 	return nil
 }
 
+// GetVolumeTags returns the tags of a volume.
+func (b *mockBackend) GetVolumeTags(projectName string, volName string, volType drivers.VolumeType) (map[string]string, error) {
+	return nil, nil
+}
+
+// SetVolumeTags replaces the tags of a volume.
+func (b *mockBackend) SetVolumeTags(projectName string, volName string, volType drivers.VolumeType, tags map[string]string, op *operations.Operation) error {
+	return nil
+}
+
 // DeleteCustomVolume removes a custom volume.
 func (b *mockBackend) DeleteCustomVolume(projectName string, volName string, op *operations.Operation) error {
 	return nil
@@ -389,6 +580,16 @@ func (b *mockBackend) GetCustomVolumeUsage(projectName string, volName string) (
 	return nil, nil
 }
 
+// GetCustomVolumeIOStats returns the IO counters of a custom volume.
+func (b *mockBackend) GetCustomVolumeIOStats(projectName string, volName string) (*drivers.VolumeIOStats, error) {
+	return nil, nil
+}
+
+// ExportCustomVolumeData streams the contents of a custom volume.
+func (b *mockBackend) ExportCustomVolumeData(projectName string, volName string, w io.Writer, op *operations.Operation) error {
+	return nil
+}
+
 // MountCustomVolume mounts a custom volume.
 func (b *mockBackend) MountCustomVolume(projectName string, volName string, op *operations.Operation) (*MountInfo, error) {
 	return nil, nil
@@ -405,7 +606,7 @@ func (b *mockBackend) ImportCustomVolume(projectName string, poolVol *backupConf
 }
 
 // CreateCustomVolumeSnapshot creates a snapshot of a custom volume.
-func (b *mockBackend) CreateCustomVolumeSnapshot(projectName string, volName string, newSnapshotName string, expiryDate time.Time, instanceStateful bool, op *operations.Operation) error {
+func (b *mockBackend) CreateCustomVolumeSnapshot(projectName string, volName string, newSnapshotName string, expiryDate time.Time, instanceStateful bool, force bool, auto bool, quiesce bool, op *operations.Operation) error {
 	return nil
 }
 
@@ -419,16 +620,36 @@ func (b *mockBackend) DeleteCustomVolumeSnapshot(projectName string, volName str
 	return nil
 }
 
+// DeleteCustomVolumeSnapshots removes multiple custom volume snapshots.
+func (b *mockBackend) DeleteCustomVolumeSnapshots(projectName string, snapshotNames []string, workers int, op *operations.Operation) error {
+	return nil
+}
+
 // UpdateCustomVolumeSnapshot applies new config to a custom volume snapshot.
 func (b *mockBackend) UpdateCustomVolumeSnapshot(projectName string, volName string, newDesc string, newConfig map[string]string, expiryDate time.Time, op *operations.Operation) error {
 	return nil
 }
 
+// ApplyRetentionPolicy deletes the snapshots of a volume beyond its retention count.
+func (b *mockBackend) ApplyRetentionPolicy(projectName string, volName string, volType drivers.VolumeType, op *operations.Operation) (int, error) {
+	return 0, nil
+}
+
 // RestoreCustomVolume restores a custom volume from a snapshot.
-func (b *mockBackend) RestoreCustomVolume(projectName string, volName string, snapshotName string, op *operations.Operation) error {
+func (b *mockBackend) RestoreCustomVolume(projectName string, volName string, snapshotName string, safetySnapshot bool, op *operations.Operation) (string, error) {
+	return "", nil
+}
+
+// RestoreCustomVolumeFiles restores specific paths of a custom volume from a snapshot.
+func (b *mockBackend) RestoreCustomVolumeFiles(projectName string, volName string, snapshotName string, paths []string, op *operations.Operation) error {
 	return nil
 }
 
+// PromoteCustomVolumeSnapshot creates an independent custom volume from a snapshot.
+func (b *mockBackend) PromoteCustomVolumeSnapshot(projectName string, volName string, snapshotName string, newVolName string, deleteSnapshot bool, op *operations.Operation) (bool, error) {
+	return false, nil
+}
+
 // BackupCustomVolume creates a custom volume backup.
 func (b *mockBackend) BackupCustomVolume(projectName string, volName string, writer instancewriter.InstanceWriter, basePrefix string, optimized bool, snapshots bool, op *operations.Operation) error {
 	return nil
@@ -444,6 +665,11 @@ func (b *mockBackend) CreateCustomVolumeFromISO(projectName string, volName stri
 	return nil
 }
 
+// ImportForeignVolume imports a volume created outside of Incus.
+func (b *mockBackend) ImportForeignVolume(spec ForeignVolume, op *operations.Operation) error {
+	return nil
+}
+
 // GenerateBucketBackupConfig returns the backup config entry for this bucket.
 func (b *mockBackend) GenerateBucketBackupConfig(projectName string, bucketName string, op *operations.Operation) (*backupConfig.Config, error) {
 	return nil, nil
@@ -459,6 +685,16 @@ func (b *mockBackend) CreateBucketFromBackup(srcBackup backup.Info, srcData io.R
 	return nil
 }
 
+// ExportBucket exports a bucket to a tarball.
+func (b *mockBackend) ExportBucket(projectName string, bucketName string, writer io.Writer, includeObjects bool, op *operations.Operation) error {
+	return nil
+}
+
+// ImportBucketFromExport creates a bucket from an exported tarball.
+func (b *mockBackend) ImportBucketFromExport(projectName string, srcData io.ReadSeeker, op *operations.Operation) error {
+	return nil
+}
+
 // GetInstanceNBD returns an NBD connection to the VM's root disk.
 func (b *mockBackend) GetInstanceNBD(inst instance.Instance, writable bool) (net.Conn, func(), error) {
 	return nil, nil, nil
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// snapshotQueueWaiter is a caller waiting for a snapshot lock.
+type snapshotQueueWaiter struct {
+	ch chan struct{}
+	op *operations.Operation
+}
+
+// snapshotQueue holds the state of a snapshot lock and the callers waiting for it, in arrival order.
+type snapshotQueue struct {
+	waiters []*snapshotQueueWaiter
+}
+
+var (
+	snapshotQueues   = make(map[string]*snapshotQueue)
+	snapshotQueuesMu = sync.Mutex{}
+)
+
+// snapshotQueueLock acquires the named snapshot lock, granting it to the waiting callers in arrival order.
+// While waiting, the number of snapshot operations queued ahead is reported in the "queue_position" field of
+// the operation metadata. If nowait is true and the lock is held, ErrBusy is returned instead of waiting.
+func (b *backend) snapshotQueueLock(lockName string, nowait bool, op *operations.Operation) (func(), error) {
+	unlock := func() { releaseSnapshotQueue(lockName) }
+
+	ctx := context.Background()
+
+	if b.db.Config["operation.lock_timeout"] != "" {
+		timeout, err := time.ParseDuration(b.db.Config["operation.lock_timeout"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid operation.lock_timeout: %w", err)
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	snapshotQueuesMu.Lock()
+	queue := snapshotQueues[lockName]
+	if queue == nil {
+		snapshotQueues[lockName] = &snapshotQueue{}
+		snapshotQueuesMu.Unlock()
+		return unlock, nil
+	}
+
+	if nowait {
+		snapshotQueuesMu.Unlock()
+		return nil, ErrBusy
+	}
+
+	waiter := &snapshotQueueWaiter{ch: make(chan struct{}), op: op}
+	queue.waiters = append(queue.waiters, waiter)
+	ahead := len(queue.waiters)
+	snapshotQueuesMu.Unlock()
+
+	b.logger.Debug("Waiting for queued snapshot operations", logger.Ctx{"lock": lockName, "ahead": ahead})
+	setSnapshotQueuePosition(op, ahead)
+
+	// Stop waiting if the operation gets cancelled.
+	opCtx := context.Background()
+	if op != nil {
+		opCtx = op.CancelContext()
+	}
+
+	select {
+	case <-waiter.ch:
+	case <-ctx.Done():
+		if abandonSnapshotQueue(lockName, waiter) {
+			return nil, api.StatusErrorf(http.StatusServiceUnavailable, "Timed out waiting for concurrent operation")
+		}
+	case <-opCtx.Done():
+		if abandonSnapshotQueue(lockName, waiter) {
+			return nil, fmt.Errorf("Cancelled while waiting for concurrent operation: %w", opCtx.Err())
+		}
+	}
+
+	setSnapshotQueuePosition(op, 0)
+
+	return unlock, nil
+}
+
+// releaseSnapshotQueue hands the named snapshot lock over to the next waiting caller, if any.
+func releaseSnapshotQueue(lockName string) {
+	snapshotQueuesMu.Lock()
+	queue := snapshotQueues[lockName]
+	if queue == nil {
+		snapshotQueuesMu.Unlock()
+		return
+	}
+
+	if len(queue.waiters) == 0 {
+		delete(snapshotQueues, lockName)
+		snapshotQueuesMu.Unlock()
+		return
+	}
+
+	next := queue.waiters[0]
+	queue.waiters = slices.Clone(queue.waiters[1:])
+	close(next.ch)
+	updateSnapshotQueuePositions(queue.waiters)
+	snapshotQueuesMu.Unlock()
+}
+
+// abandonSnapshotQueue removes the waiter from the named snapshot queue.
+// It returns false if the lock was handed over to the waiter in the meantime, in which case the waiter holds it.
+func abandonSnapshotQueue(lockName string, waiter *snapshotQueueWaiter) bool {
+	snapshotQueuesMu.Lock()
+	defer snapshotQueuesMu.Unlock()
+
+	queue := snapshotQueues[lockName]
+	if queue == nil {
+		return false
+	}
+
+	i := slices.Index(queue.waiters, waiter)
+	if i < 0 {
+		return false
+	}
+
+	queue.waiters = slices.Delete(queue.waiters, i, i+1)
+	updateSnapshotQueuePositions(queue.waiters)
+
+	return true
+}
+
+// updateSnapshotQueuePositions lets the waiting callers know their position in the queue.
+// It must be called with snapshotQueuesMu held so that concurrent updates don't leave stale positions behind.
+func updateSnapshotQueuePositions(waiters []*snapshotQueueWaiter) {
+	for i, waiter := range waiters {
+		setSnapshotQueuePosition(waiter.op, i+1)
+	}
+}
+
+// setSnapshotQueuePosition records the number of snapshot operations queued ahead in the operation metadata.
+func setSnapshotQueuePosition(op *operations.Operation, ahead int) {
+	if op == nil {
+		return
+	}
+
+	_ = op.ExtendMetadata(map[string]any{"queue_position": ahead})
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// newTestSnapshotQueueBackend returns a backend with what snapshotQueueLock needs.
+func newTestSnapshotQueueBackend() *backend {
+	return &backend{logger: logger.AddContext(logger.Ctx{})}
+}
+
+// waitSnapshotQueueLen waits until the named snapshot queue has the given number of waiters.
+func waitSnapshotQueueLen(t *testing.T, lockName string, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		snapshotQueuesMu.Lock()
+		defer snapshotQueuesMu.Unlock()
+
+		queue := snapshotQueues[lockName]
+		return queue != nil && len(queue.waiters) == n
+	}, 5*time.Second, time.Millisecond)
+}
+
+// Test the snapshot lock is handed over to the waiting callers in arrival order.
+func TestSnapshotQueueLock_FIFO(t *testing.T) {
+	b := newTestSnapshotQueueBackend()
+	lockName := "TestSnapshotQueueLock_FIFO"
+
+	unlock, err := b.snapshotQueueLock(lockName, false, nil)
+	require.NoError(t, err)
+
+	var orderMu sync.Mutex
+	order := []int{}
+
+	wg := sync.WaitGroup{}
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			unlock, err := b.snapshotQueueLock(lockName, false, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			orderMu.Lock()
+			order = append(order, i)
+			orderMu.Unlock()
+
+			unlock()
+		}()
+
+		// Make sure each caller is queued before starting the next one.
+		waitSnapshotQueueLen(t, lockName, i+1)
+	}
+
+	unlock()
+	wg.Wait()
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+
+	// Test the queue is removed once the lock is released by the last caller.
+	snapshotQueuesMu.Lock()
+	assert.NotContains(t, snapshotQueues, lockName)
+	snapshotQueuesMu.Unlock()
+}
+
+// Test nowait returns ErrBusy rather than waiting when the lock is held.
+func TestSnapshotQueueLock_NoWait(t *testing.T) {
+	b := newTestSnapshotQueueBackend()
+	lockName := "TestSnapshotQueueLock_NoWait"
+
+	unlock, err := b.snapshotQueueLock(lockName, true, nil)
+	require.NoError(t, err)
+
+	_, err = b.snapshotQueueLock(lockName, true, nil)
+	assert.ErrorIs(t, err, ErrBusy)
+
+	unlock()
+
+	unlock, err = b.snapshotQueueLock(lockName, true, nil)
+	require.NoError(t, err)
+	unlock()
+}
+
+// Test the queue_position operation metadata follows the callers moving up the queue.
+func TestSnapshotQueueLock_QueuePosition(t *testing.T) {
+	b := newTestSnapshotQueueBackend()
+	lockName := "TestSnapshotQueueLock_QueuePosition"
+
+	newOp := func() *operations.Operation {
+		op, err := operations.OperationCreate(nil, "", operations.OperationClassTask, operationtype.SnapshotCreate, nil, nil, nil, nil, nil, nil)
+		require.NoError(t, err)
+
+		return op
+	}
+
+	queuePosition := func(op *operations.Operation) any {
+		return op.Metadata()["queue_position"]
+	}
+
+	unlock, err := b.snapshotQueueLock(lockName, false, nil)
+	require.NoError(t, err)
+
+	ops := []*operations.Operation{newOp(), newOp(), newOp()}
+	unlocks := make([]chan func(), len(ops))
+
+	for i, op := range ops {
+		unlocks[i] = make(chan func(), 1)
+
+		go func() {
+			unlock, err := b.snapshotQueueLock(lockName, false, op)
+			if !assert.NoError(t, err) {
+				close(unlocks[i])
+				return
+			}
+
+			unlocks[i] <- unlock
+		}()
+
+		waitSnapshotQueueLen(t, lockName, i+1)
+	}
+
+	assert.Equal(t, 1, queuePosition(ops[0]))
+	assert.Equal(t, 2, queuePosition(ops[1]))
+	assert.Equal(t, 3, queuePosition(ops[2]))
+
+	// Hand the lock over to the first caller, the others move up the queue.
+	unlock()
+	unlock = <-unlocks[0]
+	require.NotNil(t, unlock)
+
+	assert.Equal(t, 0, queuePosition(ops[0]))
+	assert.Equal(t, 1, queuePosition(ops[1]))
+	assert.Equal(t, 2, queuePosition(ops[2]))
+
+	// Hand the lock over to the second caller.
+	unlock()
+	unlock = <-unlocks[1]
+	require.NotNil(t, unlock)
+
+	assert.Equal(t, 0, queuePosition(ops[1]))
+	assert.Equal(t, 1, queuePosition(ops[2]))
+
+	unlock()
+	unlock = <-unlocks[2]
+	require.NotNil(t, unlock)
+
+	assert.Equal(t, 0, queuePosition(ops[2]))
+	unlock()
+}
@@ -5,3 +5,11 @@ type S3Credentials struct {
 	AccessKey string `json:"access_key"`
 	SecretKey string `json:"secret_key"`
 }
+
+// S3BucketKey represents a bucket key found on a remote storage driver.
+type S3BucketKey struct {
+	S3Credentials
+
+	Name string
+	Role string
+}
@@ -221,7 +221,7 @@ func (d *btrfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcDat
 	// Load optimized backup header file if specified.
 	var optimizedHeader *BTRFSMetaDataHeader
 	if *srcBackup.OptimizedHeader {
-		optimizedHeader, err = d.loadOptimizedBackupHeader(srcData, GetVolumeMountPath(d.name, vol.volType, ""), basePrefix)
+		optimizedHeader, err = d.loadOptimizedBackupHeader(srcData, GetVolumeMountPath(d.name, d.config, vol.volType, ""), basePrefix)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -247,7 +247,7 @@ func (d *btrfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcDat
 	}
 
 	// Create a temporary directory to unpack the backup into.
-	tmpUnpackDir, err := os.MkdirTemp(GetVolumeMountPath(d.name, vol.volType, ""), "backup.")
+	tmpUnpackDir, err := os.MkdirTemp(GetVolumeMountPath(d.name, d.config, vol.volType, ""), "backup.")
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to create temporary directory %q: %w", tmpUnpackDir, err)
 	}
@@ -345,7 +345,7 @@ func (d *btrfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcDat
 
 	if len(srcBackup.Snapshots) > 0 {
 		// Create new snapshots directory.
-		err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, vol.name)
+		err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, vol.name)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -496,15 +496,15 @@ func (d *btrfs) CreateVolumeFromCopy(vol Volume, srcVol Volume, copySnapshots bo
 	// Copy any snapshots needed.
 	if len(snapshots) > 0 {
 		// Create the parent directory.
-		err = CreateParentSnapshotDirIfMissing(d.name, vol.volType, vol.name)
+		err = CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, vol.name)
 		if err != nil {
 			return err
 		}
 
 		// Copy the snapshots.
 		for _, snapName := range snapshots {
-			srcSnapshot := GetVolumeMountPath(d.name, srcVol.volType, GetSnapshotVolumeName(srcVol.name, snapName))
-			dstSnapshot := GetVolumeMountPath(d.name, vol.volType, GetSnapshotVolumeName(vol.name, snapName))
+			srcSnapshot := GetVolumeMountPath(d.name, d.config, srcVol.volType, GetSnapshotVolumeName(srcVol.name, snapName))
+			dstSnapshot := GetVolumeMountPath(d.name, d.config, vol.volType, GetSnapshotVolumeName(vol.name, snapName))
 
 			cleanup, err := d.snapshotSubvolume(srcSnapshot, dstSnapshot, true)
 			if err != nil {
@@ -701,7 +701,7 @@ func (d *btrfs) createVolumeFromMigrationOptimized(vol Volume, conn io.ReadWrite
 	}
 
 	// Get instances directory (e.g. /var/lib/incus/storage-pools/btrfs/containers).
-	instancesPath := GetVolumeMountPath(d.name, vol.volType, "")
+	instancesPath := GetVolumeMountPath(d.name, d.config, vol.volType, "")
 
 	// Create a temporary directory which will act as the parent directory of the received ro snapshot.
 	tmpVolumesMountPoint, err := os.MkdirTemp(instancesPath, "migration.")
@@ -719,12 +719,12 @@ func (d *btrfs) createVolumeFromMigrationOptimized(vol Volume, conn io.ReadWrite
 	// Handle btrfs send/receive migration.
 	if !volTargetArgs.VolumeOnly && len(volTargetArgs.Snapshots) > 0 {
 		// Create the parent directory.
-		err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, vol.name)
+		err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, vol.name)
 		if err != nil {
 			return err
 		}
 
-		reverter.Add(func() { _ = deleteParentSnapshotDirIfEmpty(d.name, vol.volType, vol.name) })
+		reverter.Add(func() { _ = deleteParentSnapshotDirIfEmpty(d.name, d.config, vol.volType, vol.name) })
 
 		// Transfer the snapshots.
 		for _, snapshot := range volTargetArgs.Snapshots {
@@ -833,9 +833,9 @@ func (d *btrfs) RefreshVolume(vol Volume, srcVol Volume, srcSnapshots []Volume,
 	transfer := func(src Volume, target Volume, origin Volume) error {
 		var sender *exec.Cmd
 
-		srcSubvolPath := filepath.Join(GetPoolMountPath(src.pool), fmt.Sprintf("%s-snapshots/%s", src.volType, src.name))
-		targetSubvolPath := filepath.Join(GetPoolMountPath(target.pool), fmt.Sprintf("%s-snapshots/%s", target.volType, target.name))
-		originSubvolPath := filepath.Join(GetPoolMountPath(origin.pool), fmt.Sprintf("%s-snapshots/%s", origin.volType, origin.name))
+		srcSubvolPath := GetVolumeMountPath(src.pool, src.poolConfig, src.volType, src.name)
+		targetSubvolPath := GetVolumeSnapshotDir(target.pool, target.poolConfig, target.volType, target.name)
+		originSubvolPath := GetVolumeMountPath(origin.pool, origin.poolConfig, origin.volType, origin.name)
 
 		receiver := exec.Command("btrfs", "receive", targetSubvolPath)
 		sender = exec.Command("btrfs", "send", "-p", originSubvolPath, srcSubvolPath)
@@ -995,7 +995,7 @@ func (d *btrfs) DeleteVolume(vol Volume, op *operations.Operation) error {
 	}
 
 	// If the volume doesn't exist, then nothing more to do.
-	volPath := GetVolumeMountPath(d.name, vol.volType, volName)
+	volPath := GetVolumeMountPath(d.name, d.config, vol.volType, volName)
 	if !util.PathExists(volPath) {
 		return nil
 	}
@@ -1008,7 +1008,7 @@ func (d *btrfs) DeleteVolume(vol Volume, op *operations.Operation) error {
 
 	// Although the volume snapshot directory should already be removed, lets remove it here
 	// to just in case the top-level directory is left.
-	err = deleteParentSnapshotDirIfEmpty(d.name, vol.volType, volName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, vol.volType, volName)
 	if err != nil {
 		return err
 	}
@@ -1111,6 +1111,30 @@ func (d *btrfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}} [^*]
 
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -1655,7 +1679,7 @@ func (d *btrfs) migrateVolumeOptimized(vol Volume, conn io.ReadWriteCloser, volS
 	}
 
 	// Get instances directory (e.g. /var/lib/incus/storage-pools/btrfs/containers).
-	instancesPath := GetVolumeMountPath(d.name, vol.volType, "")
+	instancesPath := GetVolumeMountPath(d.name, d.config, vol.volType, "")
 
 	// Create a temporary directory which will act as the parent directory of the read-only snapshot.
 	tmpVolumesMountPoint, err := os.MkdirTemp(instancesPath, "migration.")
@@ -1895,7 +1919,7 @@ func (d *btrfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, b
 
 	// Make a temporary copy of the instance.
 	sourceVolume := vol.MountPath()
-	instancesPath := GetVolumeMountPath(d.name, vol.volType, "")
+	instancesPath := GetVolumeMountPath(d.name, d.config, vol.volType, "")
 
 	tmpInstanceMntPoint, err := os.MkdirTemp(instancesPath, "backup.")
 	if err != nil {
@@ -1954,11 +1978,11 @@ func (d *btrfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, b
 // CreateVolumeSnapshot creates a snapshot of a volume.
 func (d *btrfs) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) error {
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
-	srcPath := GetVolumeMountPath(d.name, snapVol.volType, parentName)
+	srcPath := GetVolumeMountPath(d.name, d.config, snapVol.volType, parentName)
 	snapPath := snapVol.MountPath()
 
 	// Create the parent directory.
-	err := CreateParentSnapshotDirIfMissing(d.name, snapVol.volType, parentName)
+	err := CreateParentSnapshotDirIfMissing(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -2013,7 +2037,7 @@ func (d *btrfs) DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation) e
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
-	err = deleteParentSnapshotDirIfEmpty(d.name, snapVol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -2086,7 +2110,7 @@ func (d *btrfs) volumeSnapshotsSorted(vol Volume, op *operations.Operation) ([]s
 
 	var snapshotNames []string
 
-	snapshotPrefix := fmt.Sprintf("%s-snapshots/%s/", vol.volType, vol.name)
+	snapshotPrefix := strings.TrimPrefix(GetVolumeSnapshotDir(vol.pool, vol.poolConfig, vol.volType, vol.name), GetPoolMountPath(vol.pool)+"/") + "/"
 	scanner := bufio.NewScanner(&stdout)
 
 	for scanner.Scan() {
@@ -2112,7 +2136,7 @@ func (d *btrfs) volumeSnapshotsSorted(vol Volume, op *operations.Operation) ([]s
 }
 
 // RestoreVolume restores a volume from a snapshot.
-func (d *btrfs) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *btrfs) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	reverter := revert.New()
 	defer reverter.Fail()
 
@@ -486,7 +486,7 @@ func (d *ceph) CreateVolumeFromCopy(vol Volume, srcVol Volume, copySnapshots boo
 	lastSnap := ""
 
 	if len(snapshots) > 0 {
-		err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, vol.name)
+		err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, vol.name)
 		if err != nil {
 			return err
 		}
@@ -591,7 +591,7 @@ func (d *ceph) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser, vo
 	// Handle rbd migration.
 	if len(volTargetArgs.Snapshots) > 0 {
 		// Create the parent directory.
-		err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, vol.name)
+		err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, vol.name)
 		if err != nil {
 			return err
 		}
@@ -874,6 +874,15 @@ func (d *ceph) commonVolumeRules() map[string]func(value string) error {
 		//  shortdesc: Mount options for block-backed file system volumes
 		"block.mount_options": validate.IsAny,
 
+		// gendoc:generate(entity=storage_volume_ceph, group=common, key=mount.options)
+		// Comma-separated mount options added to the ones used by default, for example `noatime`. Changes take effect the next time the volume is mounted.
+		// ---
+		//  type: string
+		//  condition: block-based custom volume with content type `filesystem`
+		//  default: same as `volume.mount.options`
+		//  shortdesc: Additional mount options for the volume
+		"mount.options": validate.Optional(validateMountOptions),
+
 		// gendoc:generate(entity=storage_volume_ceph, group=common, key=block.create_options)
 		//
 		// ---
@@ -967,6 +976,30 @@ func (d *ceph) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}} [^*]
 
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_ceph, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -986,6 +1019,11 @@ func (d *ceph) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 		delete(commonRules, "block.mount_options")
 	}
 
+	// mount.options is only relevant for custom filesystem volumes.
+	if vol.volType != VolumeTypeCustom || vol.contentType != ContentTypeFS {
+		delete(commonRules, "mount.options")
+	}
+
 	return d.validateVolume(vol, commonRules, removeUnknownKeys)
 }
 
@@ -1146,7 +1184,7 @@ func (d *ceph) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, o
 		fsType := vol.ConfigBlockFilesystem()
 
 		if sizeBytes < oldSizeBytes {
-			if !filesystemTypeCanBeShrunk(fsType) {
+			if !FilesystemTypeCanBeShrunk(fsType) {
 				return fmt.Errorf("Filesystem %q cannot be shrunk: %w", fsType, ErrCannotBeShrunk)
 			}
 
@@ -1213,7 +1251,7 @@ func (d *ceph) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, o
 
 // GetVolumeDiskPath returns the location of a root disk block device.
 func (d *ceph) GetVolumeDiskPath(vol Volume) (string, error) {
-	if vol.IsVMBlock() || (vol.volType == VolumeTypeCustom && IsContentBlock(vol.contentType)) {
+	if vol.IsVMBlock() || (vol.volType == VolumeTypeCustom && IsContentBlock(vol.contentType)) || (vol.volType == VolumeTypeContainer && vol.IsBlockBacked()) {
 		_, devPath, err := d.getRBDMappedDevPath(vol, false)
 		return devPath, err
 	}
@@ -1221,6 +1259,25 @@ func (d *ceph) GetVolumeDiskPath(vol Volume) (string, error) {
 	return "", ErrNotSupported
 }
 
+// GetVolumeClones returns the names of the RBD volumes cloned from the read-only snapshot of the volume.
+func (d *ceph) GetVolumeClones(vol Volume) ([]string, error) {
+	hasReadonlySnapshot, err := d.hasVolume(d.getRBDVolumeName(vol, "readonly", false))
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasReadonlySnapshot {
+		return []string{}, nil
+	}
+
+	clones, err := d.rbdListSnapshotClones(vol, "readonly")
+	if err != nil && !response.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	return clones, nil
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *ceph) ListVolumes() ([]Volume, error) {
 	vols := make(map[string]Volume)
@@ -1627,7 +1684,7 @@ func (d *ceph) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) er
 	defer reverter.Fail()
 
 	parentName, snapshotOnlyName, _ := api.GetParentAndSnapshotName(snapVol.name)
-	sourcePath := GetVolumeMountPath(d.name, snapVol.volType, parentName)
+	sourcePath := GetVolumeMountPath(d.name, d.config, snapVol.volType, parentName)
 	snapshotName := fmt.Sprintf("snapshot_%s", snapshotOnlyName)
 
 	if linux.IsMountPoint(sourcePath) {
@@ -1642,7 +1699,7 @@ func (d *ceph) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) er
 	}
 
 	// Create the parent directory.
-	err := CreateParentSnapshotDirIfMissing(d.name, snapVol.volType, parentName)
+	err := CreateParentSnapshotDirIfMissing(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -1716,7 +1773,7 @@ func (d *ceph) DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation) er
 	}
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
-	err = deleteParentSnapshotDirIfEmpty(d.name, snapVol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -1937,7 +1994,7 @@ func (d *ceph) VolumeSnapshots(vol Volume, op *operations.Operation) ([]string,
 }
 
 // RestoreVolume restores a volume from a snapshot.
-func (d *ceph) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *ceph) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	ourUnmount, err := d.UnmountVolume(vol, false, op)
 	if err != nil {
 		return err
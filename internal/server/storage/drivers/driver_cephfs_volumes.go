@@ -254,7 +254,7 @@ func (d *cephfs) DeleteVolume(vol Volume, op *operations.Operation) error {
 		return errors.New("Cannot remove a volume that has snapshots")
 	}
 
-	volPath := GetVolumeMountPath(d.name, vol.volType, vol.name)
+	volPath := GetVolumeMountPath(d.name, d.config, vol.volType, vol.name)
 
 	// If the volume doesn't exist, then nothing more to do.
 	if !util.PathExists(volPath) {
@@ -269,7 +269,7 @@ func (d *cephfs) DeleteVolume(vol Volume, op *operations.Operation) error {
 
 	// Although the volume snapshot directory should already be removed, lets remove it here
 	// to just in case the top-level directory is left.
-	snapshotDir := GetVolumeSnapshotDir(d.name, vol.volType, vol.name)
+	snapshotDir := GetVolumeSnapshotDir(d.name, d.config, vol.volType, vol.name)
 
 	err = os.RemoveAll(snapshotDir)
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
@@ -366,6 +366,30 @@ func (d *cephfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}} [^*]
 
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -397,7 +421,7 @@ func (d *cephfs) GetVolumeUsage(vol Volume) (int64, error) {
 		return -1, ErrNotSupported
 	}
 
-	out, err := subprocess.RunCommand("getfattr", "-n", "ceph.quota.max_bytes", "--only-values", GetVolumeMountPath(d.name, vol.volType, vol.name))
+	out, err := subprocess.RunCommand("getfattr", "-n", "ceph.quota.max_bytes", "--only-values", GetVolumeMountPath(d.name, d.config, vol.volType, vol.name))
 	if err != nil {
 		return -1, err
 	}
@@ -422,7 +446,7 @@ func (d *cephfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool,
 		return err
 	}
 
-	_, err = subprocess.RunCommand("setfattr", "-n", "ceph.quota.max_bytes", "-v", fmt.Sprintf("%d", sizeBytes), GetVolumeMountPath(d.name, vol.volType, vol.name))
+	_, err = subprocess.RunCommand("setfattr", "-n", "ceph.quota.max_bytes", "-v", fmt.Sprintf("%d", sizeBytes), GetVolumeMountPath(d.name, d.config, vol.volType, vol.name))
 	return err
 }
 
@@ -471,7 +495,7 @@ func (d *cephfs) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Ope
 // RenameVolume renames the volume and all related filesystem entries.
 func (d *cephfs) RenameVolume(vol Volume, newVolName string, op *operations.Operation) error {
 	// Create the parent directory.
-	err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, newVolName)
+	err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, newVolName)
 	if err != nil {
 		return err
 	}
@@ -496,16 +520,21 @@ func (d *cephfs) RenameVolume(vol Volume, newVolName string, op *operations.Oper
 
 		// Remove the new snapshot directory if we are reverting.
 		if len(revertPaths) > 0 {
-			snapshotDir := GetVolumeSnapshotDir(d.name, vol.volType, newVolName)
+			snapshotDir := GetVolumeSnapshotDir(d.name, d.config, vol.volType, newVolName)
 			_ = os.RemoveAll(snapshotDir)
 		}
 	}()
 
 	// Rename the snapshot directory first.
-	srcSnapshotDir := GetVolumeSnapshotDir(d.name, vol.volType, vol.name)
+	srcSnapshotDir := GetVolumeSnapshotDir(d.name, d.config, vol.volType, vol.name)
 
 	if util.PathExists(srcSnapshotDir) {
-		targetSnapshotDir := GetVolumeSnapshotDir(d.name, vol.volType, newVolName)
+		targetSnapshotDir := GetVolumeSnapshotDir(d.name, d.config, vol.volType, newVolName)
+
+		err = ensureSnapshotShardDir(d.name, d.config, vol.volType, newVolName)
+		if err != nil {
+			return err
+		}
 
 		err = os.Rename(srcSnapshotDir, targetSnapshotDir)
 		if err != nil {
@@ -524,16 +553,16 @@ func (d *cephfs) RenameVolume(vol Volume, newVolName string, op *operations.Oper
 		return err
 	}
 
-	sourcePath := GetVolumeMountPath(d.name, vol.volType, newVolName)
-	targetPath := GetVolumeMountPath(d.name, vol.volType, newVolName)
+	sourcePath := GetVolumeMountPath(d.name, d.config, vol.volType, newVolName)
+	targetPath := GetVolumeMountPath(d.name, d.config, vol.volType, newVolName)
 
 	for _, snapshot := range snapshots {
 		// Figure out the snapshot paths.
 		_, snapName, _ := api.GetParentAndSnapshotName(snapshot.name)
 		oldCephSnapPath := filepath.Join(sourcePath, ".snap", snapName)
 		newCephSnapPath := filepath.Join(targetPath, ".snap", snapName)
-		oldPath := GetVolumeMountPath(d.name, vol.volType, GetSnapshotVolumeName(vol.name, snapName))
-		newPath := GetVolumeMountPath(d.name, vol.volType, GetSnapshotVolumeName(newVolName, snapName))
+		oldPath := GetVolumeMountPath(d.name, d.config, vol.volType, GetSnapshotVolumeName(vol.name, snapName))
+		newPath := GetVolumeMountPath(d.name, d.config, vol.volType, GetSnapshotVolumeName(newVolName, snapName))
 
 		// Update the symlink.
 		err = os.Symlink(newCephSnapPath, newPath)
@@ -548,8 +577,8 @@ func (d *cephfs) RenameVolume(vol Volume, newVolName string, op *operations.Oper
 		})
 	}
 
-	oldPath := GetVolumeMountPath(d.name, vol.volType, vol.name)
-	newPath := GetVolumeMountPath(d.name, vol.volType, newVolName)
+	oldPath := GetVolumeMountPath(d.name, d.config, vol.volType, vol.name)
+	newPath := GetVolumeMountPath(d.name, d.config, vol.volType, newVolName)
 	err = os.Rename(oldPath, newPath)
 	if err != nil {
 		return fmt.Errorf("Failed to rename '%s' to '%s': %w", oldPath, newPath, err)
@@ -560,6 +589,12 @@ func (d *cephfs) RenameVolume(vol Volume, newVolName string, op *operations.Oper
 		newPath: newPath,
 	})
 
+	// Remove the old snapshot shard directory if no longer used.
+	err = DeleteSnapshotShardDirIfEmpty(d.name, d.config, vol.volType, vol.name)
+	if err != nil {
+		return err
+	}
+
 	revertPaths = nil
 	return nil
 }
@@ -579,7 +614,7 @@ func (d *cephfs) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation)
 	parentName, snapName, _ := api.GetParentAndSnapshotName(snapVol.name)
 
 	// Create the snapshot.
-	sourcePath := GetVolumeMountPath(d.name, snapVol.volType, parentName)
+	sourcePath := GetVolumeMountPath(d.name, d.config, snapVol.volType, parentName)
 	cephSnapPath := filepath.Join(sourcePath, ".snap", snapName)
 
 	err := os.Mkdir(cephSnapPath, 0o711)
@@ -588,7 +623,7 @@ func (d *cephfs) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation)
 	}
 
 	// Create the parent directory.
-	err = CreateParentSnapshotDirIfMissing(d.name, snapVol.volType, parentName)
+	err = CreateParentSnapshotDirIfMissing(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -608,7 +643,7 @@ func (d *cephfs) DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation)
 	parentName, snapName, _ := api.GetParentAndSnapshotName(snapVol.name)
 
 	// Delete the snapshot itself.
-	sourcePath := GetVolumeMountPath(d.name, snapVol.volType, parentName)
+	sourcePath := GetVolumeMountPath(d.name, d.config, snapVol.volType, parentName)
 	cephSnapPath := filepath.Join(sourcePath, ".snap", snapName)
 
 	err := os.Remove(cephSnapPath)
@@ -663,8 +698,8 @@ func (d *cephfs) VolumeSnapshots(vol Volume, op *operations.Operation) ([]string
 }
 
 // RestoreVolume resets a volume to its snapshotted state.
-func (d *cephfs) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
-	sourcePath := GetVolumeMountPath(d.name, vol.volType, vol.name)
+func (d *cephfs) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
+	sourcePath := GetVolumeMountPath(d.name, d.config, vol.volType, vol.name)
 	cephSnapPath := filepath.Join(sourcePath, ".snap", snapshotName)
 
 	// Restore using rsync.
@@ -680,7 +715,7 @@ func (d *cephfs) RestoreVolume(vol Volume, snapshotName string, op *operations.O
 // RenameVolumeSnapshot renames a snapshot.
 func (d *cephfs) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	parentName, snapName, _ := api.GetParentAndSnapshotName(snapVol.name)
-	sourcePath := GetVolumeMountPath(d.name, snapVol.volType, parentName)
+	sourcePath := GetVolumeMountPath(d.name, d.config, snapVol.volType, parentName)
 	oldCephSnapPath := filepath.Join(sourcePath, ".snap", snapName)
 	newCephSnapPath := filepath.Join(sourcePath, ".snap", newSnapshotName)
 
@@ -696,7 +731,7 @@ func (d *cephfs) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op
 		return fmt.Errorf("Failed to remove '%s': %w", oldPath, err)
 	}
 
-	newPath := GetVolumeMountPath(d.name, snapVol.volType, GetSnapshotVolumeName(parentName, newSnapshotName))
+	newPath := GetVolumeMountPath(d.name, d.config, snapVol.volType, GetSnapshotVolumeName(parentName, newSnapshotName))
 	err = os.Symlink(newCephSnapPath, newPath)
 	if err != nil {
 		return fmt.Errorf("Failed to symlink '%s' to '%s': %w", newCephSnapPath, newPath, err)
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -214,6 +215,19 @@ func (d *cephobject) UpdateBucket(bucket Volume, changedConfig map[string]string
 	return nil
 }
 
+// GetBucketUsage returns the number of objects and the disk space used by the bucket.
+func (d *cephobject) GetBucketUsage(bucket Volume) (int64, int64, error) {
+	_, bucketName := project.StorageVolumeParts(bucket.name)
+	storageBucketName := d.radosgwBucketName(bucketName)
+
+	objects, size, err := d.radosgwadminBucketStats(context.TODO(), storageBucketName)
+	if err != nil {
+		return -1, -1, fmt.Errorf("Failed getting bucket usage: %w", err)
+	}
+
+	return objects, size, nil
+}
+
 // bucketKeyRadosgwAccessRole returns the radosgw access setting for the specified role name.
 func (d *cephobject) bucketKeyRadosgwAccessRole(roleName string) (string, error) {
 	switch roleName {
@@ -304,6 +318,83 @@ func (d *cephobject) DeleteBucketKey(bucket Volume, keyName string, op *operatio
 	return nil
 }
 
+// ListBuckets returns the names of the buckets found on the storage that were created by Incus.
+// Those buckets use the pool's bucket name prefix and are owned by the bucket user of the same name.
+func (d *cephobject) ListBuckets() ([]string, error) {
+	bucketOwners, err := d.radosgwadminBucketOwners(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing buckets: %w", err)
+	}
+
+	bucketNames := []string{}
+	for storageBucketName, owner := range bucketOwners {
+		bucketName, found := strings.CutPrefix(storageBucketName, d.config["cephobject.bucket.name_prefix"])
+		if !found || bucketName == "" {
+			continue
+		}
+
+		// Skip buckets that weren't created by Incus, even if they happen to match the prefix.
+		if owner != storageBucketName {
+			continue
+		}
+
+		bucketNames = append(bucketNames, bucketName)
+	}
+
+	return bucketNames, nil
+}
+
+// ListBucketKeys returns the keys of a bucket, which are the sub users of the bucket user.
+func (d *cephobject) ListBucketKeys(bucket Volume) ([]S3BucketKey, error) {
+	_, bucketName := project.StorageVolumeParts(bucket.name)
+	storageBucketName := d.radosgwBucketName(bucketName)
+
+	_, bucketSubUsers, err := d.radosgwadminGetUser(context.TODO(), storageBucketName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting bucket user: %w", err)
+	}
+
+	permissions, err := d.radosgwadminGetSubUserPermissions(context.TODO(), storageBucketName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting bucket key permissions: %w", err)
+	}
+
+	keys := make([]S3BucketKey, 0, len(bucketSubUsers))
+	for keyName, creds := range bucketSubUsers {
+		// Map the access granted by bucketKeyRadosgwAccessRole back to the key role.
+		var roleName string
+
+		switch permissions[keyName] {
+		case "read":
+			roleName = "read-only"
+		case "full-control":
+			roleName = "admin"
+		default:
+			return nil, fmt.Errorf("Bucket key %q has unsupported permissions %q", keyName, permissions[keyName])
+		}
+
+		keys = append(keys, S3BucketKey{S3Credentials: creds, Name: keyName, Role: roleName})
+	}
+
+	return keys, nil
+}
+
+// ListVolumes returns the buckets found on the storage as bucket volumes.
+// Buckets on the storage don't record their project, so they are returned as part of the default project.
+func (d *cephobject) ListVolumes() ([]Volume, error) {
+	bucketNames, err := d.ListBuckets()
+	if err != nil {
+		return nil, err
+	}
+
+	vols := make([]Volume, 0, len(bucketNames))
+	for _, bucketName := range bucketNames {
+		vols = append(vols, NewVolume(d, d.name, VolumeTypeBucket, ContentTypeFS, project.StorageVolume(api.ProjectDefaultName, bucketName), nil, d.config))
+	}
+
+	return vols, nil
+}
+
 // GetBucketURL returns the URL of the specified bucket.
 func (d *cephobject) GetBucketURL(bucketName string) *url.URL {
 	u, err := url.ParseRequestURI(d.config["cephobject.radosgw.endpoint"])
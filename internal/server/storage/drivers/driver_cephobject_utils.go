@@ -93,6 +93,34 @@ func (d *cephobject) radosgwadminGetUser(ctx context.Context, user string) (*S3C
 	return userKey, subUsers, nil
 }
 
+// radosgwadminGetSubUserPermissions returns the permissions of the sub users of a radosgw user, keyed on the
+// sub user name without the main user prefix.
+func (d *cephobject) radosgwadminGetSubUserPermissions(ctx context.Context, user string) (map[string]string, error) {
+	out, err := d.radosgwadmin(ctx, "user", "info", "--uid", user)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting user %q info: %w", user, err)
+	}
+
+	resp := struct {
+		SubUsers []struct {
+			ID          string `json:"id"`
+			Permissions string `json:"permissions"`
+		} `json:"subusers"`
+	}{}
+
+	err = json.Unmarshal([]byte(out), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[string]string, len(resp.SubUsers))
+	for _, subUser := range resp.SubUsers {
+		permissions[strings.TrimPrefix(subUser.ID, fmt.Sprintf("%s:", user))] = subUser.Permissions
+	}
+
+	return permissions, nil
+}
+
 // radosgwadminUserAdd creates a radosgw user and return generated credentials.
 func (d *cephobject) radosgwadminUserAdd(ctx context.Context, user string, maxBuckets int) (*S3Credentials, error) {
 	reverter := revert.New()
@@ -251,6 +279,60 @@ func (d *cephobject) radosgwadminBucketList(ctx context.Context) ([]string, erro
 	return buckets, nil
 }
 
+// radosgwadminBucketOwners returns the owner of each bucket, keyed on the bucket name.
+func (d *cephobject) radosgwadminBucketOwners(ctx context.Context) (map[string]string, error) {
+	out, err := d.radosgwadmin(ctx, "bucket", "stats")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []struct {
+		Bucket string `json:"bucket"`
+		Owner  string `json:"owner"`
+	}{}
+
+	err = json.Unmarshal([]byte(out), &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]string, len(stats))
+	for _, bucketStats := range stats {
+		owners[bucketStats.Bucket] = bucketStats.Owner
+	}
+
+	return owners, nil
+}
+
+// radosgwadminBucketStats returns the number of objects and the size of a bucket.
+func (d *cephobject) radosgwadminBucketStats(ctx context.Context, bucket string) (int64, int64, error) {
+	out, err := d.radosgwadmin(ctx, "bucket", "stats", "--bucket", bucket)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	stats := struct {
+		Usage map[string]struct {
+			Size       int64 `json:"size"`
+			NumObjects int64 `json:"num_objects"`
+		} `json:"usage"`
+	}{}
+
+	err = json.Unmarshal([]byte(out), &stats)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	// Sum the usage of all the storage classes of the bucket.
+	var objects, size int64
+	for _, usage := range stats.Usage {
+		objects += usage.NumObjects
+		size += usage.Size
+	}
+
+	return objects, size, nil
+}
+
 // radosgwBucketName returns the bucket name to use for the actual radosgw bucket.
 func (d *cephobject) radosgwBucketName(bucketName string) string {
 	return fmt.Sprintf("%s%s", d.config["cephobject.bucket.name_prefix"], bucketName)
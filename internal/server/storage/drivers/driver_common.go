@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/internal/server/project"
 	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/subprocess"
@@ -74,6 +75,35 @@ func (d *common) validatePool(config map[string]string, driverRules map[string]f
 		}
 	}
 
+	// Validate volume template keys (volume-template.<name>.<key>) using the same rules as volume.* options.
+	for k, v := range config {
+		templateKey, found := strings.CutPrefix(k, "volume-template.")
+		if !found {
+			continue
+		}
+
+		templateName, volKey, found := strings.Cut(templateKey, ".")
+		if !found || templateName == "" || volKey == "" {
+			return fmt.Errorf("Invalid volume template option %q", k)
+		}
+
+		checkedFields[k] = struct{}{} // Mark field as checked.
+
+		if strings.HasPrefix(volKey, "user.") {
+			continue
+		}
+
+		validator, found := rules[fmt.Sprintf("volume.%s", volKey)]
+		if !found {
+			return fmt.Errorf("Invalid volume template option %q", k)
+		}
+
+		err := validator(v)
+		if err != nil {
+			return fmt.Errorf("Invalid value for option %q: %w", k, err)
+		}
+	}
+
 	// Look for any unchecked fields, as these are unknown fields and validation should fail.
 key:
 	for k := range config {
@@ -133,6 +163,16 @@ func (d *common) fillVolumeConfig(vol *Volume, excludedKeys ...string) error {
 			continue
 		}
 
+		// vm.state_size is a pool-wide default applied to the size of VM filesystem volumes.
+		if volKey == "vm.state_size" {
+			continue
+		}
+
+		// mount.options is only relevant for custom filesystem volumes.
+		if (vol.Type() != VolumeTypeCustom || vol.ContentType() != ContentTypeFS) && volKey == "mount.options" {
+			continue
+		}
+
 		if vol.config[volKey] == "" {
 			vol.config[volKey] = d.config[k]
 		}
@@ -146,6 +186,11 @@ func (d *common) FillVolumeConfig(vol Volume) error {
 	return d.fillVolumeConfig(&vol)
 }
 
+// RecommendedVolumeConfig returns driver specific recommended config for a new volume.
+func (d *common) RecommendedVolumeConfig(vol Volume) map[string]string {
+	return nil
+}
+
 // validateVolume validates a volume config against common rules and optional driver specific rules.
 // This functions has a removeUnknownKeys option that if set to true will remove any unknown fields
 // (excluding those starting with "user.") which can be used when translating a volume config to a
@@ -189,6 +234,11 @@ key:
 		}
 
 		if !removeUnknownKeys {
+			// Give a clearer error for mount options set on a driver or volume that can't honor them.
+			if k == "mount.options" {
+				return fmt.Errorf("Mount options aren't supported for volume %q on storage pool %q", vol.name, d.name)
+			}
+
 			return fmt.Errorf("Invalid option for volume %q option %q", vol.name, k)
 		}
 
@@ -288,6 +338,11 @@ func (d *common) ApplyPatch(name string) error {
 	return patch()
 }
 
+// Patches returns the sorted names of the patches known to the driver.
+func (d *common) Patches() []string {
+	return slices.Sorted(maps.Keys(d.patches))
+}
+
 // moveGPTAltHeader moves the GPT alternative header to the end of the disk device supplied.
 // If the device supplied is not detected as not being a GPT disk then no action is taken and nil is returned.
 // If the required sgdisk command is not available a warning is logged, but no error is returned, as really it is
@@ -396,6 +451,16 @@ func (d *common) GetVolumeUsage(vol Volume) (int64, error) {
 	return -1, ErrNotSupported
 }
 
+// CacheVolumeSnapshots is a no-op for drivers that don't cache volume properties.
+func (d *common) CacheVolumeSnapshots(vol Volume) error {
+	return nil
+}
+
+// GetVolumeIOStats returns the cumulative IO counters of a volume.
+func (d *common) GetVolumeIOStats(vol Volume) (*VolumeIOStats, error) {
+	return nil, ErrNotSupported
+}
+
 // SetVolumeQuota applies a size limit on volume.
 func (d *common) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
 	return ErrNotSupported
@@ -478,7 +543,7 @@ func (d *common) CanRestoreVolume(vol Volume, snapshotName string) error {
 }
 
 // RestoreVolume resets a volume to its snapshotted state.
-func (d *common) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *common) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	return ErrNotSupported
 }
 
@@ -526,8 +591,13 @@ func (d *common) UpdateBucket(bucket Volume, changedConfig map[string]string) er
 	return ErrNotSupported
 }
 
+// GetBucketUsage returns the number of objects and the disk space used by the bucket.
+func (d *common) GetBucketUsage(bucket Volume) (int64, int64, error) {
+	return -1, -1, ErrNotSupported
+}
+
 // ValidateBucketKey validates the supplied bucket key config.
-func (d *common) ValidateBucketKey(keyName string, creds S3Credentials, roleName string) error {
+func (d *common) ValidateBucketKey(keyName string, creds S3Credentials, roleName string, pathPrefix string) error {
 	if keyName == "" {
 		return errors.New("Key name is required")
 	}
@@ -537,6 +607,18 @@ func (d *common) ValidateBucketKey(keyName string, creds S3Credentials, roleName
 		return errors.New("Invalid key role")
 	}
 
+	if pathPrefix != "" {
+		if strings.HasPrefix(pathPrefix, "/") {
+			return errors.New("Key path prefix must be relative to the bucket")
+		}
+
+		for _, seg := range strings.Split(pathPrefix, "/") {
+			if seg == "." || seg == ".." {
+				return errors.New("Key path prefix cannot contain relative path elements")
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -555,6 +637,16 @@ func (d *common) DeleteBucketKey(bucket Volume, keyName string, op *operations.O
 	return nil
 }
 
+// ListBuckets returns the names of the buckets found on the storage.
+func (d *common) ListBuckets() ([]string, error) {
+	return nil, ErrNotSupported
+}
+
+// ListBucketKeys returns the keys of a bucket found on the storage.
+func (d *common) ListBucketKeys(bucket Volume) ([]S3BucketKey, error) {
+	return nil, ErrNotSupported
+}
+
 // roundVolumeBlockSizeBytes returns sizeBytes rounded up to the next multiple
 // of MinBlockBoundary.
 func (d *common) roundVolumeBlockSizeBytes(vol Volume, sizeBytes int64) (int64, error) {
@@ -609,3 +701,8 @@ func (d *common) Qcow2DeletionCleanup(vol Volume, childName string) error {
 func (d *common) ActivateTask(vol Volume, task func(devPath string, op *operations.Operation) error, op *operations.Operation) error {
 	return ErrNotSupported
 }
+
+// GetVolumeClones returns the names of the volumes cloned from the volume or its snapshots.
+func (d *common) GetVolumeClones(vol Volume) ([]string, error) {
+	return nil, ErrNotSupported
+}
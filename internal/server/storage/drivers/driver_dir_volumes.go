@@ -16,6 +16,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/internal/server/storage/quota"
 	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/units"
@@ -205,7 +206,7 @@ func (d *dir) DeleteVolume(vol Volume, op *operations.Operation) error {
 
 	// Although the volume snapshot directory should already be removed, lets remove it here
 	// to just in case the top-level directory is left.
-	err = deleteParentSnapshotDirIfEmpty(d.name, vol.volType, vol.name)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, vol.volType, vol.name)
 	if err != nil {
 		return err
 	}
@@ -318,6 +319,30 @@ func (d *dir) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}}  [^*]
 
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_dir, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -536,7 +561,7 @@ func (d *dir) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) err
 		}
 
 		bwlimit := d.config["rsync.bwlimit"]
-		srcPath := GetVolumeMountPath(d.name, snapVol.volType, parentName)
+		srcPath := GetVolumeMountPath(d.name, d.config, snapVol.volType, parentName)
 		d.Logger().Debug("Copying filesystem volume", logger.Ctx{"sourcePath": srcPath, "targetPath": snapPath, "bwlimit": bwlimit, "rsyncArgs": rsyncArgs})
 
 		// Copy filesystem volume into snapshot directory.
@@ -565,7 +590,7 @@ func (d *dir) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) err
 			return err
 		}
 
-		err = copyDevice(srcDevPath, targetDevPath)
+		err = CopyDevice(srcDevPath, targetDevPath)
 		if err != nil {
 			return err
 		}
@@ -589,7 +614,7 @@ func (d *dir) DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation) err
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
-	err = deleteParentSnapshotDirIfEmpty(d.name, snapVol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -658,7 +683,7 @@ func (d *dir) VolumeSnapshots(vol Volume, op *operations.Operation) ([]string, e
 }
 
 // RestoreVolume restores a volume from a snapshot.
-func (d *dir) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *dir) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	snapVol, err := vol.NewSnapshot(snapshotName)
 	if err != nil {
 		return err
@@ -705,7 +730,7 @@ func (d *dir) RestoreVolume(vol Volume, snapshotName string, op *operations.Oper
 			return err
 		}
 
-		err = copyDevice(srcDevPath, targetDevPath)
+		err = CopyDeviceWithProgress(srcDevPath, targetDevPath, tracker)
 		if err != nil {
 			return err
 		}
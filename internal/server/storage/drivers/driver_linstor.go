@@ -300,6 +300,7 @@ func (d *linstor) Info() Info {
 		Name:                         "linstor",
 		Version:                      linstorVersion,
 		VolumeTypes:                  []VolumeType{VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
+		Snapshots:                    true,
 		DefaultVMBlockFilesystemSize: deviceConfig.DefaultVMBlockFilesystemSize,
 		Buckets:                      false,
 		Remote:                       d.isRemote(),
@@ -314,6 +315,7 @@ func (d *linstor) Info() Info {
 		IOUring:                      true,
 		MountedRoot:                  false,
 		Deactivate:                   false,
+		SeparateVMConfigVolumes:      true,
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 	localMigration "github.com/lxc/incus/v7/internal/server/migration"
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/units"
@@ -93,6 +94,15 @@ func (d *linstor) commonVolumeRules() map[string]func(value string) error {
 		//  shortdesc: Mount options for block-backed file system volumes
 		"block.mount_options": validate.IsAny,
 
+		// gendoc:generate(entity=storage_volume_linstor, group=common, key=mount.options)
+		// Comma-separated mount options added to the ones used by default, for example `noatime`. Changes take effect the next time the volume is mounted.
+		// ---
+		//  type: string
+		//  condition: block-based custom volume with content type `filesystem`
+		//  default: same as `volume.mount.options`
+		//  shortdesc: Additional mount options for the volume
+		"mount.options": validate.Optional(validateMountOptions),
+
 		// gendoc:generate(entity=storage_volume_linstor, group=common, key=block.create_options)
 		//
 		// ---
@@ -222,6 +232,30 @@ func (d *linstor) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}} [^*]
 
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_linstor, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -248,6 +282,11 @@ func (d *linstor) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 		delete(commonRules, "block.mount_options")
 	}
 
+	// mount.options is only relevant for custom filesystem volumes.
+	if vol.volType != VolumeTypeCustom || vol.contentType != ContentTypeFS {
+		delete(commonRules, "mount.options")
+	}
+
 	return d.validateVolume(vol, commonRules, removeUnknownKeys, LinstorRawConfigKeyPrefix)
 }
 
@@ -598,7 +637,7 @@ func (d *linstor) HasVolume(vol Volume) (bool, error) {
 
 // GetVolumeDiskPath returns the location of a root disk block device.
 func (d *linstor) GetVolumeDiskPath(vol Volume) (string, error) {
-	if vol.IsVMBlock() || (vol.volType == VolumeTypeCustom && IsContentBlock(vol.contentType)) {
+	if vol.IsVMBlock() || (vol.volType == VolumeTypeCustom && IsContentBlock(vol.contentType)) || (vol.volType == VolumeTypeContainer && vol.IsBlockBacked()) {
 		devPath, err := d.getLinstorDevPath(vol)
 		return devPath, err
 	}
@@ -839,7 +878,7 @@ func (d *linstor) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation)
 	defer rev.Fail()
 
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
-	sourcePath := GetVolumeMountPath(d.name, snapVol.volType, parentName)
+	sourcePath := GetVolumeMountPath(d.name, d.config, snapVol.volType, parentName)
 
 	if linux.IsMountPoint(sourcePath) {
 		// Attempt to sync and freeze filesystem, but do not error if not able to freeze (as filesystem
@@ -854,7 +893,7 @@ func (d *linstor) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation)
 	}
 
 	// Create the parent directory.
-	err := CreateParentSnapshotDirIfMissing(d.name, snapVol.volType, parentName)
+	err := CreateParentSnapshotDirIfMissing(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -914,7 +953,7 @@ func (d *linstor) DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation)
 	}
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
-	err = deleteParentSnapshotDirIfEmpty(d.name, snapVol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -977,7 +1016,7 @@ func (d *linstor) CanRestoreVolume(vol Volume, snapshotName string) error {
 }
 
 // RestoreVolume restores a volume from a snapshot.
-func (d *linstor) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *linstor) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	ourUnmount, err := d.UnmountVolume(vol, false, op)
 	if err != nil {
 		return err
@@ -1229,7 +1268,7 @@ func (d *linstor) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool
 		fsType := vol.ConfigBlockFilesystem()
 
 		if sizeBytes < oldSizeBytes {
-			if !filesystemTypeCanBeShrunk(fsType) {
+			if !FilesystemTypeCanBeShrunk(fsType) {
 				return fmt.Errorf("Filesystem %q cannot be shrunk: %w", fsType, ErrCannotBeShrunk)
 			}
 
@@ -161,6 +161,7 @@ func (d *lvm) Info() Info {
 		PreservesInodes:              false,
 		Remote:                       d.isRemote(),
 		VolumeTypes:                  []VolumeType{VolumeTypeBucket, VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
+		Snapshots:                    true,
 		VolumeMultiNode:              d.isRemote(),
 		BlockBacking:                 true,
 		RunningCopyFreeze:            true,
@@ -172,6 +173,9 @@ func (d *lvm) Info() Info {
 		Deactivate:                   d.isRemote(),
 		ZeroUnpack:                   !d.usesThinpool(),
 		TargetFormat:                 targetFormat,
+		IndependentSnapshotCopies:    d.usesThinpool(), // Thin snapshots don't depend on their origin.
+		SerialSnapshotDeletes:        d.clustered,      // Qcow2 snapshots are chained.
+		SeparateVMConfigVolumes:      true,
 	}
 }
 
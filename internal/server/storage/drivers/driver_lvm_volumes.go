@@ -21,6 +21,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/migration"
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/subprocess"
@@ -272,7 +273,7 @@ func (d *lvm) DeleteVolume(vol Volume, op *operations.Operation) error {
 
 		// Although the volume snapshot directory should already be removed, lets remove it here to just in
 		// case the top-level directory is left.
-		err = deleteParentSnapshotDirIfEmpty(d.name, vol.volType, vol.name)
+		err = deleteParentSnapshotDirIfEmpty(d.name, d.config, vol.volType, vol.name)
 		if err != nil {
 			return err
 		}
@@ -375,6 +376,15 @@ func (d *lvm) commonVolumeRules() map[string]func(value string) error {
 		//  shortdesc: Mount options for block-backed file system volumes
 		"block.mount_options": validate.IsAny,
 
+		// gendoc:generate(entity=storage_volume_lvm, group=common, key=mount.options)
+		// Comma-separated mount options added to the ones used by default, for example `noatime`. Changes take effect the next time the volume is mounted.
+		// ---
+		//  type: string
+		//  condition: block-based custom volume with content type `filesystem`
+		//  default: same as `volume.mount.options`
+		//  shortdesc: Additional mount options for the volume
+		"mount.options": validate.Optional(validateMountOptions),
+
 		// gendoc:generate(entity=storage_volume_lvm, group=common, key=block.create_options)
 		//
 		// ---
@@ -517,6 +527,30 @@ func (d *lvm) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}}  [^*]
 
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_lvm, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -544,6 +578,11 @@ func (d *lvm) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 		delete(commonRules, "block.mount_options")
 	}
 
+	// mount.options is only relevant for custom filesystem volumes.
+	if vol.volType != VolumeTypeCustom || vol.contentType != ContentTypeFS {
+		delete(commonRules, "mount.options")
+	}
+
 	err := d.validateVolume(vol, commonRules, removeUnknownKeys)
 	if err != nil {
 		return err
@@ -672,7 +711,7 @@ func (d *lvm) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 		fsType := vol.ConfigBlockFilesystem()
 
 		if sizeBytes < oldSizeBytes {
-			if !filesystemTypeCanBeShrunk(fsType) {
+			if !FilesystemTypeCanBeShrunk(fsType) {
 				return fmt.Errorf("Filesystem %q cannot be shrunk: %w", fsType, ErrCannotBeShrunk)
 			}
 
@@ -844,13 +883,27 @@ func (d *lvm) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 
 // GetVolumeDiskPath returns the location of a disk volume.
 func (d *lvm) GetVolumeDiskPath(vol Volume) (string, error) {
-	if vol.IsVMBlock() || (vol.volType == VolumeTypeCustom && IsContentBlock(vol.contentType)) {
+	if vol.IsVMBlock() || (vol.volType == VolumeTypeCustom && IsContentBlock(vol.contentType)) || (vol.volType == VolumeTypeContainer && vol.IsBlockBacked()) {
 		return d.lvmDevPath(d.lvmPath(d.config["lvm.vg_name"], vol.volType, vol.contentType, vol.name))
 	}
 
 	return "", ErrNotSupported
 }
 
+// GetVolumeIOStats returns the cumulative IO counters of the logical volume.
+func (d *lvm) GetVolumeIOStats(vol Volume) (*VolumeIOStats, error) {
+	devPath, err := d.lvmDevPath(d.lvmPath(d.config["lvm.vg_name"], vol.volType, vol.contentType, vol.name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New("Volume isn't active")
+		}
+
+		return nil, err
+	}
+
+	return blockDeviceIOStats(devPath)
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *lvm) ListVolumes() ([]Volume, error) {
 	vols := make(map[string]Volume)
@@ -1177,9 +1230,14 @@ func (d *lvm) RenameVolume(vol Volume, newVolName string, op *operations.Operati
 
 		// Rename snapshots dir if present.
 		if vol.contentType == ContentTypeFS {
-			srcSnapshotDir := GetVolumeSnapshotDir(d.name, vol.volType, vol.name)
-			dstSnapshotDir := GetVolumeSnapshotDir(d.name, vol.volType, newVolName)
+			srcSnapshotDir := GetVolumeSnapshotDir(d.name, d.config, vol.volType, vol.name)
+			dstSnapshotDir := GetVolumeSnapshotDir(d.name, d.config, vol.volType, newVolName)
 			if util.PathExists(srcSnapshotDir) {
+				err = ensureSnapshotShardDir(d.name, d.config, vol.volType, newVolName)
+				if err != nil {
+					return err
+				}
+
 				err = os.Rename(srcSnapshotDir, dstSnapshotDir)
 				if err != nil {
 					return fmt.Errorf("Error renaming LVM logical volume snapshot directory from %q to %q: %w", srcSnapshotDir, dstSnapshotDir, err)
@@ -1200,8 +1258,8 @@ func (d *lvm) RenameVolume(vol Volume, newVolName string, op *operations.Operati
 
 		// Rename volume dir.
 		if vol.contentType == ContentTypeFS {
-			srcVolumePath := GetVolumeMountPath(d.name, vol.volType, vol.name)
-			dstVolumePath := GetVolumeMountPath(d.name, vol.volType, newVolName)
+			srcVolumePath := GetVolumeMountPath(d.name, d.config, vol.volType, vol.name)
+			dstVolumePath := GetVolumeMountPath(d.name, d.config, vol.volType, newVolName)
 			err = os.Rename(srcVolumePath, dstVolumePath)
 			if err != nil {
 				return fmt.Errorf("Error renaming LVM logical volume mount path from %q to %q: %w", srcVolumePath, dstVolumePath, err)
@@ -1219,6 +1277,14 @@ func (d *lvm) RenameVolume(vol Volume, newVolName string, op *operations.Operati
 			}
 		}
 
+		// Remove the old snapshot shard directory if no longer used.
+		if vol.contentType == ContentTypeFS {
+			err = DeleteSnapshotShardDirIfEmpty(d.name, d.config, vol.volType, vol.name)
+			if err != nil {
+				return err
+			}
+		}
+
 		reverter.Success()
 		return nil
 	}, false, op)
@@ -1350,7 +1416,7 @@ func (d *lvm) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) err
 	}
 
 	// Create the parent directory.
-	err := CreateParentSnapshotDirIfMissing(d.name, snapVol.volType, parentName)
+	err := CreateParentSnapshotDirIfMissing(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -1428,7 +1494,7 @@ func (d *lvm) DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation) err
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
-	err = deleteParentSnapshotDirIfEmpty(d.name, snapVol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -1713,7 +1779,7 @@ func (d *lvm) VolumeSnapshots(vol Volume, op *operations.Operation) ([]string, e
 }
 
 // RestoreVolume restores a volume from a snapshot.
-func (d *lvm) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *lvm) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	// Instantiate snapshot volume from snapshot name.
 	snapVol, err := vol.NewSnapshot(snapshotName)
 	if err != nil {
@@ -1789,7 +1855,7 @@ func (d *lvm) RestoreVolume(vol Volume, snapshotName string, op *operations.Oper
 		// For VMs, also restore the filesystem volume.
 		if vol.IsVMBlock() {
 			fsVol := vol.NewVMBlockFilesystemVolume()
-			err := d.RestoreVolume(fsVol, snapshotName, op)
+			err := d.RestoreVolume(fsVol, snapshotName, tracker, op)
 			if err != nil {
 				return err
 			}
@@ -1862,7 +1928,7 @@ func (d *lvm) RestoreVolume(vol Volume, snapshotName string, op *operations.Oper
 				}
 
 				d.Logger().Debug("Copying block volume", logger.Ctx{"srcDevPath": srcDevPath, "targetPath": targetDevPath})
-				err = copyDevice(srcDevPath, targetDevPath)
+				err = CopyDeviceWithProgress(srcDevPath, targetDevPath, tracker)
 				if err != nil {
 					return err
 				}
@@ -1912,7 +1978,7 @@ func (d *lvm) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *o
 	}
 
 	oldPath := snapVol.MountPath()
-	newPath := GetVolumeMountPath(d.name, snapVol.volType, newSnapVolName)
+	newPath := GetVolumeMountPath(d.name, d.config, snapVol.volType, newSnapVolName)
 
 	if util.PathExists(oldPath) {
 		err = os.Rename(oldPath, newPath)
@@ -115,6 +115,7 @@ func (d *truenas) Info() Info {
 		PreservesInodes:              false,
 		Remote:                       d.isRemote(),
 		VolumeTypes:                  []VolumeType{VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
+		Snapshots:                    true,
 		VolumeMultiNode:              false, // can only use the same volume if its read-only.d.isRemote(),
 		BlockBacking:                 true,
 		RunningCopyFreeze:            true,
@@ -122,6 +123,7 @@ func (d *truenas) Info() Info {
 		IOUring:                      false,
 		MountedRoot:                  false,
 		Buckets:                      false,
+		SeparateVMConfigVolumes:      true,
 	}
 
 	return info
@@ -22,6 +22,7 @@ import (
 	localMigration "github.com/lxc/incus/v7/internal/server/migration"
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/units"
@@ -733,9 +734,14 @@ func (d *truenas) deleteVolume(vol Volume, optionalExistance *bool, op *operatio
 		}
 
 		// Delete the snapshot storage.
-		err = os.RemoveAll(GetVolumeSnapshotDir(d.name, vol.volType, vol.name))
+		err = os.RemoveAll(GetVolumeSnapshotDir(d.name, d.config, vol.volType, vol.name))
 		if err != nil && !errors.Is(err, fs.ErrNotExist) {
-			return fmt.Errorf("Failed to remove '%s': %w", GetVolumeSnapshotDir(d.name, vol.volType, vol.name), err)
+			return fmt.Errorf("Failed to remove '%s': %w", GetVolumeSnapshotDir(d.name, d.config, vol.volType, vol.name), err)
+		}
+
+		err = DeleteSnapshotShardDirIfEmpty(d.name, d.config, vol.volType, vol.name)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -799,6 +805,15 @@ func (d *truenas) commonVolumeRules() map[string]func(value string) error {
 		//  shortdesc: Mount options for block-backed file system volumes
 		"block.mount_options": validate.IsAny,
 
+		// gendoc:generate(entity=storage_volume_truenas, group=common, key=mount.options)
+		// Comma-separated mount options added to the ones used by default, for example `noatime`. Changes take effect the next time the volume is mounted.
+		// ---
+		//  type: string
+		//  condition: block-based custom volume with content type `filesystem`
+		//  default: same as `volume.mount.options`
+		//  shortdesc: Additional mount options for the volume
+		"mount.options": validate.Optional(validateMountOptions),
+
 		// gendoc:generate(entity=storage_volume_truenas, group=common, key=block.create_options)
 		//
 		// ---
@@ -919,6 +934,30 @@ func (d *truenas) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}}
 
+	// gendoc:generate(entity=storage_volume_truenas, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_truenas, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_truenas, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_truenas, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -938,6 +977,11 @@ func (d *truenas) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 		delete(commonRules, "block.mount_options")
 	}
 
+	// mount.options is only relevant for custom filesystem volumes.
+	if vol.volType != VolumeTypeCustom || vol.contentType != ContentTypeFS {
+		delete(commonRules, "mount.options")
+	}
+
 	return d.validateVolume(vol, commonRules, removeUnknownKeys)
 }
 
@@ -1007,6 +1051,14 @@ func (d *truenas) GetVolumeUsage(vol Volume) (int64, error) {
 	return valueInt, nil
 }
 
+// CacheVolumeSnapshots fetches the usage of the volume and all of its snapshots in a single call, so that
+// subsequent GetVolumeUsage calls on the snapshots are served from the cache.
+func (d *truenas) CacheVolumeSnapshots(vol Volume) error {
+	d.prefillCachedProperties(d.dataset(vol, false))
+
+	return nil
+}
+
 // SetVolumeQuota sets the quota/reservation on the volume.
 // Does nothing if supplied with an empty/zero size for block volumes.
 func (d *truenas) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
@@ -1057,7 +1109,7 @@ func (d *truenas) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool
 		l := d.logger.AddContext(logger.Ctx{"vol": vol, "size": fmt.Sprintf("%db", sizeBytes)})
 
 		if sizeBytes < oldVolSizeBytes {
-			if !filesystemTypeCanBeShrunk(fsType) {
+			if !FilesystemTypeCanBeShrunk(fsType) {
 				return fmt.Errorf("Filesystem %q cannot be shrunk: %w", fsType, ErrCannotBeShrunk)
 			}
 
@@ -1217,6 +1269,22 @@ func (d *truenas) GetVolumeDiskPath(vol Volume) (string, error) {
 	return d.locateIscsiDataset(dataset)
 }
 
+// GetVolumeClones returns the names of the datasets cloned from the volume or its snapshots.
+func (d *truenas) GetVolumeClones(vol Volume) ([]string, error) {
+	dataset := d.dataset(vol, false)
+
+	exists, err := d.datasetExists(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return []string{}, nil
+	}
+
+	return d.getClones(dataset)
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *truenas) ListVolumes() ([]Volume, error) {
 	vols := make(map[string]Volume)
@@ -1633,7 +1701,7 @@ func (d *truenas) CreateVolumeSnapshot(vol Volume, op *operations.Operation) err
 	defer reverter.Fail()
 
 	// Create the parent directory.
-	err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, parentName)
+	err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -1659,7 +1727,7 @@ func (d *truenas) CreateVolumeSnapshot(vol Volume, op *operations.Operation) err
 			In theory, a similar problem can exist with raw devices... and we may want to look at using something
 			similar to `blockdev --flushbufs` to flush the block device before the snap.
 		*/
-		volMountPath := GetVolumeMountPath(vol.pool, vol.volType, parentName)
+		volMountPath := GetVolumeMountPath(vol.pool, vol.poolConfig, vol.volType, parentName)
 		if linux.IsMountPoint(volMountPath) {
 			err := linux.SyncFS(volMountPath)
 			if err != nil {
@@ -1739,7 +1807,7 @@ func (d *truenas) DeleteVolumeSnapshot(vol Volume, op *operations.Operation) err
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
 	parentName, _, _ := api.GetParentAndSnapshotName(vol.name)
-	err = deleteParentSnapshotDirIfEmpty(d.name, vol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, vol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -1996,7 +2064,7 @@ func (d *truenas) CanRestoreVolume(vol Volume, snapshotName string) error {
 }
 
 // RestoreVolume restores a volume from a snapshot.
-func (d *truenas) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *truenas) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	return d.restoreVolume(vol, snapshotName, false, op)
 }
 
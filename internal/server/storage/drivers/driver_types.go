@@ -5,6 +5,7 @@ type Info struct {
 	Name                         string
 	Version                      string
 	VolumeTypes                  []VolumeType // Supported volume types.
+	Snapshots                    bool         // Whether volume snapshots are supported.
 	DefaultVMBlockFilesystemSize string       // Default volume size for VM block filesystems.
 	Buckets                      bool         // Buckets supported.
 	Remote                       bool         // Whether the driver uses a remote backing store.
@@ -22,6 +23,12 @@ type Info struct {
 	Deactivate                   bool         // Whether an unmount action is required prior to removing the pool.
 	ZeroUnpack                   bool         // Whether to write zeroes (no discard) during unpacking.
 	TargetFormat                 string       // Whether the output image format should be raw or qcow2.
+	IndependentSnapshotCopies    bool         // Whether copies of a snapshot are cheap and don't depend on it.
+	Delegation                   bool         // Whether volumes can be delegated to instances.
+	Encryption                   bool         // Whether natively encrypted volumes are preserved when copied or migrated.
+	CheapClones                  bool         // Whether a snapshot can be cloned into a writable volume without copying its data.
+	SerialSnapshotDeletes        bool         // Whether snapshots must be deleted one at a time, in order.
+	SeparateVMConfigVolumes      bool         // Whether a VM's config filesystem volume is stored apart from its block volume.
 }
 
 // VolumeFiller provides a struct for filling a volume.
@@ -31,3 +38,11 @@ type VolumeFiller struct {
 
 	Fingerprint string // If the Filler will unpack an image, it should be this fingerprint.
 }
+
+// VolumeIOStats provides cumulative IO counters for a volume.
+type VolumeIOStats struct {
+	ReadBytes  uint64 // Number of bytes read.
+	ReadOps    uint64 // Number of completed read operations.
+	WriteBytes uint64 // Number of bytes written.
+	WriteOps   uint64 // Number of completed write operations.
+}
@@ -126,12 +126,17 @@ func (d *zfs) Info() Info {
 		PreservesInodes:              true,
 		Remote:                       d.isRemote(),
 		VolumeTypes:                  []VolumeType{VolumeTypeBucket, VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
+		Snapshots:                    true,
 		VolumeMultiNode:              d.isRemote(),
 		BlockBacking:                 util.IsTrue(d.config["volume.zfs.block_mode"]),
 		RunningCopyFreeze:            util.IsTrue(d.config["volume.zfs.block_mode"]),
 		DirectIO:                     true,
 		MountedRoot:                  false,
 		Buckets:                      true,
+		Delegation:                   zfsDelegate,
+		Encryption:                   true,
+		CheapClones:                  !util.IsFalse(d.config["zfs.clone_copy"]),
+		SeparateVMConfigVolumes:      true,
 	}
 
 	return info
@@ -464,7 +464,7 @@ func (d *zfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData
 
 		if len(srcBackup.Snapshots) > 0 {
 			// Create new snapshots directory.
-			err := CreateParentSnapshotDirIfMissing(d.name, v.volType, v.name)
+			err := CreateParentSnapshotDirIfMissing(d.name, d.config, v.volType, v.name)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -1212,7 +1212,7 @@ func (d *zfs) createVolumeFromMigrationOptimized(vol Volume, conn io.ReadWriteCl
 	// Handle zfs send/receive migration.
 	if len(volTargetArgs.Snapshots) > 0 {
 		// Create the parent directory.
-		err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, vol.name)
+		err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, vol.name)
 		if err != nil {
 			return err
 		}
@@ -1485,7 +1485,7 @@ func (d *zfs) RefreshVolume(vol Volume, srcVol Volume, srcSnapshots []Volume, al
 	_, lastIdenticalSnapshotOnlyName, _ := api.GetParentAndSnapshotName(lastIdenticalSnapshot.Name())
 
 	// Rollback target volume to the latest identical snapshot
-	err = d.RestoreVolume(vol, lastIdenticalSnapshotOnlyName, op)
+	err = d.RestoreVolume(vol, lastIdenticalSnapshotOnlyName, nil, op)
 	if err != nil {
 		return fmt.Errorf("Failed to restore volume: %w", err)
 	}
@@ -1580,7 +1580,7 @@ func (d *zfs) RefreshVolume(vol Volume, srcVol Volume, srcSnapshots []Volume, al
 	}
 
 	// Restore target volume from main source snapshot.
-	err = d.RestoreVolume(vol, snapUUID, op)
+	err = d.RestoreVolume(vol, snapUUID, nil, op)
 	if err != nil {
 		return err
 	}
@@ -1663,9 +1663,14 @@ func (d *zfs) deleteVolume(vol Volume, op *operations.Operation) error {
 		}
 
 		// Delete the snapshot storage.
-		err = os.RemoveAll(GetVolumeSnapshotDir(d.name, vol.volType, vol.name))
+		err = os.RemoveAll(GetVolumeSnapshotDir(d.name, d.config, vol.volType, vol.name))
 		if err != nil && !errors.Is(err, fs.ErrNotExist) {
-			return fmt.Errorf("Failed to remove '%s': %w", GetVolumeSnapshotDir(d.name, vol.volType, vol.name), err)
+			return fmt.Errorf("Failed to remove '%s': %w", GetVolumeSnapshotDir(d.name, d.config, vol.volType, vol.name), err)
+		}
+
+		err = DeleteSnapshotShardDirIfEmpty(d.name, d.config, vol.volType, vol.name)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -1708,6 +1713,15 @@ func (d *zfs) commonVolumeRules() map[string]func(value string) error {
 		//  shortdesc: Mount options for block-backed file system volumes
 		"block.mount_options": validate.IsAny,
 
+		// gendoc:generate(entity=storage_volume_zfs, group=common, key=mount.options)
+		// Comma-separated mount options added to the ones used by default, for example `noatime`. Changes take effect the next time the volume is mounted.
+		// ---
+		//  type: string
+		//  condition: custom volume with content type `filesystem` (`zfs.block_mode` enabled or not)
+		//  default: same as `volume.mount.options`
+		//  shortdesc: Additional mount options for the volume
+		"mount.options": validate.Optional(validateMountOptions),
+
 		// gendoc:generate(entity=storage_volume_zfs, group=common, key=block.create_options)
 		//
 		// ---
@@ -1855,6 +1869,30 @@ func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.pattern` or `snap%d`
 	//  shortdesc: {{snapshot_pattern_format}} [^*]
 
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=snapshots.retain)
+	// Older snapshots beyond this count are deleted, whether or not they have expired.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.retain`
+	//  shortdesc: Number of snapshots to keep
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=snapshots.max)
+	// Once the volume has this many snapshots, new snapshots are refused or the oldest ones are deleted, depending on `snapshots.max.policy`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max`
+	//  shortdesc: Maximum number of snapshots
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=snapshots.max.policy)
+	// Set to `reject` to refuse new snapshots once `snapshots.max` is reached, or to `prune` to delete the oldest snapshots instead.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.snapshots.max.policy` or `reject`
+	//  shortdesc: What to do when `snapshots.max` is reached
+
 	// gendoc:generate(entity=storage_volume_zfs, group=common, key=snapshots.schedule)
 	//
 	// ---
@@ -1886,6 +1924,11 @@ func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 		delete(commonRules, "block.mount_options")
 	}
 
+	// mount.options is only relevant for custom filesystem volumes.
+	if vol.volType != VolumeTypeCustom || vol.contentType != ContentTypeFS {
+		delete(commonRules, "mount.options")
+	}
+
 	return d.validateVolume(vol, commonRules, removeUnknownKeys)
 }
 
@@ -1968,6 +2011,14 @@ func (d *zfs) GetVolumeUsage(vol Volume) (int64, error) {
 	return valueInt, nil
 }
 
+// CacheVolumeSnapshots fetches the usage of the volume and all of its snapshots in a single call, so that
+// subsequent GetVolumeUsage calls on the snapshots are served from the cache.
+func (d *zfs) CacheVolumeSnapshots(vol Volume) error {
+	d.prefillCachedProperties(d.dataset(vol, false))
+
+	return nil
+}
+
 // SetVolumeQuota sets the quota/reservation on the volume.
 // Does nothing if supplied with an empty/zero size for block volumes.
 func (d *zfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
@@ -2032,7 +2083,7 @@ func (d *zfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 			l := d.logger.AddContext(logger.Ctx{"dev": volDevPath, "size": fmt.Sprintf("%db", sizeBytes)})
 
 			if sizeBytes < oldVolSizeBytes {
-				if !filesystemTypeCanBeShrunk(fsType) {
+				if !FilesystemTypeCanBeShrunk(fsType) {
 					return fmt.Errorf("Filesystem %q cannot be shrunk: %w", fsType, ErrCannotBeShrunk)
 				}
 
@@ -2263,6 +2314,64 @@ func (d *zfs) GetVolumeDiskPath(vol Volume) (string, error) {
 	return d.tryGetVolumeDiskPathFromDataset(ctx, d.dataset(vol, false))
 }
 
+// GetVolumeClones returns the names of the datasets cloned from the volume or its snapshots.
+func (d *zfs) GetVolumeClones(vol Volume) ([]string, error) {
+	vols := []Volume{vol}
+
+	// Image volumes may also exist in a block-backed variant for each supported filesystem.
+	if vol.volType == VolumeTypeImage {
+		for _, filesystem := range blockBackedAllowedFilesystems {
+			tmpVol := vol.Clone()
+			tmpVol.config["block.filesystem"] = filesystem
+			vols = append(vols, tmpVol)
+		}
+	}
+
+	clones := []string{}
+	seen := map[string]bool{}
+	for _, v := range vols {
+		dataset := d.dataset(v, false)
+		if seen[dataset] {
+			continue
+		}
+
+		seen[dataset] = true
+
+		exists, err := d.datasetExists(dataset)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			continue
+		}
+
+		datasetClones, err := d.getClones(dataset)
+		if err != nil {
+			return nil, err
+		}
+
+		clones = append(clones, datasetClones...)
+	}
+
+	return clones, nil
+}
+
+// GetVolumeIOStats returns the cumulative IO counters of the volume.
+// Only volumes backed by a zvol are supported.
+func (d *zfs) GetVolumeIOStats(vol Volume) (*VolumeIOStats, error) {
+	if !vol.IsBlockBacked() && !IsContentBlock(vol.contentType) {
+		return nil, ErrNotSupported
+	}
+
+	devPath, err := d.GetVolumeDiskPath(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockDeviceIOStats(devPath)
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *zfs) ListVolumes() ([]Volume, error) {
 	vols := make(map[string]Volume)
@@ -2531,6 +2640,7 @@ func (d *zfs) MountVolume(vol Volume, op *operations.Operation) error {
 				volOptions = append(volOptions, "strictatime")
 			}
 
+			volOptions = append(volOptions, vol.ConfigMountOptions()...)
 			mountFlags, mountOptions := linux.ResolveMountOptions(volOptions)
 
 			// Mount the dataset.
@@ -3221,7 +3331,7 @@ func (d *zfs) CreateVolumeSnapshot(vol Volume, op *operations.Operation) error {
 	defer reverter.Fail()
 
 	// Create the parent directory.
-	err := CreateParentSnapshotDirIfMissing(d.name, vol.volType, parentName)
+	err := CreateParentSnapshotDirIfMissing(d.name, d.config, vol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -3288,7 +3398,7 @@ func (d *zfs) DeleteVolumeSnapshot(vol Volume, op *operations.Operation) error {
 	}
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
-	err = deleteParentSnapshotDirIfEmpty(d.name, vol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(d.name, d.config, vol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -3683,7 +3793,7 @@ func (d *zfs) CanRestoreVolume(vol Volume, snapshotName string) error {
 }
 
 // RestoreVolume restores a volume from a snapshot.
-func (d *zfs) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+func (d *zfs) RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error {
 	return d.restoreVolume(vol, snapshotName, false, op)
 }
 
@@ -3846,6 +3956,20 @@ func (d *zfs) FillVolumeConfig(vol Volume) error {
 	return nil
 }
 
+// RecommendedVolumeConfig returns the recommended zfs.blocksize for the volume.
+// This matches the ZFS defaults for volblocksize on zvols and recordsize on datasets.
+func (d *zfs) RecommendedVolumeConfig(vol Volume) map[string]string {
+	if vol.contentType == ContentTypeBlock || d.isBlockBacked(vol) {
+		return map[string]string{"zfs.blocksize": "16KiB"}
+	}
+
+	if vol.contentType == ContentTypeFS {
+		return map[string]string{"zfs.blocksize": "128KiB"}
+	}
+
+	return nil
+}
+
 func (d *zfs) isBlockBacked(vol Volume) bool {
 	return util.IsTrue(vol.Config()["zfs.block_mode"])
 }
@@ -77,8 +77,8 @@ func genericVFSRenameVolume(d Driver, vol Volume, newVolName string, op *operati
 	}
 
 	// Rename the volume itself.
-	srcVolumePath := GetVolumeMountPath(d.Name(), vol.volType, volName)
-	dstVolumePath := GetVolumeMountPath(d.Name(), vol.volType, newVolName)
+	srcVolumePath := GetVolumeMountPath(d.Name(), d.Config(), vol.volType, volName)
+	dstVolumePath := GetVolumeMountPath(d.Name(), d.Config(), vol.volType, newVolName)
 
 	if util.PathExists(srcVolumePath) {
 		err := os.Rename(srcVolumePath, dstVolumePath)
@@ -90,16 +90,26 @@ func genericVFSRenameVolume(d Driver, vol Volume, newVolName string, op *operati
 	}
 
 	// And if present, the snapshots too.
-	srcSnapshotDir := GetVolumeSnapshotDir(d.Name(), vol.volType, vol.name)
-	dstSnapshotDir := GetVolumeSnapshotDir(d.Name(), vol.volType, newVolName)
+	srcSnapshotDir := GetVolumeSnapshotDir(d.Name(), d.Config(), vol.volType, vol.name)
+	dstSnapshotDir := GetVolumeSnapshotDir(d.Name(), d.Config(), vol.volType, newVolName)
 
 	if util.PathExists(srcSnapshotDir) {
-		err := os.Rename(srcSnapshotDir, dstSnapshotDir)
+		err := ensureSnapshotShardDir(d.Name(), d.Config(), vol.volType, newVolName)
+		if err != nil {
+			return err
+		}
+
+		err = os.Rename(srcSnapshotDir, dstSnapshotDir)
 		if err != nil {
 			return fmt.Errorf("Failed to rename %q to %q: %w", srcSnapshotDir, dstSnapshotDir, err)
 		}
 
 		reverter.Add(func() { _ = os.Rename(dstSnapshotDir, srcSnapshotDir) })
+
+		err = DeleteSnapshotShardDirIfEmpty(d.Name(), d.Config(), vol.volType, vol.name)
+		if err != nil {
+			return err
+		}
 	}
 
 	reverter.Success()
@@ -108,7 +118,7 @@ func genericVFSRenameVolume(d Driver, vol Volume, newVolName string, op *operati
 
 // genericVFSVolumeSnapshots is a generic VolumeSnapshots implementation for VFS-only drivers.
 func genericVFSVolumeSnapshots(d Driver, vol Volume, op *operations.Operation) ([]string, error) {
-	snapshotDir := GetVolumeSnapshotDir(d.Name(), vol.volType, vol.name)
+	snapshotDir := GetVolumeSnapshotDir(d.Name(), d.Config(), vol.volType, vol.name)
 	snapshots := []string{}
 
 	ents, err := os.ReadDir(snapshotDir)
@@ -145,7 +155,7 @@ func genericVFSRenameVolumeSnapshot(d Driver, snapVol Volume, newSnapshotName st
 
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
 	oldPath := snapVol.MountPath()
-	newPath := GetVolumeMountPath(d.Name(), snapVol.volType, GetSnapshotVolumeName(parentName, newSnapshotName))
+	newPath := GetVolumeMountPath(d.Name(), d.Config(), snapVol.volType, GetSnapshotVolumeName(parentName, newSnapshotName))
 
 	if util.PathExists(oldPath) {
 		err := os.Rename(oldPath, newPath)
@@ -225,8 +235,13 @@ func genericVFSMigrateVolume(d Driver, s *state.State, vol Volume, conn io.ReadW
 			}
 		}
 
-		d.Logger().Debug("Sending block volume", logger.Ctx{"volName": vol.name, "path": path})
-		_, err = util.SafeCopy(conn, fromPipe)
+		d.Logger().Debug("Sending block volume", logger.Ctx{"volName": vol.name, "path": path, "sparse": volSrcArgs.SparseBlocks})
+		if volSrcArgs.SparseBlocks {
+			err = localMigration.SendSparseBlocks(conn, fromPipe)
+		} else {
+			_, err = util.SafeCopy(conn, fromPipe)
+		}
+
 		if err != nil {
 			return fmt.Errorf("Error copying %q to migration connection: %w", path, err)
 		}
@@ -354,9 +369,26 @@ func genericVFSCreateVolumeFromMigration(d Driver, initVolume func(vol Volume) (
 			}
 		}
 
-		d.Logger().Debug("Receiving block volume started", logger.Ctx{"volName": volName, "path": path})
+		d.Logger().Debug("Receiving block volume started", logger.Ctx{"volName": volName, "path": path, "sparse": volTargetArgs.SparseBlocks})
 		defer d.Logger().Debug("Receiving block volume stopped", logger.Ctx{"volName": volName, "path": path})
 
+		// Only the allocated regions are sent, the disk was reset above so the rest can be skipped
+		// unless the driver requires zeroes to be written.
+		if volTargetArgs.SparseBlocks {
+			size, err := localMigration.ReceiveSparseBlocks(to, fromPipe, d.Info().ZeroUnpack)
+			if err != nil {
+				return fmt.Errorf("Error copying from migration connection to %q: %w", path, err)
+			}
+
+			err = to.Close()
+			if err != nil {
+				return err
+			}
+
+			// Skipped trailing regions don't extend file based volumes.
+			return enlargeVolumeBlockFile(path, size)
+		}
+
 		toPipe := io.Writer(to)
 		if !d.Info().ZeroUnpack {
 			toPipe = NewSparseFileWrapper(to)
@@ -652,7 +684,7 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 
 	if len(snapshots) > 0 {
 		// Create new snapshots directory.
-		err := CreateParentSnapshotDirIfMissing(d.Name(), vol.volType, vol.name)
+		err := CreateParentSnapshotDirIfMissing(d.Name(), d.Config(), vol.volType, vol.name)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -801,7 +833,7 @@ func genericVFSCopyVolume(d Driver, initVolume func(vol Volume) (revert.Hook, er
 		}
 
 		d.Logger().Debug("Copying block volume", logger.Ctx{"srcDevPath": srcDevPath, "targetPath": targetDevPath})
-		err = copyDevice(srcDevPath, targetDevPath)
+		err = CopyDevice(srcDevPath, targetDevPath)
 		if err != nil {
 			return err
 		}
@@ -10,6 +10,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/internal/server/state"
 	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/revert"
 )
@@ -49,6 +50,7 @@ type Driver interface {
 	Validate(config map[string]string) error
 	Update(changedConfig map[string]string) error
 	ApplyPatch(name string) error
+	Patches() []string
 
 	// Buckets.
 	ValidateBucket(bucket Volume) error
@@ -56,13 +58,17 @@ type Driver interface {
 	CreateBucket(bucket Volume, op *operations.Operation) error
 	DeleteBucket(bucket Volume, op *operations.Operation) error
 	UpdateBucket(bucket Volume, changedConfig map[string]string) error
-	ValidateBucketKey(keyName string, creds S3Credentials, roleName string) error
+	GetBucketUsage(bucket Volume) (int64, int64, error)
+	ValidateBucketKey(keyName string, creds S3Credentials, roleName string, pathPrefix string) error
 	CreateBucketKey(bucket Volume, keyName string, creds S3Credentials, roleName string, op *operations.Operation) (*S3Credentials, error)
 	UpdateBucketKey(bucket Volume, keyName string, creds S3Credentials, roleName string, op *operations.Operation) (*S3Credentials, error)
 	DeleteBucketKey(bucket Volume, keyName string, op *operations.Operation) error
+	ListBuckets() ([]string, error)
+	ListBucketKeys(bucket Volume) ([]S3BucketKey, error)
 
 	// Volumes.
 	FillVolumeConfig(vol Volume) error
+	RecommendedVolumeConfig(vol Volume) map[string]string
 	ValidateVolume(vol Volume, removeUnknownKeys bool) error
 	CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Operation) error
 	CreateVolumeFromCopy(vol Volume, srcVol Volume, copySnapshots bool, allowInconsistent bool, op *operations.Operation) error
@@ -71,8 +77,11 @@ type Driver interface {
 	RenameVolume(vol Volume, newName string, op *operations.Operation) error
 	UpdateVolume(vol Volume, changedConfig map[string]string) error
 	GetVolumeUsage(vol Volume) (int64, error)
+	CacheVolumeSnapshots(vol Volume) error
+	GetVolumeIOStats(vol Volume) (*VolumeIOStats, error)
 	SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error
 	GetVolumeDiskPath(vol Volume) (string, error)
+	GetVolumeClones(vol Volume) ([]string, error)
 	ListVolumes() ([]Volume, error)
 
 	// ActivateTask is a low-level access function to get to the underlying storage.
@@ -104,7 +113,7 @@ type Driver interface {
 	DeleteVolumeSnapshot(snapVol Volume, op *operations.Operation) error
 	RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error
 	VolumeSnapshots(vol Volume, op *operations.Operation) ([]string, error)
-	RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error
+	RestoreVolume(vol Volume, snapshotName string, tracker *ioprogress.ProgressTracker, op *operations.Operation) error
 	Qcow2DeletionCleanup(vol Volume, childName string) error
 
 	// Migration.
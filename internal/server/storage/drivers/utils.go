@@ -2,7 +2,11 @@ package drivers
 
 import (
 	"archive/tar"
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -27,6 +31,7 @@ import (
 	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/archive"
 	"github.com/lxc/incus/v7/shared/idmap"
+	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/subprocess"
 	"github.com/lxc/incus/v7/shared/util"
@@ -251,34 +256,93 @@ func GetPoolMountPath(poolName string) string {
 	return internalUtil.VarPath("storage-pools", poolName)
 }
 
+// isSnapshotLayoutSharded returns whether the pool config shards the snapshot directories of its volumes.
+func isSnapshotLayoutSharded(poolConfig map[string]string) bool {
+	return poolConfig["snapshots.layout"] == "sharded"
+}
+
+// snapshotShard returns the name of the shard directory holding the snapshot directory of the parent volume.
+func snapshotShard(parentName string) string {
+	hash := sha256.Sum256([]byte(parentName))
+	return hex.EncodeToString(hash[:1])
+}
+
 // GetVolumeMountPath returns the mount path for a specific volume based on its pool and type and
 // whether it is a snapshot or not. For VolumeTypeImage the volName is the image fingerprint.
-func GetVolumeMountPath(poolName string, volType VolumeType, volName string) string {
+// The pool config is only used to pick the snapshot directory layout and may be nil for non-snapshot volumes.
+func GetVolumeMountPath(poolName string, poolConfig map[string]string, volType VolumeType, volName string) string {
 	if internalInstance.IsSnapshot(volName) {
-		return internalUtil.VarPath("storage-pools", poolName, fmt.Sprintf("%s-snapshots", string(volType)), volName)
+		_, snapName, _ := api.GetParentAndSnapshotName(volName)
+		return filepath.Join(GetVolumeSnapshotDir(poolName, poolConfig, volType, volName), snapName)
 	}
 
 	return internalUtil.VarPath("storage-pools", poolName, string(volType), volName)
 }
 
 // GetVolumeSnapshotDir gets the snapshot mount directory for the parent volume.
-func GetVolumeSnapshotDir(poolName string, volType VolumeType, volName string) string {
+// With the sharded snapshot layout, the directory is placed in a sub-directory named after a hash prefix of the
+// parent volume name, so that the snapshot directories of many volumes aren't all held in a single directory.
+func GetVolumeSnapshotDir(poolName string, poolConfig map[string]string, volType VolumeType, volName string) string {
 	parent, _, _ := api.GetParentAndSnapshotName(volName)
+	if isSnapshotLayoutSharded(poolConfig) {
+		return internalUtil.VarPath("storage-pools", poolName, fmt.Sprintf("%s-snapshots", string(volType)), snapshotShard(parent), parent)
+	}
+
 	return internalUtil.VarPath("storage-pools", poolName, fmt.Sprintf("%s-snapshots", string(volType)), parent)
 }
 
+// ensureSnapshotShardDir creates the shard directory holding the snapshot directory of the parent volume if
+// the pool uses the sharded snapshot layout and it is missing.
+func ensureSnapshotShardDir(poolName string, poolConfig map[string]string, volType VolumeType, volName string) error {
+	if !isSnapshotLayoutSharded(poolConfig) {
+		return nil
+	}
+
+	shardPath := filepath.Dir(GetVolumeSnapshotDir(poolName, poolConfig, volType, volName))
+
+	err := os.Mkdir(shardPath, 0o700)
+	if err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("Failed to create snapshot shard directory %q: %w", shardPath, err)
+	}
+
+	return nil
+}
+
+// DeleteSnapshotShardDirIfEmpty removes the shard directory holding the snapshot directory of the parent volume if
+// the pool uses the sharded snapshot layout and it is empty.
+func DeleteSnapshotShardDirIfEmpty(poolName string, poolConfig map[string]string, volType VolumeType, volName string) error {
+	if !isSnapshotLayoutSharded(poolConfig) {
+		return nil
+	}
+
+	shardPath := filepath.Dir(GetVolumeSnapshotDir(poolName, poolConfig, volType, volName))
+
+	// Removing a directory which isn't empty fails, in which case it is still in use by other volumes.
+	err := os.Remove(shardPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, unix.ENOTEMPTY) && !errors.Is(err, unix.EEXIST) {
+		return fmt.Errorf("Failed to remove snapshot shard directory %q: %w", shardPath, err)
+	}
+
+	return nil
+}
+
 // GetSnapshotVolumeName returns the full volume name for a parent volume and snapshot name.
 func GetSnapshotVolumeName(parentName, snapshotName string) string {
 	return fmt.Sprintf("%s%s%s", parentName, internalInstance.SnapshotDelimiter, snapshotName)
 }
 
 // CreateParentSnapshotDirIfMissing creates the parent directory for volume snapshots.
-func CreateParentSnapshotDirIfMissing(poolName string, volType VolumeType, volName string) error {
-	snapshotsPath := GetVolumeSnapshotDir(poolName, volType, volName)
+func CreateParentSnapshotDirIfMissing(poolName string, poolConfig map[string]string, volType VolumeType, volName string) error {
+	snapshotsPath := GetVolumeSnapshotDir(poolName, poolConfig, volType, volName)
 
 	// If it's missing, create it.
 	if !util.PathExists(snapshotsPath) {
-		err := os.Mkdir(snapshotsPath, 0o700)
+		err := ensureSnapshotShardDir(poolName, poolConfig, volType, volName)
+		if err != nil {
+			return err
+		}
+
+		err = os.Mkdir(snapshotsPath, 0o700)
 		if err != nil {
 			return fmt.Errorf("Failed to create parent snapshot directory %q: %w", snapshotsPath, err)
 		}
@@ -290,9 +354,9 @@ func CreateParentSnapshotDirIfMissing(poolName string, volType VolumeType, volNa
 }
 
 // deleteParentSnapshotDirIfEmpty removes the parent snapshot directory if it is empty.
-// It accepts the pool name, volume type and parent volume name.
-func deleteParentSnapshotDirIfEmpty(poolName string, volType VolumeType, volName string) error {
-	snapshotsPath := GetVolumeSnapshotDir(poolName, volType, volName)
+// It accepts the pool name, pool config, volume type and parent volume name.
+func deleteParentSnapshotDirIfEmpty(poolName string, poolConfig map[string]string, volType VolumeType, volName string) error {
+	snapshotsPath := GetVolumeSnapshotDir(poolName, poolConfig, volType, volName)
 
 	// If it exists, try to delete it.
 	if util.PathExists(snapshotsPath) {
@@ -306,6 +370,8 @@ func deleteParentSnapshotDirIfEmpty(poolName string, volType VolumeType, volName
 			if err != nil && !errors.Is(err, fs.ErrNotExist) {
 				return fmt.Errorf("Failed to remove '%s': %w", snapshotsPath, err)
 			}
+
+			return DeleteSnapshotShardDirIfEmpty(poolName, poolConfig, volType, volName)
 		}
 	}
 
@@ -458,8 +524,33 @@ func makeFSType(path string, fsType string, options *mkfsOptions) (string, error
 	return "", nil
 }
 
-// filesystemTypeCanBeShrunk indicates if filesystems of fsType can be shrunk.
-func filesystemTypeCanBeShrunk(fsType string) bool {
+// FormatBlockFilesystem creates a filesystem of the given type on the block device at path.
+func FormatBlockFilesystem(path string, fsType string) error {
+	msg, err := makeFSType(path, fsType, nil)
+	if err != nil {
+		return fmt.Errorf("Failed formatting %q with %q: %w (%s)", path, fsType, err, msg)
+	}
+
+	return nil
+}
+
+// BlockFilesystemType returns the type of the filesystem found on the block device at path.
+func BlockFilesystemType(path string) (string, error) {
+	return fsProbe(path)
+}
+
+// BlockPartitionTableType returns the type of the partition table found on the block device at path.
+func BlockPartitionTableType(path string) (string, error) {
+	val, err := subprocess.RunCommand("blkid", "-p", "-s", "PTTYPE", "-o", "value", path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(val), nil
+}
+
+// FilesystemTypeCanBeShrunk indicates if filesystems of fsType can be shrunk.
+func FilesystemTypeCanBeShrunk(fsType string) bool {
 	if fsType == "" {
 		fsType = DefaultFilesystem
 	}
@@ -481,7 +572,7 @@ func shrinkFileSystem(fsType string, devPath string, vol Volume, byteSize int64,
 		fsType = DefaultFilesystem
 	}
 
-	if !filesystemTypeCanBeShrunk(fsType) {
+	if !FilesystemTypeCanBeShrunk(fsType) {
 		return ErrCannotBeShrunk
 	}
 
@@ -636,9 +727,8 @@ func regenerateFilesystemXFSUUID(devPath string) error {
 	return nil
 }
 
-// copyDevice copies one device path to another using dd running at low priority.
-// It expects outputPath to exist already, so will not create it.
-func copyDevice(inputPath string, outputPath string) error {
+// copyDeviceCommand returns the dd command used to copy one device path to another.
+func copyDeviceCommand(inputPath string, outputPath string) []string {
 	cmd := []string{
 		"nice", "-n19", // Run dd with low priority to reduce CPU impact on other processes.
 		"dd", fmt.Sprintf("if=%s", inputPath), fmt.Sprintf("of=%s", outputPath),
@@ -659,7 +749,15 @@ func copyDevice(inputPath string, outputPath string) error {
 		_ = to.Close()
 	}
 
-	_, err = subprocess.RunCommand(cmd[0], cmd[1:]...)
+	return cmd
+}
+
+// CopyDevice copies one device path to another using dd running at low priority.
+// It expects outputPath to exist already, so will not create it.
+func CopyDevice(inputPath string, outputPath string) error {
+	cmd := copyDeviceCommand(inputPath, outputPath)
+
+	_, err := subprocess.RunCommand(cmd[0], cmd[1:]...)
 	if err != nil {
 		return err
 	}
@@ -667,6 +765,88 @@ func copyDevice(inputPath string, outputPath string) error {
 	return nil
 }
 
+// CopyDeviceWithProgress copies one device path to another like CopyDevice, reporting progress to tracker.
+// If the tracker has no length set, the size of inputPath is used to report the progress as a percentage.
+func CopyDeviceWithProgress(inputPath string, outputPath string, tracker *ioprogress.ProgressTracker) error {
+	if tracker == nil || tracker.Handler == nil {
+		return CopyDevice(inputPath, outputPath)
+	}
+
+	if tracker.Length <= 0 {
+		size, err := BlockDiskSizeBytes(inputPath)
+		if err == nil {
+			tracker.Length = size
+		}
+	}
+
+	args := append(copyDeviceCommand(inputPath, outputPath), "status=progress")
+	cmd := exec.Command(args[0], args[1:]...)
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	var output strings.Builder
+
+	// dd separates its progress updates with carriage returns rather than newlines.
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		i := bytes.IndexAny(data, "\r\n")
+		if i >= 0 {
+			return i + 1, data[:i], nil
+		}
+
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+
+		return 0, nil, nil
+	})
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Progress lines start with "<bytes> bytes", anything else is kept for error reporting.
+		fields := strings.Fields(line)
+		if len(fields) > 1 && fields[1] == "bytes" {
+			copied, err := strconv.ParseInt(fields[0], 10, 64)
+			if err == nil {
+				var speed int64
+				elapsed := time.Since(start).Seconds()
+				if elapsed > 0 {
+					speed = int64(float64(copied) / elapsed)
+				}
+
+				if tracker.Length > 0 {
+					tracker.Handler(min(copied*100/tracker.Length, 100), speed)
+				} else {
+					tracker.Handler(copied, speed)
+				}
+
+				continue
+			}
+		}
+
+		if line != "" {
+			output.WriteString(line + "\n")
+		}
+	}
+
+	err = cmd.Wait()
+	if err != nil {
+		return fmt.Errorf("Failed copying %q to %q: %w (%s)", inputPath, outputPath, err, strings.TrimSpace(output.String()))
+	}
+
+	return nil
+}
+
 // loopFilePath returns the loop file path for a storage pool.
 func loopFilePath(poolName string) string {
 	return filepath.Join(internalUtil.VarPath("disks"), fmt.Sprintf("%s.img", poolName))
@@ -801,6 +981,43 @@ func ShiftZFSSkipper(dir string, absPath string, fi os.FileInfo, newuid int64, n
 	return nil
 }
 
+// blockDeviceIOStats returns the cumulative IO counters of a block device from sysfs.
+func blockDeviceIOStats(devPath string) (*VolumeIOStats, error) {
+	devPath, err := filepath.EvalSymlinks(devPath)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(filepath.Join("/sys/class/block", filepath.Base(devPath), "stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	// See https://www.kernel.org/doc/Documentation/block/stat.txt for the field layout.
+	fields := strings.Fields(string(content))
+	if len(fields) < 7 {
+		return nil, fmt.Errorf("Unexpected block device statistics for %q", devPath)
+	}
+
+	values := make([]uint64, 7)
+	for i := range values {
+		values[i], err = strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing block device statistics for %q: %w", devPath, err)
+		}
+	}
+
+	// Sectors are always 512 bytes in the block layer statistics.
+	stats := &VolumeIOStats{
+		ReadOps:    values[0],
+		ReadBytes:  values[2] * 512,
+		WriteOps:   values[4],
+		WriteBytes: values[6] * 512,
+	}
+
+	return stats, nil
+}
+
 // BlockDiskSizeBytes returns the size of a block disk (path can be either block device or raw file).
 func BlockDiskSizeBytes(blockDiskPath string) (int64, error) {
 	if linux.IsBlockdevPath(blockDiskPath) {
@@ -1027,6 +1244,17 @@ func ValidateDependentConfigKey(cfg map[string]string) error {
 	return nil
 }
 
+// DefaultVMBlockFilesystemSize returns the default size of the filesystem volume of VM block volumes on the
+// pool. This is the pool's "volume.vm.state_size" setting if set, otherwise the driver's default.
+func DefaultVMBlockFilesystemSize(d Driver) string {
+	size := d.Config()["volume.vm.state_size"]
+	if size != "" {
+		return size
+	}
+
+	return d.Info().DefaultVMBlockFilesystemSize
+}
+
 // BackupPrefix returns backup prefix based on volume type.
 func BackupPrefix(vol Volume) string {
 	backupPrefix := "container"
@@ -1331,3 +1559,14 @@ func UnpackVolume(d Driver, vol Volume, r io.ReadSeeker, tarArgs []string, unpac
 
 	return nil
 }
+
+// validateMountOptions validates a comma-separated list of filesystem mount options.
+func validateMountOptions(value string) error {
+	for _, option := range strings.Split(value, ",") {
+		if option == "" || strings.ContainsAny(option, " \t\n") {
+			return fmt.Errorf("Invalid mount option %q", option)
+		}
+	}
+
+	return nil
+}
@@ -347,7 +347,7 @@ func Qcow2DeleteConfigSnapshot(vol Volume, snapVol Volume, op *operations.Operat
 
 	// Remove the parent snapshot directory if this is the last snapshot being removed.
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
-	err = deleteParentSnapshotDirIfEmpty(snapVol.pool, snapVol.volType, parentName)
+	err = deleteParentSnapshotDirIfEmpty(snapVol.pool, snapVol.poolConfig, snapVol.volType, parentName)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package drivers
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,27 +13,87 @@ func TestGetVolumeMountPath(t *testing.T) {
 	poolName := "testpool"
 
 	// Test custom volume.
-	path := GetVolumeMountPath(poolName, VolumeTypeCustom, "testvol")
+	path := GetVolumeMountPath(poolName, nil, VolumeTypeCustom, "testvol")
 	expected := GetPoolMountPath(poolName) + "/custom/testvol"
 	assert.Equal(t, expected, path)
 
 	// Test custom volume snapshot.
-	path = GetVolumeMountPath(poolName, VolumeTypeCustom, "testvol/snap1")
+	path = GetVolumeMountPath(poolName, nil, VolumeTypeCustom, "testvol/snap1")
 	expected = GetPoolMountPath(poolName) + "/custom-snapshots/testvol/snap1"
 	assert.Equal(t, expected, path)
 
 	// Test image volume.
-	path = GetVolumeMountPath(poolName, VolumeTypeImage, "fingerprint")
+	path = GetVolumeMountPath(poolName, nil, VolumeTypeImage, "fingerprint")
 	expected = GetPoolMountPath(poolName) + "/images/fingerprint"
 	assert.Equal(t, expected, path)
 
 	// Test container volume.
-	path = GetVolumeMountPath(poolName, VolumeTypeContainer, "testvol")
+	path = GetVolumeMountPath(poolName, nil, VolumeTypeContainer, "testvol")
 	expected = GetPoolMountPath(poolName) + "/containers/testvol"
 	assert.Equal(t, expected, path)
 
 	// Test virtual-machine volume.
-	path = GetVolumeMountPath(poolName, VolumeTypeVM, "testvol")
+	path = GetVolumeMountPath(poolName, nil, VolumeTypeVM, "testvol")
 	expected = GetPoolMountPath(poolName) + "/virtual-machines/testvol"
 	assert.Equal(t, expected, path)
 }
+
+// Test validateMountOptions.
+func Test_validateMountOptions(t *testing.T) {
+	assert.NoError(t, validateMountOptions("noatime"))
+	assert.NoError(t, validateMountOptions("noatime,acl,commit=60"))
+	assert.Error(t, validateMountOptions("noatime,"))
+	assert.Error(t, validateMountOptions("noatime,,acl"))
+	assert.Error(t, validateMountOptions("noatime acl"))
+}
+
+// Test GetVolumeSnapshotDir with the sharded snapshot layout.
+func TestGetVolumeSnapshotDir_Sharded(t *testing.T) {
+	poolName := "testpool-sharded"
+	poolConfig := map[string]string{"snapshots.layout": "sharded"}
+
+	shard := snapshotShard("testvol")
+	assert.Len(t, shard, 2)
+
+	// Test snapshot directory of the parent volume.
+	path := GetVolumeSnapshotDir(poolName, poolConfig, VolumeTypeContainer, "testvol/snap1")
+	expected := GetPoolMountPath(poolName) + "/containers-snapshots/" + shard + "/testvol"
+	assert.Equal(t, expected, path)
+
+	// Test snapshot mount path.
+	path = GetVolumeMountPath(poolName, poolConfig, VolumeTypeContainer, "testvol/snap1")
+	assert.Equal(t, expected+"/snap1", path)
+
+	// Test the volume itself isn't sharded.
+	path = GetVolumeMountPath(poolName, poolConfig, VolumeTypeContainer, "testvol")
+	assert.Equal(t, GetPoolMountPath(poolName)+"/containers/testvol", path)
+
+	// Test the flat layout.
+	path = GetVolumeSnapshotDir(poolName, map[string]string{"snapshots.layout": "flat"}, VolumeTypeContainer, "testvol/snap1")
+	assert.Equal(t, GetPoolMountPath(poolName)+"/containers-snapshots/testvol", path)
+}
+
+// Test the shard directory is removed along with the last parent snapshot directory it holds.
+func TestDeleteParentSnapshotDirIfEmpty_Sharded(t *testing.T) {
+	t.Setenv("INCUS_DIR", t.TempDir())
+
+	poolName := "testpool-sharded"
+	poolConfig := map[string]string{"snapshots.layout": "sharded"}
+
+	err := os.MkdirAll(filepath.Join(GetPoolMountPath(poolName), "custom-snapshots"), 0o700)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = CreateParentSnapshotDirIfMissing(poolName, poolConfig, VolumeTypeCustom, "testvol")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	shardPath := filepath.Dir(GetVolumeSnapshotDir(poolName, poolConfig, VolumeTypeCustom, "testvol"))
+	assert.DirExists(t, shardPath)
+
+	err = deleteParentSnapshotDirIfEmpty(poolName, poolConfig, VolumeTypeCustom, "testvol")
+	assert.NoError(t, err)
+	assert.NoDirExists(t, shardPath)
+}
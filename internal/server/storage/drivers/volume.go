@@ -12,6 +12,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/pkg/sftp"
@@ -37,6 +38,15 @@ const tmpVolSuffix = ".incustmp"
 // isoVolSuffix suffix used for iso content type volumes.
 const isoVolSuffix = ".iso"
 
+// diskPathCache holds the disk paths of mounted volumes, keyed by the volume's mount lock name.
+// Entries are dropped whenever a volume's mount ref counter reaches zero.
+var diskPathCache = map[string]string{}
+
+// diskPathCacheGen is incremented whenever an entry is dropped from diskPathCache.
+var diskPathCacheGen uint64
+
+var diskPathCacheMu sync.Mutex
+
 // DefaultBlockSize is the default size of block volumes.
 const DefaultBlockSize = "10GiB"
 
@@ -197,7 +207,7 @@ func (v Volume) MountPath() string {
 		volName = fmt.Sprintf("%s%s", volName, isoVolSuffix)
 	}
 
-	return GetVolumeMountPath(v.pool, v.volType, volName)
+	return GetVolumeMountPath(v.pool, v.poolConfig, v.volType, volName)
 }
 
 // mountLockName returns the lock name to use for mount/unmount operations on a volume.
@@ -217,7 +227,12 @@ func (v Volume) MountRefCountIncrement() uint {
 
 // MountRefCountDecrement decrements the mount ref counter for the volume and returns the new value.
 func (v Volume) MountRefCountDecrement() uint {
-	return refcount.Decrement(v.mountLockName(), 1)
+	refCount := refcount.Decrement(v.mountLockName(), 1)
+	if refCount == 0 {
+		v.ClearCachedDiskPath()
+	}
+
+	return refCount
 }
 
 // MountInUse returns whether the volume has a mount ref counter >0.
@@ -225,6 +240,39 @@ func (v Volume) MountInUse() bool {
 	return refcount.Get(v.mountLockName()) > 0
 }
 
+// CachedDiskPath returns the cached disk path of the volume along with the cache generation.
+// The generation must be passed to SetCachedDiskPath when caching a newly retrieved path.
+func (v Volume) CachedDiskPath() (string, uint64, bool) {
+	diskPathCacheMu.Lock()
+	defer diskPathCacheMu.Unlock()
+
+	path, found := diskPathCache[v.mountLockName()]
+
+	return path, diskPathCacheGen, found
+}
+
+// SetCachedDiskPath caches the disk path of the volume if it's still mounted and no entry was dropped
+// from the cache since gen was retrieved.
+func (v Volume) SetCachedDiskPath(path string, gen uint64) {
+	diskPathCacheMu.Lock()
+	defer diskPathCacheMu.Unlock()
+
+	if gen != diskPathCacheGen || !v.MountInUse() {
+		return
+	}
+
+	diskPathCache[v.mountLockName()] = path
+}
+
+// ClearCachedDiskPath drops the cached disk path of the volume.
+func (v Volume) ClearCachedDiskPath() {
+	diskPathCacheMu.Lock()
+	defer diskPathCacheMu.Unlock()
+
+	delete(diskPathCache, v.mountLockName())
+	diskPathCacheGen++
+}
+
 // EnsureMountPath creates the volume's mount path if missing, then sets the correct permission for the type.
 // If permission setting fails and the volume is a snapshot then the error is ignored as snapshots are read only.
 // The boolean flag indicates whether this is being called during volume creation.
@@ -239,7 +287,7 @@ func (v Volume) EnsureMountPath(creation bool) error {
 		if v.IsSnapshot() {
 			// Create the parent directory if needed.
 			parentName, _, _ := api.GetParentAndSnapshotName(v.name)
-			err := CreateParentSnapshotDirIfMissing(v.pool, v.volType, parentName)
+			err := CreateParentSnapshotDirIfMissing(v.pool, v.poolConfig, v.volType, parentName)
 			if err != nil {
 				return err
 			}
@@ -606,7 +654,8 @@ func (v Volume) ConfigBlockFilesystem() string {
 }
 
 // ConfigBlockMountOptions returns the filesystem mount options to use for block volumes. Returns config value
-// "block.mount_options" if defined in volume or pool's volume config, otherwise defaultFilesystemMountOptions.
+// "block.mount_options" if defined in volume or pool's volume config, otherwise defaultFilesystemMountOptions,
+// followed by the options from ConfigMountOptions.
 func (v Volume) ConfigBlockMountOptions() string {
 	if v.ExpandedConfig("block.type") == BlockVolumeTypeQcow2 && !v.mountFullFilesystem {
 		_, snapName, isSnap := api.GetParentAndSnapshotName(v.name)
@@ -618,17 +667,32 @@ func (v Volume) ConfigBlockMountOptions() string {
 		return fmt.Sprintf("subvol=%s", subvol)
 	}
 
-	fs := v.ExpandedConfig("block.mount_options")
-	if fs != "" {
-		return fs
+	options := v.ExpandedConfig("block.mount_options")
+	if options == "" {
+		// Use some special options if the filesystem for the volume is BTRFS.
+		if v.ConfigBlockFilesystem() == "btrfs" {
+			options = "user_subvol_rm_allowed,discard"
+		} else {
+			options = defaultFilesystemMountOptions
+		}
+	}
+
+	return strings.Join(append([]string{options}, v.ConfigMountOptions()...), ",")
+}
+
+// ConfigMountOptions returns the additional filesystem mount options set in the "mount.options" config key of
+// the volume or pool's volume config. Only custom filesystem volumes use these options.
+func (v Volume) ConfigMountOptions() []string {
+	if v.volType != VolumeTypeCustom || v.contentType != ContentTypeFS {
+		return nil
 	}
 
-	// Use some special options if the filesystem for the volume is BTRFS.
-	if v.ConfigBlockFilesystem() == "btrfs" {
-		return "user_subvol_rm_allowed,discard"
+	options := v.ExpandedConfig("mount.options")
+	if options == "" {
+		return nil
 	}
 
-	return defaultFilesystemMountOptions
+	return strings.Split(options, ",")
 }
 
 // ConfigSize returns the size to use when creating new a volume. Returns config value "size" if defined in volume
@@ -125,3 +125,82 @@ func Test_Volume_ConfigSizeFromSource(t *testing.T) {
 		assert.Equal(t, test.err, err)
 	}
 }
+
+// Test Volume_CachedDiskPath.
+func Test_Volume_CachedDiskPath(t *testing.T) {
+	vol := Volume{pool: "pool1", volType: VolumeTypeVM, contentType: ContentTypeBlock, name: "vm1"}
+
+	// Volumes that aren't mounted aren't cached.
+	_, gen, found := vol.CachedDiskPath()
+	assert.False(t, found)
+
+	vol.SetCachedDiskPath("/dev/sda", gen)
+	_, _, found = vol.CachedDiskPath()
+	assert.False(t, found)
+
+	// Mounted volumes are cached.
+	vol.MountRefCountIncrement()
+	_, gen, _ = vol.CachedDiskPath()
+	vol.SetCachedDiskPath("/dev/sda", gen)
+	path, _, found := vol.CachedDiskPath()
+	assert.True(t, found)
+	assert.Equal(t, "/dev/sda", path)
+
+	// Unmounting drops the cached path.
+	vol.MountRefCountDecrement()
+	_, _, found = vol.CachedDiskPath()
+	assert.False(t, found)
+
+	// Paths retrieved before an entry was dropped aren't cached.
+	vol.MountRefCountIncrement()
+	_, gen, _ = vol.CachedDiskPath()
+	vol.ClearCachedDiskPath()
+	vol.SetCachedDiskPath("/dev/sdb", gen)
+	_, _, found = vol.CachedDiskPath()
+	assert.False(t, found)
+
+	vol.MountRefCountDecrement()
+}
+
+// Test Volume_ConfigBlockMountOptions.
+func Test_Volume_ConfigBlockMountOptions(t *testing.T) {
+	tests := []struct {
+		vol     Volume
+		options string
+	}{
+		{
+			// Check the default options are used when nothing is set.
+			vol:     Volume{config: map[string]string{"block.filesystem": "ext4"}},
+			options: defaultFilesystemMountOptions,
+		},
+		{
+			// Check mount.options is added to the default options.
+			vol:     Volume{volType: VolumeTypeCustom, contentType: ContentTypeFS, config: map[string]string{"block.filesystem": "ext4", "mount.options": "noatime,acl"}},
+			options: defaultFilesystemMountOptions + ",noatime,acl",
+		},
+		{
+			// Check mount.options is added to block.mount_options.
+			vol:     Volume{volType: VolumeTypeCustom, contentType: ContentTypeFS, config: map[string]string{"block.mount_options": "discard", "mount.options": "noatime"}},
+			options: "discard,noatime",
+		},
+		{
+			// Check the pool's volume.mount.options is used when the volume doesn't set it.
+			vol:     Volume{volType: VolumeTypeCustom, contentType: ContentTypeFS, config: map[string]string{"block.mount_options": "discard"}, poolConfig: map[string]string{"volume.mount.options": "noatime"}},
+			options: "discard,noatime",
+		},
+		{
+			// Check the pool's volume.mount.options isn't applied to instance volumes.
+			vol:     Volume{volType: VolumeTypeContainer, contentType: ContentTypeFS, config: map[string]string{"block.mount_options": "discard"}, poolConfig: map[string]string{"volume.mount.options": "noatime"}},
+			options: "discard",
+		},
+		{
+			// Check the pool's volume.mount.options isn't applied to custom block volumes.
+			vol:     Volume{volType: VolumeTypeCustom, contentType: ContentTypeBlock, config: map[string]string{"block.mount_options": "discard"}, poolConfig: map[string]string{"volume.mount.options": "noatime"}},
+			options: "discard",
+		},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.options, tc.vol.ConfigBlockMountOptions(), "test %d", i)
+	}
+}
@@ -2,6 +2,9 @@ package storage
 
 import (
 	"errors"
+	"net/http"
+
+	"github.com/lxc/incus/v7/shared/api"
 )
 
 // ErrNilValue is the "Nil value provided" error.
@@ -12,3 +15,6 @@ var ErrBackupSnapshotsMismatch = errors.New("Backup snapshots mismatch")
 
 // ErrVolumeNotAttachedToRunningInstance is the "Volume is not attached to running instance" error.
 var ErrVolumeNotAttachedToRunningInstance = errors.New("Volume is not attached to running instance")
+
+// ErrBusy is the "Another snapshot of the instance is in progress" error.
+var ErrBusy = api.StatusErrorf(http.StatusServiceUnavailable, "Another snapshot of the instance is in progress")
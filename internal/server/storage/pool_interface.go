@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"io"
 	"net"
 	"net/url"
@@ -20,8 +21,16 @@ import (
 
 // VolumeUsage contains the used and total size of a volume.
 type VolumeUsage struct {
-	Used  int64
-	Total int64
+	Used    int64
+	Total   int64
+	Objects int64 // Only set for buckets.
+}
+
+// StaleBackupFile describes an instance whose backup.yaml file doesn't match its current config.
+type StaleBackupFile struct {
+	Project  string
+	Instance string
+	Reason   string
 }
 
 // MountInfo represents info about the result of a mount operation.
@@ -29,6 +38,70 @@ type MountInfo struct {
 	DiskPath    string                               // The location of the block disk (if supported).
 	BackingPath []string                             // The location of the block disk (backing disk for qcow2).
 	PostHooks   []func(inst instance.Instance) error // Hooks to be called following a mount.
+	MountPath   string                               // The location of the mounted filesystem (if not the instance path).
+}
+
+// EnsureImageResult describes what EnsureImage did with the optimized image volume.
+type EnsureImageResult string
+
+const (
+	// EnsureImageNone means no optimized image volume was needed or an error occurred.
+	EnsureImageNone EnsureImageResult = ""
+
+	// EnsureImageCreated means a new optimized image volume was created.
+	EnsureImageCreated EnsureImageResult = "created"
+
+	// EnsureImageRegenerated means an existing optimized image volume was replaced to match the pool settings.
+	EnsureImageRegenerated EnsureImageResult = "regenerated"
+
+	// EnsureImageReused means an existing optimized image volume was left untouched.
+	EnsureImageReused EnsureImageResult = "reused"
+)
+
+// Capabilities describes the features supported by a storage pool.
+type Capabilities struct {
+	Buckets           bool // Whether storage buckets can be created.
+	Snapshots         bool // Whether volumes can be snapshotted.
+	OptimizedImages   bool // Whether images are stored as volumes that new instances are cloned from.
+	Remote            bool // Whether volumes are on remote storage shared by all cluster members.
+	Shrinkable        bool // Whether filesystem volumes can be shrunk with the pool's default settings.
+	RunningCopyFreeze bool // Whether running instances must be frozen while being snapshotted or copied.
+	Delegation        bool // Whether volumes can be delegated to instances.
+	Encryption        bool // Whether natively encrypted volumes are preserved when copied or migrated.
+}
+
+// PatchInfo describes a storage patch known to a pool.
+type PatchInfo struct {
+	Name    string // Name of the patch.
+	Applied bool   // Whether the patch has been applied on this server.
+	Early   bool   // Whether the patch has a backend component run before the driver patch.
+	Late    bool   // Whether the patch has a backend component run after the driver patch.
+}
+
+// HealthCheckResult describes the outcome of a storage pool health check.
+type HealthCheckResult struct {
+	Healthy   bool                      // Whether the pool is usable on this server.
+	Mounted   bool                      // Whether the pool had to be mounted by the check.
+	Resources *api.ResourcesStoragePool // Pool resources as reported by the driver (if available).
+	Error     error                     // Reason for the pool being unhealthy.
+}
+
+// ActiveBucket describes a local bucket whose volume is held mounted by in-flight S3 requests.
+type ActiveBucket struct {
+	Project    string // Project of the bucket.
+	Name       string // Name of the bucket.
+	References int    // Number of mounts held on the bucket volume.
+}
+
+// ForeignVolume describes a disk created outside of Incus that is to be imported as a storage volume.
+type ForeignVolume struct {
+	Project     string              // Project the volume belongs to.
+	Name        string              // Custom volume name or, for instance volumes, the existing instance's name.
+	Type        drivers.VolumeType  // Custom, container or virtual-machine.
+	ContentType drivers.ContentType // Filesystem or block, defaults to the instance's content type.
+	Size        int64               // Volume size in bytes, defaults to the size of a block source.
+	Source      string              // Disk image, block device or directory to copy from (only used with Copy).
+	Copy        bool                // Copy the source into a new volume rather than adopting one already on the pool.
 }
 
 // Type represents an Incus storage pool type.
@@ -44,6 +117,7 @@ type Pool interface {
 	ID() int64
 	Name() string
 	Driver() drivers.Driver
+	Capabilities() Capabilities
 	Description() string
 	Status() string
 	LocalStatus() string
@@ -51,60 +125,83 @@ type Pool interface {
 
 	GetResources() (*api.ResourcesStoragePool, error)
 	IsUsed() (bool, error)
-	Delete(clientType request.ClientType, op *operations.Operation) error
+	Delete(clientType request.ClientType, force bool, op *operations.Operation) error
 	Update(clientType request.ClientType, newDesc string, newConfig map[string]string, op *operations.Operation) error
+	SetDescription(newDesc string, op *operations.Operation) error
+	PreviewUpdate(newDesc string, newConfig map[string]string) (*api.StoragePoolUpdatePreview, error)
 
 	Create(clientType request.ClientType, op *operations.Operation) error
 	Mount() (bool, error)
 	Unmount() (bool, error)
+	CleanupSnapshotWritableClones() error
+	WaitUntilReady(ctx context.Context) error
+	HealthCheck(ctx context.Context) (*HealthCheckResult, error)
+	ExportPoolManifest(op *operations.Operation) (*api.StoragePoolManifest, error)
+	ReconcileAuthorizerVolumes(op *operations.Operation) error
+	BeginMaintenanceSession(deferAuthorizer bool) error
+	CommitMaintenanceSession() error
+	AbortMaintenanceSession()
 
 	ApplyPatch(name string) error
+	ListPatches() ([]PatchInfo, error)
 
 	GetVolume(volumeType drivers.VolumeType, contentType drivers.ContentType, name string, config map[string]string) drivers.Volume
+	VolumeConfigDefaults(volumeType drivers.VolumeType, contentType drivers.ContentType) (map[string]string, error)
 
 	// Instances.
 	CreateInstance(inst instance.Instance, op *operations.Operation) error
 	CreateInstanceFromCopy(inst instance.Instance, src instance.Instance, snapshots bool, allowInconsistent bool, op *operations.Operation) error
-	CreateInstanceFromImage(inst instance.Instance, fingerprint string, op *operations.Operation) error
+	CreateInstanceFromImage(inst instance.Instance, fingerprint string, forceNonOptimized bool, op *operations.Operation) error
 	CreateInstanceFromMigration(inst instance.Instance, conn io.ReadWriteCloser, args migration.VolumeTargetArgs, op *operations.Operation) error
 	RenameInstance(inst instance.Instance, newName string, op *operations.Operation) error
-	DeleteInstance(inst instance.Instance, op *operations.Operation) error
+	DeleteInstance(inst instance.Instance, force bool, op *operations.Operation) error
 	UpdateInstance(inst instance.Instance, newDesc string, newConfig map[string]string, op *operations.Operation) error
 	UpdateInstanceBackupFile(inst instance.Instance, snapshots bool, op *operations.Operation) error
+	DetectStaleBackupFiles(op *operations.Operation) ([]StaleBackupFile, error)
 	GenerateInstanceBackupConfig(inst instance.Instance, snapshots bool, dependentVolumes bool, op *operations.Operation) (*backupConfig.Config, error)
+	CheckInstanceSnapshotConsistency(inst instance.Instance) (*api.SnapshotConsistencyReport, error)
 	CheckInstanceBackupFileSnapshots(backupConf *backupConfig.Config, projectName string, deleteMissing bool, op *operations.Operation) ([]*api.InstanceSnapshot, error)
 	ImportInstance(inst instance.Instance, poolVol *backupConfig.Config, op *operations.Operation) (revert.Hook, error)
+	MoveInstanceToMember(inst instance.Instance, targetMember string, op *operations.Operation) error
 	CleanupInstancePaths(inst instance.Instance, op *operations.Operation) error
 
 	MigrateInstance(inst instance.Instance, conn io.ReadWriteCloser, args *migration.VolumeSourceArgs, op *operations.Operation) error
-	RefreshInstance(inst instance.Instance, src instance.Instance, srcSnapshots []instance.Instance, allowInconsistent bool, op *operations.Operation) error
+	RefreshInstance(inst instance.Instance, src instance.Instance, srcSnapshots []instance.Instance, allowInconsistent bool, configOnly bool, op *operations.Operation) error
 
 	GetInstanceUsage(inst instance.Instance) (*VolumeUsage, error)
+	GetInstanceIOStats(inst instance.Instance) (*drivers.VolumeIOStats, error)
+	EffectiveInstanceRootSize(inst instance.Instance) (int64, error)
 	SetInstanceQuota(inst instance.Instance, size string, vmStateSize string, op *operations.Operation) error
+	RepairVMConfigVolume(inst instance.Instance, op *operations.Operation) (bool, error)
 
 	MountInstance(inst instance.Instance, op *operations.Operation) (*MountInfo, error)
 	UnmountInstance(inst instance.Instance, op *operations.Operation) error
+	InstanceMountPath(inst instance.Instance) (string, error)
 
 	// Instance snapshots.
 	CanRestoreInstanceSnapshot(inst instance.Instance, src instance.Instance) error
-	CreateInstanceSnapshot(inst instance.Instance, src instance.Instance, op *operations.Operation) error
+	CreateInstanceSnapshot(inst instance.Instance, src instance.Instance, force bool, nowait bool, configOverrides map[string]string, op *operations.Operation) error
 	RenameInstanceSnapshot(inst instance.Instance, newName string, op *operations.Operation) error
 	DeleteInstanceSnapshot(inst instance.Instance, op *operations.Operation) error
-	RestoreInstanceSnapshot(inst instance.Instance, src instance.Instance, op *operations.Operation) error
+	RestoreInstanceSnapshot(inst instance.Instance, src instance.Instance, safetySnapshot bool, op *operations.Operation) (string, error)
 	MountInstanceSnapshot(inst instance.Instance, op *operations.Operation) (*MountInfo, error)
 	UnmountInstanceSnapshot(inst instance.Instance, op *operations.Operation) error
+	MountInstanceSnapshotWritableClone(inst instance.Instance, op *operations.Operation) (*MountInfo, func() error, error)
+	GetInstanceSnapshotUsage(inst instance.Instance) (int64, error)
 	UpdateInstanceSnapshot(inst instance.Instance, newDesc string, newConfig map[string]string, op *operations.Operation) error
 
 	// Instance backups.
-	BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, dependentVolumes bool, op *operations.Operation) error
+	BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, optimizedStrict bool, snapshots bool, dependentVolumes bool, checksum bool, op *operations.Operation) error
 	CreateInstanceFromBackup(srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (func(instance.Instance) error, revert.Hook, error)
 	GetInstanceNBD(inst instance.Instance, writable bool) (net.Conn, func(), error)
 	GetInstanceAllDisksNBD(inst instance.Instance, reuse bool) (net.Conn, func(), error)
 
 	// Images.
-	EnsureImage(fingerprint string, op *operations.Operation) error
-	DeleteImage(fingerprint string, op *operations.Operation) error
+	EnsureImage(fingerprint string, op *operations.Operation) (EnsureImageResult, error)
+	DeleteImage(fingerprint string, force bool, op *operations.Operation) error
+	GarbageCollectImages(dryRun bool, op *operations.Operation) ([]string, error)
 	UpdateImage(fingerprint string, newDesc string, newConfig map[string]string, op *operations.Operation) error
+	RecomputeImageVolumeSize(fingerprint string, op *operations.Operation) (bool, error)
 
 	// Buckets.
 	CreateBucket(projectName string, bucket api.StorageBucketsPost, op *operations.Operation) error
@@ -114,37 +211,57 @@ type Pool interface {
 	CreateBucketKey(projectName string, bucketName string, key api.StorageBucketKeysPost, op *operations.Operation) (*api.StorageBucketKey, error)
 	UpdateBucketKey(projectName string, bucketName string, keyName string, key api.StorageBucketKeyPut, op *operations.Operation) error
 	DeleteBucketKey(projectName string, bucketName string, keyName string, op *operations.Operation) error
+	ExportBucketKeys(projectName string, bucketName string) ([]api.StorageBucketKey, error)
+	ImportBucketKeysToStorage(projectName string, bucketName string, op *operations.Operation) error
 	MountLocalBucket(projectName string, bucketName string, op *operations.Operation) (string, func() error, error)
+	ListActiveBuckets() ([]ActiveBucket, error)
+	StopBucketProcess(projectName string, bucketName string) error
 	GetBucketURL(bucketName string) *url.URL
+	GetBucketUsage(projectName string, bucketName string) (*VolumeUsage, error)
 	GenerateBucketBackupConfig(projectName string, bucketName string, op *operations.Operation) (*backupConfig.Config, error)
 	BackupBucket(projectName string, bucketName string, tarWriter *instancewriter.InstanceTarWriter, op *operations.Operation) error
 	CreateBucketFromBackup(srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) error
+	ExportBucket(projectName string, bucketName string, writer io.Writer, includeObjects bool, op *operations.Operation) error
+	ImportBucketFromExport(projectName string, srcData io.ReadSeeker, op *operations.Operation) error
 
 	// Custom volumes.
-	CreateCustomVolume(projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, op *operations.Operation) error
-	CreateCustomVolumeFromCopy(projectName string, srcProjectName string, volName, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, op *operations.Operation) error
+	CreateCustomVolume(projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, template string, op *operations.Operation) error
+	CreateCustomVolumeFromCopy(projectName string, srcProjectName string, volName, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, onlySnapshots []string, targetContentType drivers.ContentType, verify bool, op *operations.Operation) error
 	UpdateCustomVolume(projectName string, volName string, newDesc string, newConfig map[string]string, op *operations.Operation) error
+	GetVolumeTags(projectName string, volName string, volType drivers.VolumeType) (map[string]string, error)
+	SetVolumeTags(projectName string, volName string, volType drivers.VolumeType, tags map[string]string, op *operations.Operation) error
 	RenameCustomVolume(projectName string, volName string, newVolName string, op *operations.Operation) error
+	ListCustomVolumeBackups(projectName string, volName string) ([]api.StorageVolumeBackup, error)
+	MoveCustomVolumeToProject(projectName string, volName string, targetProjectName string, op *operations.Operation) error
 	DeleteCustomVolume(projectName string, volName string, op *operations.Operation) error
 	RebuildCustomVolume(projectName string, volName string, op *operations.Operation) error
 	GetCustomVolumeDisk(projectName string, volName string) (string, error)
 	GetCustomVolumeUsage(projectName string, volName string) (*VolumeUsage, error)
+	GetCustomVolumeIOStats(projectName string, volName string) (*drivers.VolumeIOStats, error)
+	ExportCustomVolumeData(projectName string, volName string, w io.Writer, op *operations.Operation) error
 	MountCustomVolume(projectName string, volName string, op *operations.Operation) (*MountInfo, error)
 	UnmountCustomVolume(projectName string, volName string, op *operations.Operation) (bool, error)
 	ImportCustomVolume(projectName string, poolVol *backupConfig.Config, op *operations.Operation) (revert.Hook, error)
 	RefreshCustomVolume(projectName string, srcProjectName string, volName, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, excludeOlder bool, op *operations.Operation) error
+	RefreshOrCreateCustomVolume(projectName string, srcProjectName string, volName, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, onlySnapshots []string, excludeOlder bool, op *operations.Operation) (bool, error)
 	GenerateCustomVolumeBackupConfig(projectName string, volName string, snapshots bool, op *operations.Operation) (*backupConfig.Config, error)
 	CreateCustomVolumeFromISO(projectName string, volName string, srcData io.ReadSeeker, size int64, op *operations.Operation) error
+	ImportForeignVolume(spec ForeignVolume, op *operations.Operation) error
 
 	// Custom volume snapshots.
-	CreateCustomVolumeSnapshot(projectName string, volName string, newSnapshotName string, newExpiryDate time.Time, instanceStateful bool, op *operations.Operation) error
+	CreateCustomVolumeSnapshot(projectName string, volName string, newSnapshotName string, newExpiryDate time.Time, instanceStateful bool, force bool, auto bool, quiesce bool, op *operations.Operation) error
 	RenameCustomVolumeSnapshot(projectName string, volName string, newSnapshotName string, op *operations.Operation) error
 	DeleteCustomVolumeSnapshot(projectName string, volName string, op *operations.Operation) error
+	DeleteCustomVolumeSnapshots(projectName string, snapshotNames []string, workers int, op *operations.Operation) error
 	UpdateCustomVolumeSnapshot(projectName string, volName string, newDesc string, newConfig map[string]string, newExpiryDate time.Time, op *operations.Operation) error
-	RestoreCustomVolume(projectName string, volName string, snapshotName string, op *operations.Operation) error
+	ApplyRetentionPolicy(projectName string, volName string, volType drivers.VolumeType, op *operations.Operation) (int, error)
+	RestoreCustomVolume(projectName string, volName string, snapshotName string, safetySnapshot bool, op *operations.Operation) (string, error)
+	RestoreCustomVolumeFiles(projectName string, volName string, snapshotName string, paths []string, op *operations.Operation) error
+	PromoteCustomVolumeSnapshot(projectName string, volName string, snapshotName string, newVolName string, deleteSnapshot bool, op *operations.Operation) (bool, error)
 
 	// Custom volume migration.
 	MigrationTypes(contentType drivers.ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []migration.Type
+	EffectiveMigrationTypes(srcPool Pool, contentType drivers.ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) (string, error)
 	CreateCustomVolumeFromMigration(projectName string, conn io.ReadWriteCloser, args migration.VolumeTargetArgs, op *operations.Operation) error
 	MigrateCustomVolume(projectName string, conn io.ReadWriteCloser, args *migration.VolumeSourceArgs, op *operations.Operation) error
 
@@ -154,5 +271,6 @@ type Pool interface {
 	GetCustomVolumeNBD(projectName string, volName string, writable bool) (net.Conn, func(), error)
 
 	// Storage volume recovery.
-	ListUnknownVolumes(op *operations.Operation) (map[string][]*backupConfig.Config, error)
+	ListUnknownVolumes(bestEffort bool, op *operations.Operation) (map[string][]*backupConfig.Config, error)
+	RecoveryPlanYAML(op *operations.Operation) ([]byte, error)
 }
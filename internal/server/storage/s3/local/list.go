@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io/fs"
 	"net/http"
+	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -193,3 +194,29 @@ func (s *Server) collectKeys() ([]string, error) {
 
 	return keys, nil
 }
+
+// Usage returns the number of objects stored in the bucket and their total size in bytes.
+func (s *Server) Usage() (int64, int64, error) {
+	keys, err := s.collectKeys()
+	if err != nil {
+		return -1, -1, err
+	}
+
+	var objects, size int64
+	for _, key := range keys {
+		fi, err := os.Lstat(filepath.Join(s.dataDir(), filepath.FromSlash(key)))
+		if err != nil {
+			// Ignore objects deleted since listing the bucket.
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return -1, -1, err
+		}
+
+		objects++
+		size += fi.Size()
+	}
+
+	return objects, size, nil
+}
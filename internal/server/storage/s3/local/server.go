@@ -41,6 +41,9 @@ type Credential struct {
 	AccessKey string
 	SecretKey string
 	Role      Role
+
+	// PathPrefix, if set, restricts the credential to objects whose key starts with it.
+	PathPrefix string
 }
 
 // Server serves S3 requests for a single bucket directory.
@@ -85,7 +88,7 @@ func (s *Server) uploadsDir() string {
 // already. Routing happens on the remainder of the path.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Authenticate the request before any I/O.
-	role, authErr := s.authenticate(r)
+	cred, authErr := s.authenticate(r)
 	if authErr != nil {
 		authErr.Response(w)
 		return
@@ -99,7 +102,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		objectKey = ""
 	}
 
-	if !methodAllowedForRole(r.Method, role, objectKey, r.URL.Query()) {
+	if !methodAllowedForRole(r.Method, cred.Role, objectKey, r.URL.Query()) {
 		(&s3.Error{
 			Code:    s3.ErrorInvalidRequest,
 			Message: "Operation not permitted by credential role.",
@@ -107,6 +110,14 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !pathAllowedForPrefix(r, cred.PathPrefix, objectKey) {
+		(&s3.Error{
+			Code:    s3.ErrorInvalidRequest,
+			Message: "Operation not permitted outside of the credential path prefix.",
+		}).Response(w)
+		return
+	}
+
 	if s.OnAuthenticated != nil {
 		err := s.OnAuthenticated()
 		if err != nil {
@@ -153,6 +164,35 @@ func methodAllowedForRole(method string, role Role, objectKey string, q url.Valu
 	return false
 }
 
+func pathAllowedForPrefix(r *http.Request, prefix string, objectKey string) bool {
+	// No restriction.
+	if prefix == "" {
+		return true
+	}
+
+	// At the bucket level, only existence checks and listings limited to the prefix are allowed.
+	if objectKey == "" {
+		if r.Method == http.MethodHead {
+			return true
+		}
+
+		return r.Method == http.MethodGet && strings.HasPrefix(r.URL.Query().Get("prefix"), prefix)
+	}
+
+	if !strings.HasPrefix(objectKey, prefix) {
+		return false
+	}
+
+	// The source of a copy must be within the prefix too.
+	copySource := r.Header.Get("X-Amz-Copy-Source")
+	if copySource != "" {
+		srcKey, ok := parseCopySource(copySource)
+		return ok && strings.HasPrefix(srcKey, prefix)
+	}
+
+	return true
+}
+
 func (s *Server) handleBucket(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
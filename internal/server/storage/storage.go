@@ -3,9 +3,11 @@ package storage
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"sort"
+	"sync"
 
 	"github.com/lxc/incus/v7/internal/server/db"
 	"github.com/lxc/incus/v7/internal/server/db/cluster"
@@ -251,3 +253,40 @@ func UsedBy(ctx context.Context, s *state.State, pool Pool, firstOnly bool, memb
 
 	return usedBy, nil
 }
+
+// Outcomes of creating an instance volume from an image.
+const (
+	ImageCreateOptimized      = "optimized"
+	ImageCreateUnpack         = "unpack"
+	ImageCreateShrinkFallback = "shrink_fallback"
+)
+
+var (
+	imageCreateCounts   = make(map[string]map[string]uint64)
+	imageCreateCountsMu = sync.Mutex{}
+)
+
+// recordImageCreate increments the counter of the given image creation outcome for the pool.
+func recordImageCreate(poolName string, outcome string) {
+	imageCreateCountsMu.Lock()
+	defer imageCreateCountsMu.Unlock()
+
+	if imageCreateCounts[poolName] == nil {
+		imageCreateCounts[poolName] = make(map[string]uint64)
+	}
+
+	imageCreateCounts[poolName][outcome]++
+}
+
+// ImageCreateCounts returns the number of instance volumes created from images, keyed on pool name and outcome.
+func ImageCreateCounts() map[string]map[string]uint64 {
+	imageCreateCountsMu.Lock()
+	defer imageCreateCountsMu.Unlock()
+
+	counts := make(map[string]map[string]uint64, len(imageCreateCounts))
+	for poolName, outcomes := range imageCreateCounts {
+		counts[poolName] = maps.Clone(outcomes)
+	}
+
+	return counts
+}
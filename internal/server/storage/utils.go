@@ -1,17 +1,26 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"maps"
+	"net/http"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/sys/unix"
 
 	internalInstance "github.com/lxc/incus/v7/internal/instance"
@@ -32,12 +41,14 @@ import (
 	"github.com/lxc/incus/v7/internal/server/response"
 	"github.com/lxc/incus/v7/internal/server/state"
 	"github.com/lxc/incus/v7/internal/server/storage/drivers"
+	"github.com/lxc/incus/v7/internal/server/storage/memorypipe"
 	"github.com/lxc/incus/v7/internal/server/sys"
 	internalUtil "github.com/lxc/incus/v7/internal/util"
 	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/archive"
 	"github.com/lxc/incus/v7/shared/ioprogress"
 	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/util"
 	"github.com/lxc/incus/v7/shared/validate"
 )
@@ -226,10 +237,32 @@ func VolumeDBGet(pool Pool, projectName string, volumeName string, volumeType dr
 	return dbVolume, nil
 }
 
+// VolumeTagsConfigPrefix is the prefix of the volume config keys holding the volume's tags.
+const VolumeTagsConfigPrefix = "user.tags."
+
+// VolumeTags returns the tags stored in the volume config, keyed on the tag name.
+func VolumeTags(config map[string]string) map[string]string {
+	tags := map[string]string{}
+	for k, v := range config {
+		tagName, found := strings.CutPrefix(k, VolumeTagsConfigPrefix)
+		if found {
+			tags[tagName] = v
+		}
+	}
+
+	return tags
+}
+
 // VolumeDBCreate creates a volume in the database.
 // If volumeConfig is supplied, it is modified with any driver level default config options (if not set).
 // If removeUnknownKeys is true, any unknown config keys are removed from volumeConfig rather than failing.
 func VolumeDBCreate(pool Pool, projectName string, volumeName string, volumeDescription string, volumeType drivers.VolumeType, snapshot bool, volumeConfig map[string]string, creationDate time.Time, expiryDate time.Time, contentType drivers.ContentType, removeUnknownKeys bool, hasSource bool) error {
+	return volumeDBCreate(pool, projectName, volumeName, volumeDescription, volumeType, snapshot, volumeConfig, creationDate, expiryDate, contentType, removeUnknownKeys, hasSource, false)
+}
+
+// volumeDBCreate creates a volume in the database like VolumeDBCreate.
+// If deferred is true and the pool is in a maintenance session, the write is queued until the session is committed.
+func volumeDBCreate(pool Pool, projectName string, volumeName string, volumeDescription string, volumeType drivers.VolumeType, snapshot bool, volumeConfig map[string]string, creationDate time.Time, expiryDate time.Time, contentType drivers.ContentType, removeUnknownKeys bool, hasSource bool, deferred bool) error {
 	p, ok := pool.(*backend)
 	if !ok {
 		return errors.New("Pool is not a backend")
@@ -267,6 +300,13 @@ func VolumeDBCreate(pool Pool, projectName string, volumeName string, volumeDesc
 		volumeConfig = map[string]string{}
 	}
 
+	// Don't carry the snapshot scheduling marker over to volumes created from a snapshot.
+	_, found := volumeConfig["snapshots.auto"]
+	if !snapshot && found {
+		volumeConfig = maps.Clone(volumeConfig)
+		delete(volumeConfig, "snapshots.auto")
+	}
+
 	volType, err := VolumeDBTypeToType(volDBType)
 	if err != nil {
 		return err
@@ -291,7 +331,7 @@ func VolumeDBCreate(pool Pool, projectName string, volumeName string, volumeDesc
 		return err
 	}
 
-	err = p.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = p.maintenanceDBWrite(deferred, func(ctx context.Context, tx *db.ClusterTx) error {
 		// Create the database entry for the storage volume.
 		if snapshot {
 			_, err = tx.CreateStorageVolumeSnapshot(ctx, projectName, volumeName, volumeDescription, volDBType, pool.ID(), vol.Config(), creationDate, expiryDate)
@@ -310,6 +350,12 @@ func VolumeDBCreate(pool Pool, projectName string, volumeName string, volumeDesc
 
 // VolumeDBDelete deletes a volume from the database.
 func VolumeDBDelete(pool Pool, projectName string, volumeName string, volumeType drivers.VolumeType) error {
+	return volumeDBDelete(pool, projectName, volumeName, volumeType, false)
+}
+
+// volumeDBDelete deletes a volume from the database like VolumeDBDelete.
+// If deferred is true and the pool is in a maintenance session, the write is queued until the session is committed.
+func volumeDBDelete(pool Pool, projectName string, volumeName string, volumeType drivers.VolumeType, deferred bool) error {
 	p, ok := pool.(*backend)
 	if !ok {
 		return errors.New("Pool is not a backend")
@@ -321,10 +367,16 @@ func VolumeDBDelete(pool Pool, projectName string, volumeName string, volumeType
 		return err
 	}
 
-	err = p.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		return tx.RemoveStoragePoolVolume(ctx, projectName, volumeName, volDBType, pool.ID())
+	// A deferred delete may run after the matching create was discarded, so missing records must not fail it.
+	err = p.maintenanceDBWrite(deferred, func(ctx context.Context, tx *db.ClusterTx) error {
+		err := tx.RemoveStoragePoolVolume(ctx, projectName, volumeName, volDBType, pool.ID())
+		if err != nil && !response.IsNotFoundError(err) {
+			return err
+		}
+
+		return nil
 	})
-	if err != nil && !response.IsNotFoundError(err) {
+	if err != nil {
 		return fmt.Errorf("Error deleting storage volume from database: %w", err)
 	}
 
@@ -357,6 +409,173 @@ func VolumeDBSnapshotsGet(pool Pool, projectName string, volume string, volumeTy
 	return snapshots, nil
 }
 
+// VolumeTemplateConfig returns the config of a new volume based on the pool's named volume template.
+// The supplied config is applied on top of the template's config, with an empty value removing the template's key.
+func VolumeTemplateConfig(pool Pool, template string, config map[string]string) (map[string]string, error) {
+	prefix := fmt.Sprintf("volume-template.%s.", template)
+
+	templateConfig := map[string]string{}
+	for k, v := range pool.Driver().Config() {
+		volKey, found := strings.CutPrefix(k, prefix)
+		if found {
+			templateConfig[volKey] = v
+		}
+	}
+
+	if len(templateConfig) == 0 {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Volume template %q isn't defined on storage pool %q", template, pool.Name())
+	}
+
+	for k, v := range config {
+		if v == "" {
+			delete(templateConfig, k)
+			continue
+		}
+
+		templateConfig[k] = v
+	}
+
+	return templateConfig, nil
+}
+
+// snapshotWritableClonePrefix is the storage name prefix of temporary writable snapshot clones.
+// Neither project nor instance names can contain underscores, so it can't clash with an instance volume.
+const snapshotWritableClonePrefix = "_snapshot-clone_"
+
+// restoreSafetySnapshotPrefix is the name prefix of snapshots taken before a restore.
+const restoreSafetySnapshotPrefix = "pre-restore-"
+
+// restoreSafetySnapshotName returns a timestamped name for a snapshot taken before a restore.
+func restoreSafetySnapshotName() string {
+	return restoreSafetySnapshotPrefix + time.Now().UTC().Format("20060102-150405")
+}
+
+// isRestoreSafetySnapshot returns whether the snapshot name is one of a snapshot taken before a restore.
+func isRestoreSafetySnapshot(snapshotName string) bool {
+	return strings.HasPrefix(snapshotName, restoreSafetySnapshotPrefix)
+}
+
+// withoutRestoreSafetySnapshots returns the volume snapshots other than the ones taken before a restore.
+func withoutRestoreSafetySnapshots(snapshots []db.StorageVolumeArgs) []db.StorageVolumeArgs {
+	filtered := make([]db.StorageVolumeArgs, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(snapshot.Name)
+		if isRestoreSafetySnapshot(snapName) {
+			continue
+		}
+
+		filtered = append(filtered, snapshot)
+	}
+
+	return filtered
+}
+
+// snapshotsBeyondLimit returns the names of the oldest snapshots to delete once a new snapshot is created, so that
+// the volume stays within its snapshots.max config key. The snapshots must be given oldest first. Pre-restore
+// snapshots are kept until removed by the user, so they neither count nor get pruned. An error is returned if the
+// limit is reached and snapshots.max.policy isn't set to "prune".
+func snapshotsBeyondLimit(volName string, volConfig map[string]string, snapshots []db.StorageVolumeArgs) ([]string, error) {
+	if volConfig["snapshots.max"] == "" {
+		return nil, nil
+	}
+
+	limit, err := strconv.Atoi(volConfig["snapshots.max"])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid snapshots.max value: %w", err)
+	}
+
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	snapshots = withoutRestoreSafetySnapshots(snapshots)
+	if len(snapshots) < limit {
+		return nil, nil
+	}
+
+	if volConfig["snapshots.max.policy"] != "prune" {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Volume %q has reached its maximum of %d snapshots", volName, limit)
+	}
+
+	names := make([]string, 0, len(snapshots)-limit+1)
+	for _, snapshot := range snapshots[:len(snapshots)-limit+1] {
+		names = append(names, snapshot.Name)
+	}
+
+	return names, nil
+}
+
+// deleteLeftoverVolumes deletes the left over image volumes found on a pool being deleted using deleteVolume.
+// Other volumes should not exist by this point, so unless force is set they are kept, as we don't want to end up
+// removing an instance or custom volume accidentally, and their descriptions are returned so that they can be
+// reported. When forced, they are deleted first as they may depend on the image volumes.
+func deleteLeftoverVolumes(vols []drivers.Volume, force bool, deleteVolume func(vol drivers.Volume) error) ([]string, error) {
+	var imageVols []drivers.Volume
+	var otherVols []drivers.Volume
+	for _, vol := range vols {
+		if vol.Type() == drivers.VolumeTypeImage {
+			imageVols = append(imageVols, vol)
+		} else {
+			otherVols = append(otherVols, vol)
+		}
+	}
+
+	var leftoverVols []string
+	if !force {
+		for _, vol := range otherVols {
+			leftoverVols = append(leftoverVols, fmt.Sprintf("%q (%s, %s)", vol.Name(), vol.Type(), vol.ContentType()))
+		}
+
+		otherVols = nil
+	}
+
+	for _, vol := range append(otherVols, imageVols...) {
+		err := deleteVolume(vol)
+		if err != nil {
+			return nil, fmt.Errorf("Failed deleting left over %s volume %q (%s): %w", vol.Type(), vol.Name(), vol.ContentType(), err)
+		}
+	}
+
+	return leftoverVols, nil
+}
+
+// VolumeDBGetWithSnapshots loads a volume and its snapshots from the database in a single transaction.
+// The snapshots are returned in creation order, oldest first.
+func VolumeDBGetWithSnapshots(pool Pool, projectName string, volumeName string, volumeType drivers.VolumeType) (*db.StorageVolume, []db.StorageVolumeArgs, error) {
+	p, ok := pool.(*backend)
+	if !ok {
+		return nil, nil, errors.New("Pool is not a backend")
+	}
+
+	volDBType, err := VolumeTypeToDBType(volumeType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dbVolume *db.StorageVolume
+	var snapshots []db.StorageVolumeArgs
+
+	err = p.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbVolume, err = tx.GetStoragePoolVolume(ctx, pool.ID(), projectName, volDBType, volumeName, true)
+		if err != nil {
+			if response.IsNotFoundError(err) {
+				return fmt.Errorf("Storage volume %q in project %q of type %q does not exist on pool %q: %w", volumeName, projectName, volumeType, pool.Name(), err)
+			}
+
+			return err
+		}
+
+		snapshots, err = tx.GetLocalStoragePoolVolumeSnapshotsWithType(ctx, projectName, volumeName, volDBType, pool.ID())
+
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dbVolume, snapshots, nil
+}
+
 // BucketDBGet loads a bucket from the database.
 func BucketDBGet(pool Pool, projectName string, bucketName string, memberSpecific bool) (*db.StorageBucket, error) {
 	p, ok := pool.(*backend)
@@ -497,8 +716,11 @@ func poolAndVolumeCommonRules(vol *drivers.Volume) map[string]func(string) error
 			_, err := internalInstance.GetExpiry(time.Time{}, value)
 			return err
 		},
-		"snapshots.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
-		"snapshots.pattern":  validate.IsAny,
+		"snapshots.schedule":   validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
+		"snapshots.pattern":    validate.IsAny,
+		"snapshots.retain":     validate.Optional(validate.IsUint32),
+		"snapshots.max":        validate.Optional(validate.IsUint32),
+		"snapshots.max.policy": validate.Optional(validate.IsOneOf("reject", "prune")),
 	}
 
 	// Options relevant for custom filesystem volumes.
@@ -526,6 +748,82 @@ func validatePoolCommonRules() map[string]func(string) error {
 		"volatile.initial_source": validate.IsAny,
 		"rsync.bwlimit":           validate.Optional(validate.IsSize),
 		"rsync.compression":       validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=images.optimized)
+		// When disabled, instances are created by unpacking the image into their volume rather than by cloning
+		// an optimized image volume, which can be used to work around driver issues with image volumes.
+		// ---
+		//  type: bool
+		//  scope: global
+		//  defaultdesc: `true`
+		//  shortdesc: Whether to use optimized image volumes
+		"images.optimized": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=images.shrink_fallback)
+		// When disabled, creating an instance whose root disk is smaller than the cached image volume fails
+		// instead of falling back to unpacking the image into a new volume.
+		// ---
+		//  type: bool
+		//  scope: global
+		//  defaultdesc: `true`
+		//  shortdesc: Whether to unpack the image when the cached image volume can't be shrunk
+		"images.shrink_fallback": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=operation.lock_timeout)
+		// Operations on a volume that is busy with another operation fail once this duration has passed.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: no timeout
+		//  shortdesc: How long to wait for a concurrent operation on the same volume (for example, `30s`)
+		"operation.lock_timeout": validate.Optional(validate.IsMinimumDuration(time.Second)),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=snapshots.reserve_percent)
+		// New snapshots of a volume are refused once its existing snapshots use this share of the pool's capacity.
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: no limit
+		//  shortdesc: Percentage of the pool's capacity that the snapshots of a volume may use
+		"snapshots.reserve_percent": validate.Optional(validate.IsInRange(0, 100)),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=snapshots.delete_workers)
+		// Applies when deleting several snapshots of custom volumes at once.
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: `1`
+		//  shortdesc: Number of snapshots deleted concurrently
+		"snapshots.delete_workers": validate.Optional(validate.IsInRange(1, 64)),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=snapshots.layout)
+		// With `sharded`, the snapshot directories of the volumes are spread over subdirectories.
+		// This can only be set while the pool is pending.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: `flat`
+		//  shortdesc: Directory layout of the volume snapshots (`flat` or `sharded`)
+		"snapshots.layout": validate.Optional(validate.IsOneOf("flat", "sharded")),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=transfers.max_concurrent)
+		// Further transfers wait until one of the running transfers completes.
+		// The limit applies on each cluster member.
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: no limit
+		//  shortdesc: Maximum number of concurrent transfers from other storage pools into the pool
+		"transfers.max_concurrent": validate.Optional(validate.IsInRange(1, 64)),
+
+		// gendoc:generate(entity=storage_pool, group=common, key=volume.vm.state_size)
+		// Applies to virtual machines created after the setting is changed, when their root disk doesn't set `size.state`.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: driver default
+		//  shortdesc: Size of the file system volume of new virtual machines
+		"volume.vm.state_size": validate.Optional(validate.IsSize),
 	}
 
 	// Add to pool config rules (prefixed with volume.*) which are common for pool and volume.
@@ -564,6 +862,11 @@ func validateVolumeCommonRules(vol drivers.Volume) map[string]func(string) error
 
 	if vol.Type() == drivers.VolumeTypeCustom {
 		rules["dependent"] = validate.Optional(validate.IsBool)
+
+		// snapshots.auto marks custom volume snapshots created by the snapshot scheduler.
+		if vol.IsSnapshot() {
+			rules["snapshots.auto"] = validate.Optional(validate.IsBool)
+		}
 	}
 
 	return rules
@@ -581,7 +884,9 @@ func validateVolumeCommonRules(vol drivers.Volume) map[string]func(string) error
 // VM Format A: Separate metadata tarball and root qcow2 file.
 //   - Unpack metadata tarball into mountPath.
 //   - Check rootBlockPath is a file and convert qcow2 file into raw format in rootBlockPath.
-func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sysOS *sys.OS, allowUnsafeResize bool, targetIsZero bool, tracker *ioprogress.ProgressTracker, targetFormat string) (int64, error) {
+//
+// The unpack is aborted once ctx is done.
+func ImageUnpack(ctx context.Context, imageFile string, vol drivers.Volume, destBlockFile string, sysOS *sys.OS, allowUnsafeResize bool, targetIsZero bool, tracker *ioprogress.ProgressTracker, targetFormat string) (int64, error) {
 	l := logger.Log.AddContext(logger.Ctx{"imageFile": imageFile, "volName": vol.Name()})
 	l.Info("Image unpack started")
 	defer l.Info("Image unpack stopped")
@@ -604,7 +909,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 		rootfsPath := filepath.Join(destPath, "rootfs")
 
 		// Unpack the main image file.
-		err := archive.Unpack(imageFile, destPath, vol.IsBlockBacked(), maxMemory, tracker)
+		err := archive.UnpackContext(ctx, imageFile, destPath, vol.IsBlockBacked(), maxMemory, tracker)
 		if err != nil {
 			return -1, err
 		}
@@ -616,7 +921,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 				return -1, errors.New("Error creating rootfs directory")
 			}
 
-			err = archive.Unpack(imageRootfsFile, rootfsPath, vol.IsBlockBacked(), maxMemory, tracker)
+			err = archive.UnpackContext(ctx, imageRootfsFile, rootfsPath, vol.IsBlockBacked(), maxMemory, tracker)
 			if err != nil {
 				return -1, err
 			}
@@ -653,7 +958,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 		// crafted disk image. Since cloud tenants are not to be trusted, ensure QEMU is limits to 1 GiB
 		// address space and 2 seconds CPU time, which ought to be more than enough for real world images.
 		cmd := []string{"prlimit", "--cpu=2", "--as=1073741824", "qemu-img", "info", "-f", "qcow2", "--output=json", imgPath}
-		imgJSON, err := apparmor.QemuImg(sysOS, cmd, imgPath, dstPath, tracker)
+		imgJSON, err := apparmor.QemuImg(ctx, sysOS, cmd, imgPath, dstPath, tracker)
 		if err != nil {
 			return -1, fmt.Errorf("Failed reading image info %q: %w", imgPath, err)
 		}
@@ -776,7 +1081,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 
 		cmd = append(cmd, imgPath, dstPath)
 
-		_, err = apparmor.QemuImg(sysOS, cmd, imgPath, dstPath, tracker)
+		_, err = apparmor.QemuImg(ctx, sysOS, cmd, imgPath, dstPath, tracker)
 		if err != nil {
 			return -1, fmt.Errorf("Failed converting image to raw at %q: %w", dstPath, err)
 		}
@@ -788,7 +1093,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 
 	if util.PathExists(imageRootfsFile) {
 		// Unpack the main image file.
-		err := archive.Unpack(imageFile, destPath, vol.IsBlockBacked(), maxMemory, tracker)
+		err := archive.UnpackContext(ctx, imageFile, destPath, vol.IsBlockBacked(), maxMemory, tracker)
 		if err != nil {
 			return -1, err
 		}
@@ -808,7 +1113,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 		defer logger.WarnOnError(func() error { return os.RemoveAll(tempDir) }, "Failed to remove temporary directory")
 
 		// Unpack the whole image.
-		err = archive.Unpack(imageFile, tempDir, vol.IsBlockBacked(), maxMemory, tracker)
+		err = archive.UnpackContext(ctx, imageFile, tempDir, vol.IsBlockBacked(), maxMemory, tracker)
 		if err != nil {
 			return -1, err
 		}
@@ -838,6 +1143,136 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 	return imgSize, nil
 }
 
+// ImageRootDiskSize returns the unpacked size of the root disk of a virtual machine image, which is the size
+// ImageUnpack reports for it. Unified images are unpacked to a temporary directory to measure their root disk.
+func ImageRootDiskSize(imageFile string, sysOS *sys.OS) (int64, error) {
+	imageRootfsFile := imageFile + ".rootfs"
+	if util.PathExists(imageRootfsFile) {
+		return ForeignVolumeSize(sysOS, imageRootfsFile, drivers.BlockVolumeTypeQcow2)
+	}
+
+	tempDir, err := os.MkdirTemp(internalUtil.VarPath("images"), "incus_image_unpack_")
+	if err != nil {
+		return -1, err
+	}
+
+	defer logger.WarnOnError(func() error { return os.RemoveAll(tempDir) }, "Failed to remove temporary directory")
+
+	err = archive.Unpack(imageFile, tempDir, false, 0, nil)
+	if err != nil {
+		return -1, err
+	}
+
+	return ForeignVolumeSize(sysOS, filepath.Join(tempDir, "rootfs.img"), drivers.BlockVolumeTypeQcow2)
+}
+
+// ForeignVolumeFormat detects the disk format of the file or block device at path.
+// Only the qcow2 header is recognized, anything else is treated as a raw disk.
+func ForeignVolumeFormat(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer logger.WarnOnError(f.Close, "Failed to close file")
+
+	magic := make([]byte, 4)
+	_, err = io.ReadFull(f, magic)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("Failed reading header of %q: %w", path, err)
+	}
+
+	if bytes.Equal(magic, []byte{'Q', 'F', 'I', 0xfb}) {
+		return drivers.BlockVolumeTypeQcow2, nil
+	}
+
+	return drivers.BlockVolumeTypeRaw, nil
+}
+
+// ForeignVolumeSize returns the size in bytes of the disk at path once written to a raw block volume.
+func ForeignVolumeSize(sysOS *sys.OS, path string, format string) (int64, error) {
+	if format != drivers.BlockVolumeTypeQcow2 {
+		return drivers.BlockDiskSizeBytes(path)
+	}
+
+	// Limit qemu-img in the same way as for image unpacks as the disk comes from outside of Incus.
+	cmd := []string{"prlimit", "--cpu=2", "--as=1073741824", "qemu-img", "info", "-f", "qcow2", "--output=json", path}
+	imgJSON, err := apparmor.QemuImg(context.Background(), sysOS, cmd, path, "", nil)
+	if err != nil {
+		return -1, fmt.Errorf("Failed reading disk info %q: %w", path, err)
+	}
+
+	imgInfo := struct {
+		VirtualSize int64 `json:"virtual-size"`
+	}{}
+
+	err = json.Unmarshal([]byte(imgJSON), &imgInfo)
+	if err != nil {
+		return -1, fmt.Errorf("Failed unmarshalling disk info %q: %w (%q)", path, err, imgJSON)
+	}
+
+	return imgInfo.VirtualSize, nil
+}
+
+// ForeignVolumeUnpack writes the disk at srcPath in srcFormat to dstPath in the pool's target format.
+// The destination is expected to have been created large enough to hold the disk.
+func ForeignVolumeUnpack(sysOS *sys.OS, srcPath string, srcFormat string, dstPath string, targetFormat string, tracker *ioprogress.ProgressTracker) error {
+	if targetFormat == "" {
+		targetFormat = drivers.BlockVolumeTypeRaw
+	}
+
+	// Nothing to convert, copy the disk as is.
+	if srcFormat == targetFormat {
+		return drivers.CopyDeviceWithProgress(srcPath, dstPath, tracker)
+	}
+
+	cmd := []string{
+		"nice", "-n19", // Run with low priority to reduce CPU impact on other processes.
+		"qemu-img", "convert", "-p", "-f", srcFormat, "-O", targetFormat, "-t", "writeback",
+	}
+
+	// Raw targets have already been sized by the driver so must not be recreated.
+	if targetFormat == drivers.BlockVolumeTypeRaw {
+		cmd = append(cmd, "-n")
+	}
+
+	cmd = append(cmd, srcPath, dstPath)
+
+	_, err := apparmor.QemuImg(context.Background(), sysOS, cmd, srcPath, dstPath, tracker)
+	if err != nil {
+		return fmt.Errorf("Failed converting disk to %s at %q: %w", targetFormat, dstPath, err)
+	}
+
+	return nil
+}
+
+// validateInstanceCopyTypes checks that the source instance or snapshot can be copied into the target instance.
+func validateInstanceCopyTypes(inst instance.ConfigReader, src instance.ConfigReader) error {
+	if inst.Type() != src.Type() {
+		if internalInstance.IsSnapshot(src.Name()) {
+			return api.StatusErrorf(http.StatusBadRequest, "Cannot copy %s snapshot %q into %s %q", src.Type(), src.Name(), inst.Type(), inst.Name())
+		}
+
+		return api.StatusErrorf(http.StatusBadRequest, "Instance type %q of %q doesn't match instance type %q of source %q", inst.Type(), inst.Name(), src.Type(), src.Name())
+	}
+
+	return nil
+}
+
+// validateVMConfigOnlyRefresh checks that the target instance can have only its VM config volume refreshed from
+// the source instance.
+func validateVMConfigOnlyRefresh(inst instance.ConfigReader, src instance.ConfigReader, snapshots bool) error {
+	if inst.Type() != instancetype.VM || src.Type() != instancetype.VM {
+		return api.StatusErrorf(http.StatusBadRequest, "Config volume only refresh is only supported for virtual machines")
+	}
+
+	if snapshots {
+		return api.StatusErrorf(http.StatusBadRequest, "Config volume only refresh cannot include snapshots")
+	}
+
+	return nil
+}
+
 // InstanceContentType returns the instance's content type.
 func InstanceContentType(inst instance.ConfigReader) drivers.ContentType {
 	contentType := drivers.ContentTypeFS
@@ -848,6 +1283,17 @@ func InstanceContentType(inst instance.ConfigReader) drivers.ContentType {
 	return contentType
 }
 
+// customVolumeMoveTargetProject returns the project a custom volume stored in projectName ends up in when it's
+// moved to targetProject, taking into account whether the target project has its own custom volumes.
+func customVolumeMoveTargetProject(projectName string, targetProject *api.Project) (string, error) {
+	targetProjectName := project.StorageVolumeProjectFromRecord(targetProject, db.StoragePoolVolumeTypeCustom)
+	if targetProjectName == projectName {
+		return "", api.StatusErrorf(http.StatusBadRequest, "Project %q already stores its custom volumes in project %q", targetProject.Name, projectName)
+	}
+
+	return targetProjectName, nil
+}
+
 // VolumeUsedByProfileDevices finds profiles using a volume and passes them to profileFunc for evaluation.
 // The profileFunc is provided with a profile config, project config and a list of device names that are using
 // the volume.
@@ -1094,6 +1540,62 @@ func FallbackMigrationType(contentType drivers.ContentType) migration.MigrationF
 	return migration.MigrationFSType_RSYNC
 }
 
+// negotiateMigrationType runs the migration type negotiation between srcPool and dstPool without
+// transferring anything and returns the type that would be used.
+func negotiateMigrationType(srcPool Pool, dstPool Pool, contentType drivers.ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) (localMigration.Type, error) {
+	offerHeader := localMigration.TypesToHeader(srcPool.MigrationTypes(contentType, refresh, copySnapshots, clusterMove, storageMove)...)
+	offerHeader.Refresh = &refresh
+
+	migrationTypes, err := localMigration.MatchTypes(offerHeader, FallbackMigrationType(contentType), dstPool.MigrationTypes(contentType, refresh, copySnapshots, clusterMove, storageMove))
+	if err != nil {
+		return localMigration.Type{}, fmt.Errorf("Failed to negotiate migration type: %w", err)
+	}
+
+	return migrationTypes[0], nil
+}
+
+// isOptimizedMigrationType returns whether the migration type is a driver specific optimized one.
+func isOptimizedMigrationType(migrationType localMigration.Type) bool {
+	return migrationType.FSType != migration.MigrationFSType_RSYNC && migrationType.FSType != migration.MigrationFSType_BLOCK_AND_RSYNC
+}
+
+// MigrationTypeDescription returns a human-readable description of a negotiated migration type.
+func MigrationTypeDescription(migrationType localMigration.Type, refresh bool) string {
+	var description string
+
+	switch migrationType.FSType {
+	case migration.MigrationFSType_RSYNC:
+		description = "rsync"
+	case migration.MigrationFSType_BLOCK_AND_RSYNC:
+		description = "block"
+	default:
+		description = fmt.Sprintf("optimized (%s)", strings.ToLower(migrationType.FSType.String()))
+	}
+
+	if refresh && isOptimizedMigrationType(migrationType) {
+		description += ", incremental"
+	} else {
+		description += ", full"
+	}
+
+	if slices.Contains(migrationType.Features, "compress") {
+		description += ", compressed"
+	}
+
+	return description
+}
+
+// CanOptimizeMigration negotiates the migration type that would be used to copy a volume from srcPool to
+// dstPool without transferring anything. It returns whether the negotiated type is an optimized one.
+func CanOptimizeMigration(srcPool Pool, dstPool Pool, contentType drivers.ContentType, refresh bool, snapshots bool) (bool, localMigration.Type, error) {
+	migrationType, err := negotiateMigrationType(srcPool, dstPool, contentType, refresh, snapshots, false, srcPool.Name() != dstPool.Name())
+	if err != nil {
+		return false, localMigration.Type{}, err
+	}
+
+	return isOptimizedMigrationType(migrationType), migrationType, nil
+}
+
 // InstanceMount mounts an instance's storage volume (if not already mounted).
 // Please call InstanceUnmount when finished.
 func InstanceMount(pool Pool, inst instance.Instance, op *operations.Operation) (*MountInfo, error) {
@@ -1275,70 +1777,220 @@ func VolumeSnapshotsToMigrationSnapshots(snapshots []*api.StorageVolumeSnapshot,
 	return migrationSnapshots, nil
 }
 
-// ProjectVolume returns a project scoped volume identifier.
-// It applies the appropriate '<project>_' prefix based on the volume type.
-func ProjectVolume(projectName string, volName string, volType drivers.VolumeType) string {
-	if volType == drivers.VolumeTypeContainer || volType == drivers.VolumeTypeVM {
-		return project.Instance(projectName, volName)
-	}
-
-	return project.StorageVolume(projectName, volName)
-}
-
-// DisallowedStorageConfigForCreation returns a list of keys that cannot be specified
-// during pool creation.
-func DisallowedStorageConfigForCreation(driverName string) []string {
-	if driverName == "lvmcluster" {
-		return []string{"size"}
-	}
-
-	return []string{}
+// CustomVolumeRef identifies a custom volume in a storage pool.
+type CustomVolumeRef struct {
+	Pool    string
+	Project string
+	Name    string
 }
 
-// ClusterWideStorageConfig returns a list of keys that are cluster wide.
-func ClusterWideStorageConfig(driverName string) []string {
-	if driverName == "lvmcluster" {
-		return []string{"size"}
-	}
-
-	return []string{}
+// consistencyGroupMember is an instance or custom volume snapshotted as part of a consistency group.
+type consistencyGroupMember struct {
+	name     string                 // Description of the member used in errors.
+	pool     Pool                   // Pool the member is on.
+	freeze   func() (func(), error) // Freezes the member if needed and returns the function to unfreeze it.
+	snapshot func() error           // Takes the snapshot of the member.
+	delete   func()                 // Deletes the snapshot of the member again.
 }
 
-// GenerateDependentVolumesOffer creates an offer header containing
-// all information required for dependent volume migration.
-func GenerateDependentVolumesOffer(s *state.State, config *backupConfig.Config, projectName string, snapshots bool, devices api.DevicesMap, clusterMove bool) ([]*migration.DependentVolume, error) {
-	result := make([]*migration.DependentVolume, 0, len(config.DependentVolumes))
-	if len(config.DependentVolumes) == 0 {
-		return result, nil
+// snapshotConsistencyGroupMembers checks all the members are on pools supporting snapshots before freezing the
+// ones that need it and snapshotting all of them. If any snapshot fails, the ones already taken are deleted.
+func snapshotConsistencyGroupMembers(members []consistencyGroupMember) error {
+	for _, member := range members {
+		if !member.pool.Capabilities().Snapshots {
+			return api.StatusErrorf(http.StatusBadRequest, "Storage pool %q of %s doesn't support snapshots", member.pool.Name(), member.name)
+		}
 	}
 
-	devicesMap := DevicesMapFromBackupConfig(config)
+	reverter := revert.New()
+	defer reverter.Fail()
 
-	for _, volConfig := range config.DependentVolumes {
-		poolName := volConfig.Pool.Name
-		contentType := volConfig.Volume.ContentType
-		volName := volConfig.Volume.Name
+	// Freeze all the members up front so that nothing changes between the snapshots.
+	for _, member := range members {
+		if member.freeze == nil {
+			continue
+		}
 
-		pool, err := LoadByName(s, poolName)
+		unfreeze, err := member.freeze()
 		if err != nil {
-			return nil, fmt.Errorf("Failed loading pool: %w", err)
+			return fmt.Errorf("Failed freezing %s: %w", member.name, err)
 		}
 
-		deviceName := DeviceByPoolAndVolume(devicesMap, poolName, volName)
-		if deviceName == "" {
-			return nil, fmt.Errorf("Device for volume %s/%s not found", poolName, volName)
+		if unfreeze != nil {
+			defer unfreeze()
 		}
+	}
 
-		shouldMigrate, err := ShouldMigrateDependentVolume(s, poolName, volName, devices[deviceName], clusterMove)
+	for _, member := range members {
+		err := member.snapshot()
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("Failed snapshotting %s: %w", member.name, err)
 		}
 
-		if !shouldMigrate {
-			continue
-		}
+		reverter.Add(member.delete)
+	}
 
-		volStorageName := project.StorageVolume(projectName, volName)
+	reverter.Success()
+	return nil
+}
+
+// SnapshotConsistencyGroup takes snapshots with the same name of a set of instances and custom volumes.
+// All running instances are frozen while the snapshots are taken so that they are crash-consistent with
+// each other. If any snapshot fails, the ones already taken are removed again.
+func SnapshotConsistencyGroup(s *state.State, instances []instance.Instance, customVols []CustomVolumeRef, name string, op *operations.Operation) error {
+	if name == "" || internalInstance.IsSnapshot(name) {
+		return fmt.Errorf("Invalid snapshot name %q", name)
+	}
+
+	members := make([]consistencyGroupMember, 0, len(instances)+len(customVols))
+
+	// Check none of the snapshots already exist before touching anything.
+	for _, inst := range instances {
+		if inst.IsSnapshot() {
+			return fmt.Errorf("Instance %q is a snapshot", inst.Name())
+		}
+
+		_, err := instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name()+internalInstance.SnapshotDelimiter+name)
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "Snapshot %q of instance %q already exists", name, inst.Name())
+		} else if !response.IsNotFoundError(err) {
+			return err
+		}
+
+		pool, err := LoadByInstance(s, inst)
+		if err != nil {
+			return err
+		}
+
+		members = append(members, consistencyGroupMember{
+			name: fmt.Sprintf("instance %q", inst.Name()),
+			pool: pool,
+			freeze: func() (func(), error) {
+				if !inst.IsRunning() || inst.IsFrozen() {
+					return nil, nil
+				}
+
+				err := inst.Freeze()
+				if err != nil {
+					return nil, err
+				}
+
+				// Attempt to sync the filesystem.
+				_ = linux.SyncFS(inst.RootfsPath())
+
+				return func() { logger.WarnOnError(inst.Unfreeze, "Failed to unfreeze instance") }, nil
+			},
+			snapshot: func() error {
+				return inst.Snapshot(name, time.Time{}, false, instance.SnapshotArgs{})
+			},
+			delete: func() {
+				snap, err := instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name()+internalInstance.SnapshotDelimiter+name)
+				if err != nil {
+					return
+				}
+
+				_ = snap.Delete(true, true)
+			},
+		})
+	}
+
+	for _, customVol := range customVols {
+		pool, err := LoadByName(s, customVol.Pool)
+		if err != nil {
+			return err
+		}
+
+		_, err = VolumeDBGet(pool, customVol.Project, customVol.Name, drivers.VolumeTypeCustom)
+		if err != nil {
+			return fmt.Errorf("Failed loading volume %q: %w", customVol.Name, err)
+		}
+
+		_, err = VolumeDBGet(pool, customVol.Project, drivers.GetSnapshotVolumeName(customVol.Name, name), drivers.VolumeTypeCustom)
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "Snapshot %q of volume %q already exists", name, customVol.Name)
+		} else if !response.IsNotFoundError(err) {
+			return err
+		}
+
+		members = append(members, consistencyGroupMember{
+			name: fmt.Sprintf("volume %q", customVol.Name),
+			pool: pool,
+			snapshot: func() error {
+				return pool.CreateCustomVolumeSnapshot(customVol.Project, customVol.Name, name, time.Time{}, false, false, false, false, op)
+			},
+			delete: func() {
+				_ = pool.DeleteCustomVolumeSnapshot(customVol.Project, drivers.GetSnapshotVolumeName(customVol.Name, name), nil)
+			},
+		})
+	}
+
+	return snapshotConsistencyGroupMembers(members)
+}
+
+// ProjectVolume returns a project scoped volume identifier.
+// It applies the appropriate '<project>_' prefix based on the volume type.
+func ProjectVolume(projectName string, volName string, volType drivers.VolumeType) string {
+	if volType == drivers.VolumeTypeContainer || volType == drivers.VolumeTypeVM {
+		return project.Instance(projectName, volName)
+	}
+
+	return project.StorageVolume(projectName, volName)
+}
+
+// DisallowedStorageConfigForCreation returns a list of keys that cannot be specified
+// during pool creation.
+func DisallowedStorageConfigForCreation(driverName string) []string {
+	if driverName == "lvmcluster" {
+		return []string{"size"}
+	}
+
+	return []string{}
+}
+
+// ClusterWideStorageConfig returns a list of keys that are cluster wide.
+func ClusterWideStorageConfig(driverName string) []string {
+	if driverName == "lvmcluster" {
+		return []string{"size"}
+	}
+
+	return []string{}
+}
+
+// GenerateDependentVolumesOffer creates an offer header containing
+// all information required for dependent volume migration.
+func GenerateDependentVolumesOffer(s *state.State, config *backupConfig.Config, projectName string, snapshots bool, devices api.DevicesMap, clusterMove bool) ([]*migration.DependentVolume, error) {
+	result := make([]*migration.DependentVolume, 0, len(config.DependentVolumes))
+	if len(config.DependentVolumes) == 0 {
+		return result, nil
+	}
+
+	devicesMap := DevicesMapFromBackupConfig(config)
+
+	for _, volConfig := range config.DependentVolumes {
+		poolName := volConfig.Pool.Name
+		contentType := volConfig.Volume.ContentType
+		volName := volConfig.Volume.Name
+
+		pool, err := LoadByName(s, poolName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading pool: %w", err)
+		}
+
+		deviceName := DeviceByPoolAndVolume(devicesMap, poolName, volName)
+		if deviceName == "" {
+			return nil, fmt.Errorf("Device for volume %s/%s not found", poolName, volName)
+		}
+
+		shouldMigrate, err := ShouldMigrateDependentVolume(s, poolName, volName, devices[deviceName], clusterMove)
+		if err != nil {
+			return nil, err
+		}
+
+		if !shouldMigrate {
+			continue
+		}
+
+		volStorageName := project.StorageVolume(projectName, volName)
 		vol := pool.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(contentType), volStorageName, volConfig.Volume.Config)
 
 		poolMigrationTypes := pool.MigrationTypes(drivers.ContentType(contentType), false, snapshots, true, false)
@@ -1552,6 +2204,391 @@ func DevicesMapFromBackupConfig(config *backupConfig.Config) map[string]map[stri
 	return devicesMap
 }
 
+// poolMigrationJob is an item of a storage pool to copy to another pool.
+type poolMigrationJob struct {
+	kind    string
+	project string
+	name    string
+	volume  *db.StorageVolume
+}
+
+// String returns the description of the item used in logs and errors.
+func (j poolMigrationJob) String() string {
+	return fmt.Sprintf("%s %q in project %q", j.kind, j.name, j.project)
+}
+
+// poolMigrationJobs returns the items to copy in order, custom volumes first as the instances may have them
+// attached, then instances and finally buckets. Image volumes and snapshots are skipped.
+func poolMigrationJobs(dbVolumes []*db.StorageVolume, dbBuckets []*db.StorageBucket) []poolMigrationJob {
+	var jobs []poolMigrationJob
+
+	for _, dbVol := range dbVolumes {
+		if dbVol.Type != db.StoragePoolVolumeTypeNameCustom || internalInstance.IsSnapshot(dbVol.Name) {
+			continue
+		}
+
+		jobs = append(jobs, poolMigrationJob{kind: "custom volume", project: dbVol.Project, name: dbVol.Name, volume: dbVol})
+	}
+
+	for _, dbVol := range dbVolumes {
+		if (dbVol.Type != db.StoragePoolVolumeTypeNameContainer && dbVol.Type != db.StoragePoolVolumeTypeNameVM) || internalInstance.IsSnapshot(dbVol.Name) {
+			continue
+		}
+
+		jobs = append(jobs, poolMigrationJob{kind: "instance", project: dbVol.Project, name: dbVol.Name})
+	}
+
+	for _, dbBucket := range dbBuckets {
+		jobs = append(jobs, poolMigrationJob{kind: "bucket", project: dbBucket.Project, name: dbBucket.Name})
+	}
+
+	return jobs
+}
+
+// runPoolMigrationJobs runs the migrate function for each job in order. A failing job doesn't stop the others.
+// The progress and the final summary are reported in the operation metadata. Returns the failed jobs along with
+// all their errors.
+func runPoolMigrationJobs(jobs []poolMigrationJob, migrate func(job poolMigrationJob) error, op *operations.Operation) ([]string, error) {
+	var failures []string
+	var errs []error
+
+	for i, job := range jobs {
+		err := migrate(job)
+		if err != nil {
+			failures = append(failures, job.String())
+			errs = append(errs, fmt.Errorf("Failed migrating %s: %w", job, err))
+		}
+
+		if op != nil {
+			_ = op.ExtendMetadata(map[string]any{"pool_migration_progress": fmt.Sprintf("%d/%d", i+1, len(jobs))})
+		}
+	}
+
+	if op != nil {
+		_ = op.ExtendMetadata(map[string]any{
+			"pool_migration_succeeded": len(jobs) - len(failures),
+			"pool_migration_failed":    failures,
+		})
+	}
+
+	return failures, errors.Join(errs...)
+}
+
+// MigratePoolContents copies the instance volumes, custom volumes and buckets of the source pool on this server
+// to the destination pool, including snapshots, using the existing copy methods. Image volumes are skipped as
+// they can be regenerated. Custom volumes are copied first, then instances and finally buckets.
+//
+// Instances are copied with migrateInstanceVolume, which only copies the instance volume and its snapshots. The
+// instance records aren't modified, so the instances stay pointed at the source pool through their root disk
+// devices until moved. The custom volumes attached to the instances are copied on their own.
+//
+// A failure to copy one item doesn't stop the others. The number of copied items and the list of failed ones
+// are reported in the operation metadata, and all failures are returned together once done.
+func MigratePoolContents(srcPool Pool, dstPool Pool, op *operations.Operation) error {
+	src, ok := srcPool.(*backend)
+	if !ok {
+		return errors.New("Source pool is not a backend")
+	}
+
+	dst, ok := dstPool.(*backend)
+	if !ok {
+		return errors.New("Destination pool is not a backend")
+	}
+
+	if srcPool.Name() == dstPool.Name() {
+		return errors.New("Source and destination pools must differ")
+	}
+
+	l := src.logger.AddContext(logger.Ctx{"dstPool": dstPool.Name()})
+	l.Debug("MigratePoolContents started")
+	defer l.Debug("MigratePoolContents finished")
+
+	var dbVolumes []*db.StorageVolume
+	var dbBuckets []*db.StorageBucket
+
+	err := src.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		dbVolumes, err = tx.GetStoragePoolVolumes(ctx, srcPool.ID(), true)
+		if err != nil {
+			return fmt.Errorf("Failed loading storage volumes: %w", err)
+		}
+
+		poolID := srcPool.ID()
+		dbBuckets, err = tx.GetStoragePoolBuckets(ctx, true, db.StorageBucketFilter{PoolID: &poolID})
+		if err != nil {
+			return fmt.Errorf("Failed loading storage buckets: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	jobs := poolMigrationJobs(dbVolumes, dbBuckets)
+
+	failures, err := runPoolMigrationJobs(jobs, func(job poolMigrationJob) error {
+		var err error
+
+		switch job.kind {
+		case "custom volume":
+			err = dstPool.CreateCustomVolumeFromCopy(job.project, job.project, job.name, job.volume.Description, withoutVolatileConfig(job.volume.Config), srcPool.Name(), job.name, true, nil, "", false, op)
+		case "instance":
+			var inst instance.Instance
+
+			inst, err = instance.LoadByProjectAndName(src.state, job.project, job.name)
+			if err == nil {
+				err = migrateInstanceVolume(src, dst, inst, op)
+			}
+
+		case "bucket":
+			err = migrateBucket(srcPool, dstPool, job.project, job.name, op)
+		}
+
+		if err != nil {
+			l.Error("Failed migrating pool item", logger.Ctx{"item": job.String(), "err": err})
+		}
+
+		return err
+	}, op)
+
+	l.Info("Pool contents migrated", logger.Ctx{"succeeded": len(jobs) - len(failures), "failed": len(failures)})
+
+	return err
+}
+
+// migrateInstanceVolume copies the volume of an instance and its snapshots to another pool. Unlike
+// CreateInstanceFromCopy, the instance's symlinks and dependent volumes are left alone, so the instance keeps
+// using the volume on the source pool.
+func migrateInstanceVolume(src *backend, dst *backend, inst instance.Instance, op *operations.Operation) error {
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	srcConfig, err := src.GenerateInstanceBackupConfig(inst, true, true, op)
+	if err != nil {
+		return fmt.Errorf("Failed generating instance copy config: %w", err)
+	}
+
+	// The qcow2 volumes are handled by the backend on top of the driver transfer.
+	if srcConfig.Volume.Config["block.type"] == drivers.BlockVolumeTypeQcow2 || dst.driver.Info().TargetFormat == drivers.BlockVolumeTypeQcow2 {
+		return errors.New("Copying qcow2 instance volumes isn't supported")
+	}
+
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+
+	srcVol := src.GetVolume(volType, contentType, volStorageName, srcConfig.Volume.Config)
+	err = src.applyInstanceRootDiskOverrides(inst, &srcVol)
+	if err != nil {
+		return err
+	}
+
+	volConfig := withoutVolatileConfig(srcConfig.Volume.Config)
+	vol := dst.GetVolume(volType, contentType, volStorageName, volConfig)
+
+	volExists, err := dst.driver.HasVolume(vol)
+	if err != nil {
+		return err
+	}
+
+	if volExists {
+		return errors.New("Cannot create volume, already exists on target storage")
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Validate config and create database entry for new storage volume.
+	err = VolumeDBCreate(dst, inst.Project().Name, inst.Name(), srcConfig.Volume.Description, volType, false, volConfig, srcConfig.Volume.CreatedAt, time.Time{}, contentType, true, true)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = VolumeDBDelete(dst, inst.Project().Name, inst.Name(), volType) })
+
+	snapshotNames := make([]string, 0, len(srcConfig.VolumeSnapshots))
+	for _, snapshot := range srcConfig.VolumeSnapshots {
+		newSnapshotName := drivers.GetSnapshotVolumeName(inst.Name(), snapshot.Name)
+
+		var snapExpiryDate time.Time
+		if snapshot.ExpiresAt != nil {
+			snapExpiryDate = *snapshot.ExpiresAt
+		}
+
+		// Validate config and create database entry for new storage volume snapshot.
+		err = VolumeDBCreate(dst, inst.Project().Name, newSnapshotName, snapshot.Description, volType, true, withoutVolatileConfig(snapshot.Config), snapshot.CreatedAt, snapExpiryDate, contentType, true, true)
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = VolumeDBDelete(dst, inst.Project().Name, newSnapshotName, volType) })
+		snapshotNames = append(snapshotNames, snapshot.Name)
+	}
+
+	migrationSnapshots, err := VolumeSnapshotsToMigrationSnapshots(srcConfig.VolumeSnapshots, inst.Project().Name, src, contentType, volType, inst.Name())
+	if err != nil {
+		return err
+	}
+
+	migrationType, err := negotiateMigrationType(src, dst, contentType, false, true, false, true)
+	if err != nil {
+		return err
+	}
+
+	// Generate the effective root device volume for instance.
+	err = dst.applyInstanceRootDiskOverrides(inst, &vol)
+	if err != nil {
+		return err
+	}
+
+	// For VMs, create the target volume the same size as the source volume.
+	if inst.Type() == instancetype.VM {
+		srcVolumeSize, err := InstanceDiskBlockSize(src, inst, op)
+		if err != nil {
+			return fmt.Errorf("Failed getting source disk size: %w", err)
+		}
+
+		vol.SetConfigSize(fmt.Sprintf("%d", srcVolumeSize))
+	}
+
+	// Freeze the instance when the source can't take a consistent copy of it while running.
+	runningCopyFreeze := src.driver.Info().RunningCopyFreeze || migrationType.FSType == migration.MigrationFSType_BLOCK_AND_RSYNC
+	if runningCopyFreeze && inst.IsRunning() && !inst.IsFrozen() {
+		err = inst.Freeze()
+		if err != nil {
+			return err
+		}
+
+		defer logger.WarnOnError(inst.Unfreeze, "Failed to unfreeze instance")
+
+		// Attempt to sync the filesystem.
+		_ = linux.SyncFS(inst.RootfsPath())
+	}
+
+	// Remove the copied volume again, without going through DeleteInstance as it would remove the symlinks of
+	// the instance too.
+	reverter.Add(func() {
+		for _, snapName := range slices.Backward(snapshotNames) {
+			snapVol, err := vol.NewSnapshot(snapName)
+			if err == nil {
+				_ = dst.driver.DeleteVolumeSnapshot(snapVol, nil)
+			}
+		}
+
+		_ = dst.driver.DeleteVolume(vol, nil)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Run sender and receiver in separate go routines to prevent deadlocks.
+	g, ctx := errgroup.WithContext(ctx)
+
+	// Use in-memory pipe pair to simulate a connection between the sender and receiver.
+	aEnd, bEnd := memorypipe.NewPipePair(ctx)
+
+	g.Go(func() error {
+		return src.driver.MigrateVolume(srcVol, aEnd, &localMigration.VolumeSourceArgs{
+			Name:          inst.Name(),
+			Snapshots:     snapshotNames,
+			MigrationType: migrationType,
+			TrackProgress: true,
+			StorageMove:   true,
+		}, op)
+	})
+
+	g.Go(func() error {
+		return dst.driver.CreateVolumeFromMigration(vol, bEnd, localMigration.VolumeTargetArgs{
+			Name:          inst.Name(),
+			Snapshots:     migrationSnapshots,
+			MigrationType: migrationType,
+			StoragePool:   src.Name(),
+		}, nil, op)
+	})
+
+	err = g.Wait()
+	if err != nil {
+		return fmt.Errorf("Failed copying instance volume: %w", err)
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// withoutVolatileConfig returns a copy of the volume config without the volatile keys, which belong to the
+// original volume and get regenerated for a new one.
+func withoutVolatileConfig(config map[string]string) map[string]string {
+	newConfig := make(map[string]string, len(config))
+	for k, v := range config {
+		if !strings.HasPrefix(k, "volatile.") {
+			newConfig[k] = v
+		}
+	}
+
+	return newConfig
+}
+
+// migrateBucket copies a bucket, its keys and objects to another pool through a temporary export.
+func migrateBucket(srcPool Pool, dstPool Pool, projectName string, bucketName string, op *operations.Operation) error {
+	f, err := os.CreateTemp(internalUtil.VarPath("backups"), "incus_bucket_migrate_")
+	if err != nil {
+		return fmt.Errorf("Failed creating temporary file: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	err = srcPool.ExportBucket(projectName, bucketName, f, true, op)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	return dstPool.ImportBucketFromExport(projectName, f, op)
+}
+
+// backupVolumeCreationDate returns the creation date to record for a volume in a backup config.
+// Older volume records may lack one, in which case the first set fallback is used. If none is set, the date is
+// left zero so that the backup doesn't record a made up creation date.
+func backupVolumeCreationDate(createdAt time.Time, fallbacks ...time.Time) time.Time {
+	for _, date := range append([]time.Time{createdAt}, fallbacks...) {
+		if !date.IsZero() && date.Unix() != 0 {
+			return date
+		}
+	}
+
+	return time.Time{}
+}
+
+// checkOptimizedBackup returns an error explaining why the pool can't produce an optimized backup of the volume.
+func checkOptimizedBackup(pool Pool, vol drivers.Volume) error {
+	driverName := pool.Driver().Info().Name
+
+	if !pool.Driver().Info().OptimizedBackups {
+		return fmt.Errorf("Storage driver %q of pool %q doesn't support optimized backups", driverName, pool.Name())
+	}
+
+	if vol.Config()["block.type"] == drivers.BlockVolumeTypeQcow2 {
+		return fmt.Errorf("Storage driver %q can't produce optimized backups of qcow2 volumes", driverName)
+	}
+
+	if vol.ContentType() == drivers.ContentTypeISO {
+		return fmt.Errorf("Storage driver %q can't produce optimized backups of ISO volumes", driverName)
+	}
+
+	return nil
+}
+
 // nbdOperationLock acquires a lock for NBD operations on an instance and
 // returns an unlock function.
 func nbdOperationLock(projectName string, instanceName string) (locking.UnlockFunc, error) {
@@ -1566,3 +2603,238 @@ func nbdOperationLock(projectName string, instanceName string) (locking.UnlockFu
 
 	return unlock, nil
 }
+
+// nopWriteCloser wraps an io.Writer with a no-op Close method.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing.
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// restoreFilesRelPath validates a path to restore from a volume snapshot and returns it cleaned.
+// The path must be relative to the root of the volume and can't refer to its parent directories.
+func restoreFilesRelPath(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("Path %q must be relative to the root of the volume", path)
+	}
+
+	if slices.Contains(strings.Split(path, "/"), "..") {
+		return "", fmt.Errorf("Path %q cannot refer to a parent directory", path)
+	}
+
+	relPath := filepath.Clean(path)
+	if relPath == "." {
+		return "", errors.New("Restoring the whole volume requires a full snapshot restore")
+	}
+
+	return relPath, nil
+}
+
+// readOnlyBindMount bind mounts path read-only on a new temporary directory of the pool.
+// It returns the path of the read-only mount and a function that unmounts and removes it.
+func readOnlyBindMount(poolName string, path string, prefix string) (string, func(), error) {
+	roPath, err := os.MkdirTemp(internalUtil.VarPath("storage-pools", poolName), prefix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = drivers.TryMount(path, roPath, "none", unix.MS_BIND, "")
+	if err != nil {
+		_ = os.Remove(roPath)
+		return "", nil, err
+	}
+
+	cleanup := func() {
+		_ = drivers.TryUnmount(roPath, 0)
+		_ = os.Remove(roPath)
+	}
+
+	err = drivers.TryMount("", roPath, "none", unix.MS_BIND|unix.MS_RDONLY|unix.MS_REMOUNT, "")
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return roPath, cleanup, nil
+}
+
+// rsyncEscapePattern escapes the wildcard characters of a file name for use in an rsync filter rule.
+func rsyncEscapePattern(name string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`)
+
+	return replacer.Replace(name)
+}
+
+// migrationStatsConn wraps a migration connection and counts the bytes transferred over it.
+type migrationStatsConn struct {
+	io.ReadWriteCloser
+
+	start        time.Time
+	bytesRead    atomic.Int64
+	bytesWritten atomic.Int64
+}
+
+// newMigrationStatsConn returns a new migrationStatsConn wrapping conn.
+func newMigrationStatsConn(conn io.ReadWriteCloser) *migrationStatsConn {
+	return &migrationStatsConn{ReadWriteCloser: conn, start: time.Now()}
+}
+
+// Read reads from the wrapped connection and counts the bytes read.
+func (c *migrationStatsConn) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	c.bytesRead.Add(int64(n))
+
+	return n, err
+}
+
+// Write writes to the wrapped connection and counts the bytes written.
+func (c *migrationStatsConn) Write(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Write(p)
+	c.bytesWritten.Add(int64(n))
+
+	return n, err
+}
+
+// volumeChecksumBlockSize is the size of each block hashed when verifying block volumes.
+const volumeChecksumBlockSize = 1024 * 1024
+
+// volumeChecksumSampleBlocks is the maximum number of blocks hashed when verifying block volumes.
+const volumeChecksumSampleBlocks = 1024
+
+// filesystemChecksums returns the SHA-256 of each entry below path, keyed by its relative path.
+// Directories and other special files are recorded by mode only, symlinks by their target.
+func filesystemChecksums(path string) (map[string]string, error) {
+	sums := map[string]string{}
+
+	err := filepath.WalkDir(path, func(entryPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(path, entryPath)
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		// Skip the lost+found directory created by mkfs on block backed volumes.
+		if relPath == "lost+found" && entry.IsDir() {
+			return filepath.SkipDir
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		h := sha256.New()
+		_, _ = fmt.Fprintf(h, "%s\x00", info.Mode())
+
+		switch {
+		case info.Mode().IsRegular():
+			f, err := os.Open(entryPath)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(h, f)
+			_ = f.Close()
+			if err != nil {
+				return fmt.Errorf("Failed reading %q: %w", entryPath, err)
+			}
+
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(entryPath)
+			if err != nil {
+				return err
+			}
+
+			_, _ = io.WriteString(h, target)
+		}
+
+		sums[relPath] = hex.EncodeToString(h.Sum(nil))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sums, nil
+}
+
+// blockChecksums returns the SHA-256 of up to volumeChecksumSampleBlocks blocks spread evenly over the first
+// size bytes of the disk at path, keyed by their offset. The last block is always included.
+func blockChecksums(path string, size int64) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer logger.WarnOnError(f.Close, "Failed to close file")
+
+	blocks := (size + volumeChecksumBlockSize - 1) / volumeChecksumBlockSize
+	step := max(blocks/volumeChecksumSampleBlocks, 1)
+
+	sums := map[string]string{}
+	buf := make([]byte, volumeChecksumBlockSize)
+
+	hashBlock := func(block int64) error {
+		offset := block * volumeChecksumBlockSize
+		n := min(volumeChecksumBlockSize, size-offset)
+
+		_, err := f.ReadAt(buf[:n], offset)
+		if err != nil {
+			return fmt.Errorf("Failed reading %q at offset %d: %w", path, offset, err)
+		}
+
+		sum := sha256.Sum256(buf[:n])
+		sums[fmt.Sprintf("%d", offset)] = hex.EncodeToString(sum[:])
+
+		return nil
+	}
+
+	for block := int64(0); block < blocks; block += step {
+		err = hashBlock(block)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if blocks > 0 && (blocks-1)%step != 0 {
+		err = hashBlock(blocks - 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sums, nil
+}
+
+// checksumsMismatch returns the first key (in sorted order) whose checksum differs between a and b.
+// An empty string is returned if both sets of checksums match.
+func checksumsMismatch(a map[string]string, b map[string]string) string {
+	keys := slices.Collect(maps.Keys(a))
+	for k := range b {
+		_, found := a[k]
+		if !found {
+			keys = append(keys, k)
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if a[k] != b[k] {
+			return k
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,630 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v7/internal/migration"
+	"github.com/lxc/incus/v7/internal/server/db"
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	deviceConfig "github.com/lxc/incus/v7/internal/server/device/config"
+	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
+	localMigration "github.com/lxc/incus/v7/internal/server/migration"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/storage/drivers"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// testInstance is a minimal instance.ConfigReader.
+type testInstance struct {
+	name         string
+	instanceType instancetype.Type
+}
+
+func (i testInstance) Project() api.Project                  { return api.Project{Name: api.ProjectDefaultName} }
+func (i testInstance) Type() instancetype.Type               { return i.instanceType }
+func (i testInstance) Architecture() int                     { return 0 }
+func (i testInstance) ID() int                               { return 0 }
+func (i testInstance) Name() string                          { return i.name }
+func (i testInstance) ExpandedConfig() map[string]string     { return nil }
+func (i testInstance) ExpandedDevices() deviceConfig.Devices { return nil }
+func (i testInstance) LocalConfig() map[string]string        { return nil }
+func (i testInstance) LocalDevices() deviceConfig.Devices    { return nil }
+
+// Test validateInstanceCopyTypes.
+func Test_validateInstanceCopyTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		inst testInstance
+		src  testInstance
+		err  string
+	}{
+		{
+			name: "same type",
+			inst: testInstance{name: "c2", instanceType: instancetype.Container},
+			src:  testInstance{name: "c1", instanceType: instancetype.Container},
+		},
+		{
+			name: "container from virtual-machine",
+			inst: testInstance{name: "c2", instanceType: instancetype.Container},
+			src:  testInstance{name: "v1", instanceType: instancetype.VM},
+			err:  `Instance type "container" of "c2" doesn't match instance type "virtual-machine" of source "v1"`,
+		},
+		{
+			name: "virtual-machine from container",
+			inst: testInstance{name: "v2", instanceType: instancetype.VM},
+			src:  testInstance{name: "c1", instanceType: instancetype.Container},
+			err:  `Instance type "virtual-machine" of "v2" doesn't match instance type "container" of source "c1"`,
+		},
+		{
+			name: "container from virtual-machine snapshot",
+			inst: testInstance{name: "c2", instanceType: instancetype.Container},
+			src:  testInstance{name: "v1/snap0", instanceType: instancetype.VM},
+			err:  `Cannot copy virtual-machine snapshot "v1/snap0" into container "c2"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInstanceCopyTypes(tt.inst, tt.src)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.err)
+			assert.True(t, api.StatusErrorCheck(err, http.StatusBadRequest))
+		})
+	}
+}
+
+// Test validateVMConfigOnlyRefresh.
+func Test_validateVMConfigOnlyRefresh(t *testing.T) {
+	tests := []struct {
+		name      string
+		inst      testInstance
+		src       testInstance
+		snapshots bool
+		err       string
+	}{
+		{
+			name: "virtual-machines",
+			inst: testInstance{name: "v2", instanceType: instancetype.VM},
+			src:  testInstance{name: "v1", instanceType: instancetype.VM},
+		},
+		{
+			name: "containers",
+			inst: testInstance{name: "c2", instanceType: instancetype.Container},
+			src:  testInstance{name: "c1", instanceType: instancetype.Container},
+			err:  "Config volume only refresh is only supported for virtual machines",
+		},
+		{
+			name: "virtual-machine from container",
+			inst: testInstance{name: "v2", instanceType: instancetype.VM},
+			src:  testInstance{name: "c1", instanceType: instancetype.Container},
+			err:  "Config volume only refresh is only supported for virtual machines",
+		},
+		{
+			name:      "with snapshots",
+			inst:      testInstance{name: "v2", instanceType: instancetype.VM},
+			src:       testInstance{name: "v1", instanceType: instancetype.VM},
+			snapshots: true,
+			err:       "Config volume only refresh cannot include snapshots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVMConfigOnlyRefresh(tt.inst, tt.src, tt.snapshots)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.err)
+			assert.True(t, api.StatusErrorCheck(err, http.StatusBadRequest))
+		})
+	}
+}
+
+// Test customVolumeMoveTargetProject.
+func Test_customVolumeMoveTargetProject(t *testing.T) {
+	tests := []struct {
+		name          string
+		project       string
+		targetProject api.Project
+		want          string
+		err           string
+	}{
+		{
+			name:          "to project with custom volumes",
+			project:       api.ProjectDefaultName,
+			targetProject: api.Project{Name: "p1", ProjectPut: api.ProjectPut{Config: map[string]string{"features.storage.volumes": "true"}}},
+			want:          "p1",
+		},
+		{
+			name:          "to default project",
+			project:       "p1",
+			targetProject: api.Project{Name: api.ProjectDefaultName},
+			want:          api.ProjectDefaultName,
+		},
+		{
+			name:          "to project using default project volumes",
+			project:       "p1",
+			targetProject: api.Project{Name: "p2", ProjectPut: api.ProjectPut{Config: map[string]string{"features.storage.volumes": "false"}}},
+			want:          api.ProjectDefaultName,
+		},
+		{
+			name:          "from default project to project using default project volumes",
+			project:       api.ProjectDefaultName,
+			targetProject: api.Project{Name: "p2", ProjectPut: api.ProjectPut{Config: map[string]string{"features.storage.volumes": "false"}}},
+			err:           `Project "p2" already stores its custom volumes in project "default"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := customVolumeMoveTargetProject(tt.project, &tt.targetProject)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			assert.EqualError(t, err, tt.err)
+			assert.True(t, api.StatusErrorCheck(err, http.StatusBadRequest))
+		})
+	}
+}
+
+// Test restoreFilesRelPath.
+func Test_restoreFilesRelPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+		err  string
+	}{
+		{
+			name: "file",
+			path: "etc/hosts",
+			want: "etc/hosts",
+		},
+		{
+			name: "directory with trailing slash",
+			path: "var/lib/",
+			want: "var/lib",
+		},
+		{
+			name: "file name starting with dots",
+			path: "home/..foo",
+			want: "home/..foo",
+		},
+		{
+			name: "absolute path",
+			path: "/etc/hosts",
+			err:  `Path "/etc/hosts" must be relative to the root of the volume`,
+		},
+		{
+			name: "parent directory",
+			path: "../etc/hosts",
+			err:  `Path "../etc/hosts" cannot refer to a parent directory`,
+		},
+		{
+			name: "parent directory within the path",
+			path: "etc/../../hosts",
+			err:  `Path "etc/../../hosts" cannot refer to a parent directory`,
+		},
+		{
+			name: "volume root",
+			path: ".",
+			err:  "Restoring the whole volume requires a full snapshot restore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := restoreFilesRelPath(tt.path)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+// testMigrationPool is a Pool offering the migration types of a zfs or ceph pool for filesystem volumes.
+type testMigrationPool struct {
+	Pool
+
+	name   string
+	driver string
+}
+
+func (p testMigrationPool) Name() string { return p.name }
+
+func (p testMigrationPool) MigrationTypes(contentType drivers.ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
+	rsyncType := localMigration.Type{FSType: migration.MigrationFSType_RSYNC, Features: []string{"xattrs", "delete", "compress", "bidirectional"}}
+
+	switch p.driver {
+	case "zfs":
+		if refresh && !copySnapshots {
+			return []localMigration.Type{rsyncType}
+		}
+
+		return []localMigration.Type{{FSType: migration.MigrationFSType_ZFS, Features: []string{migration.ZFSFeatureMigrationHeader, "compress"}}, rsyncType}
+	case "ceph":
+		if refresh {
+			return []localMigration.Type{rsyncType}
+		}
+
+		return []localMigration.Type{{FSType: migration.MigrationFSType_RBD}, rsyncType}
+	}
+
+	return []localMigration.Type{rsyncType}
+}
+
+func TestCanOptimizeMigration(t *testing.T) {
+	tests := []struct {
+		name      string
+		srcDriver string
+		dstDriver string
+		refresh   bool
+		snapshots bool
+		want      migration.MigrationFSType
+	}{
+		{
+			name:      "same driver",
+			srcDriver: "zfs",
+			dstDriver: "zfs",
+			want:      migration.MigrationFSType_ZFS,
+		},
+		{
+			name:      "same driver with snapshots",
+			srcDriver: "zfs",
+			dstDriver: "zfs",
+			snapshots: true,
+			want:      migration.MigrationFSType_ZFS,
+		},
+		{
+			name:      "same driver refresh without snapshots",
+			srcDriver: "zfs",
+			dstDriver: "zfs",
+			refresh:   true,
+			want:      migration.MigrationFSType_RSYNC,
+		},
+		{
+			name:      "same driver refresh with snapshots",
+			srcDriver: "zfs",
+			dstDriver: "zfs",
+			refresh:   true,
+			snapshots: true,
+			want:      migration.MigrationFSType_ZFS,
+		},
+		{
+			name:      "same driver without optimized refresh",
+			srcDriver: "ceph",
+			dstDriver: "ceph",
+			refresh:   true,
+			snapshots: true,
+			want:      migration.MigrationFSType_RSYNC,
+		},
+		{
+			name:      "different drivers",
+			srcDriver: "zfs",
+			dstDriver: "ceph",
+			snapshots: true,
+			want:      migration.MigrationFSType_RSYNC,
+		},
+		{
+			name:      "different drivers refresh",
+			srcDriver: "ceph",
+			dstDriver: "zfs",
+			refresh:   true,
+			snapshots: true,
+			want:      migration.MigrationFSType_RSYNC,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srcPool := testMigrationPool{name: "src", driver: tt.srcDriver}
+			dstPool := testMigrationPool{name: "dst", driver: tt.dstDriver}
+
+			optimized, migrationType, err := CanOptimizeMigration(srcPool, dstPool, drivers.ContentTypeFS, tt.refresh, tt.snapshots)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, migrationType.FSType)
+			assert.Equal(t, tt.want != migration.MigrationFSType_RSYNC, optimized)
+		})
+	}
+}
+
+func TestPoolMigrationJobs(t *testing.T) {
+	newVolume := func(volType string, name string) *db.StorageVolume {
+		return &db.StorageVolume{StorageVolume: api.StorageVolume{Type: volType, Name: name, Project: api.ProjectDefaultName}}
+	}
+
+	dbVolumes := []*db.StorageVolume{
+		newVolume(db.StoragePoolVolumeTypeNameContainer, "c1"),
+		newVolume(db.StoragePoolVolumeTypeNameCustom, "vol1"),
+		newVolume(db.StoragePoolVolumeTypeNameImage, "abcdef"),
+		newVolume(db.StoragePoolVolumeTypeNameVM, "v1"),
+		newVolume(db.StoragePoolVolumeTypeNameContainer, "c1/snap0"),
+		newVolume(db.StoragePoolVolumeTypeNameCustom, "vol2"),
+		newVolume(db.StoragePoolVolumeTypeNameCustom, "vol1/snap0"),
+	}
+
+	dbBuckets := []*db.StorageBucket{
+		{StorageBucket: api.StorageBucket{Name: "bucket1", Project: api.ProjectDefaultName}},
+	}
+
+	jobs := poolMigrationJobs(dbVolumes, dbBuckets)
+
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		names = append(names, job.String())
+	}
+
+	// Custom volumes come before the instances, images and snapshots are left out.
+	assert.Equal(t, []string{
+		`custom volume "vol1" in project "default"`,
+		`custom volume "vol2" in project "default"`,
+		`instance "c1" in project "default"`,
+		`instance "v1" in project "default"`,
+		`bucket "bucket1" in project "default"`,
+	}, names)
+
+	assert.Same(t, dbVolumes[1], jobs[0].volume)
+}
+
+func TestRunPoolMigrationJobs(t *testing.T) {
+	jobs := []poolMigrationJob{
+		{kind: "custom volume", project: api.ProjectDefaultName, name: "vol1"},
+		{kind: "instance", project: api.ProjectDefaultName, name: "c1"},
+		{kind: "instance", project: api.ProjectDefaultName, name: "c2"},
+		{kind: "bucket", project: api.ProjectDefaultName, name: "bucket1"},
+	}
+
+	op, err := operations.OperationCreate(nil, "", operations.OperationClassTask, operationtype.VolumeMigrate, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	// A failing job doesn't stop the following ones.
+	var ran []string
+	failures, err := runPoolMigrationJobs(jobs, func(job poolMigrationJob) error {
+		ran = append(ran, job.name)
+		if job.kind == "instance" && job.name == "c1" {
+			return errors.New("copy failed")
+		}
+
+		return nil
+	}, op)
+
+	assert.Equal(t, []string{"vol1", "c1", "c2", "bucket1"}, ran)
+	assert.Equal(t, []string{`instance "c1" in project "default"`}, failures)
+	assert.EqualError(t, err, `Failed migrating instance "c1" in project "default": copy failed`)
+
+	metadata := op.Metadata()
+	assert.Equal(t, "4/4", metadata["pool_migration_progress"])
+	assert.Equal(t, 3, metadata["pool_migration_succeeded"])
+	assert.Equal(t, []string{`instance "c1" in project "default"`}, metadata["pool_migration_failed"])
+
+	// Nothing is reported as failed when all jobs succeed.
+	failures, err = runPoolMigrationJobs(jobs, func(job poolMigrationJob) error { return nil }, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+}
+
+// testSnapshotPool is a Pool reporting whether it supports snapshots.
+type testSnapshotPool struct {
+	Pool
+
+	name      string
+	snapshots bool
+}
+
+func (p testSnapshotPool) Name() string { return p.name }
+
+func (p testSnapshotPool) Capabilities() Capabilities { return Capabilities{Snapshots: p.snapshots} }
+
+// newTestConsistencyGroupMember returns a member recording its calls in events and whose snapshot fails with snapshotErr.
+func newTestConsistencyGroupMember(name string, pool Pool, events *[]string, snapshotErr error) consistencyGroupMember {
+	return consistencyGroupMember{
+		name: name,
+		pool: pool,
+		freeze: func() (func(), error) {
+			*events = append(*events, "freeze "+name)
+			return func() { *events = append(*events, "unfreeze "+name) }, nil
+		},
+		snapshot: func() error {
+			*events = append(*events, "snapshot "+name)
+			return snapshotErr
+		},
+		delete: func() {
+			*events = append(*events, "delete "+name)
+		},
+	}
+}
+
+func Test_snapshotConsistencyGroupMembers(t *testing.T) {
+	pool := testSnapshotPool{name: "pool1", snapshots: true}
+	noSnapshotsPool := testSnapshotPool{name: "pool2"}
+
+	tests := []struct {
+		name    string
+		members func(events *[]string) []consistencyGroupMember
+		events  []string
+		err     string
+	}{
+		{
+			name: "all snapshots taken",
+			members: func(events *[]string) []consistencyGroupMember {
+				return []consistencyGroupMember{
+					newTestConsistencyGroupMember("c1", pool, events, nil),
+					newTestConsistencyGroupMember("vol1", pool, events, nil),
+				}
+			},
+			events: []string{"freeze c1", "freeze vol1", "snapshot c1", "snapshot vol1", "unfreeze vol1", "unfreeze c1"},
+		},
+		{
+			name: "pool without snapshot support",
+			members: func(events *[]string) []consistencyGroupMember {
+				return []consistencyGroupMember{
+					newTestConsistencyGroupMember("c1", pool, events, nil),
+					newTestConsistencyGroupMember("vol1", noSnapshotsPool, events, nil),
+				}
+			},
+			events: nil,
+			err:    `Storage pool "pool2" of vol1 doesn't support snapshots`,
+		},
+		{
+			name: "partial failure",
+			members: func(events *[]string) []consistencyGroupMember {
+				return []consistencyGroupMember{
+					newTestConsistencyGroupMember("c1", pool, events, nil),
+					newTestConsistencyGroupMember("c2", pool, events, nil),
+					newTestConsistencyGroupMember("vol1", pool, events, errors.New("no space left")),
+				}
+			},
+			events: []string{
+				"freeze c1", "freeze c2", "freeze vol1",
+				"snapshot c1", "snapshot c2", "snapshot vol1",
+				"unfreeze vol1", "unfreeze c2", "unfreeze c1",
+				"delete c2", "delete c1",
+			},
+			err: "Failed snapshotting vol1: no space left",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+
+			err := snapshotConsistencyGroupMembers(tt.members(&events))
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+
+			assert.Equal(t, tt.events, events)
+		})
+	}
+}
+
+func Test_snapshotsBeyondLimit(t *testing.T) {
+	snapshots := []db.StorageVolumeArgs{
+		{Name: "vol1/pre-restore-20260101-000000"},
+		{Name: "vol1/snap0"},
+		{Name: "vol1/snap1"},
+		{Name: "vol1/snap2"},
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   []string
+		status int
+	}{
+		{
+			name:   "no limit",
+			config: map[string]string{},
+		},
+		{
+			name:   "below the limit",
+			config: map[string]string{"snapshots.max": "4"},
+		},
+		{
+			name:   "limit reached",
+			config: map[string]string{"snapshots.max": "3"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "limit reached with pruning",
+			config: map[string]string{"snapshots.max": "3", "snapshots.max.policy": "prune"},
+			want:   []string{"vol1/snap0"},
+		},
+		{
+			name:   "limit lowered with pruning",
+			config: map[string]string{"snapshots.max": "2", "snapshots.max.policy": "prune"},
+			want:   []string{"vol1/snap0", "vol1/snap1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := snapshotsBeyondLimit("vol1", tt.config, snapshots)
+			if tt.status != 0 {
+				assert.True(t, api.StatusErrorCheck(err, tt.status))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_deleteLeftoverVolumes(t *testing.T) {
+	vols := []drivers.Volume{
+		drivers.NewVolume(nil, "pool1", drivers.VolumeTypeImage, drivers.ContentTypeFS, "abcdef", nil, nil),
+		drivers.NewVolume(nil, "pool1", drivers.VolumeTypeContainer, drivers.ContentTypeFS, "default_c1", nil, nil),
+		drivers.NewVolume(nil, "pool1", drivers.VolumeTypeCustom, drivers.ContentTypeBlock, "default_vol1", nil, nil),
+	}
+
+	tests := []struct {
+		name     string
+		force    bool
+		failOn   string
+		deleted  []string
+		leftover []string
+		err      string
+	}{
+		{
+			name:     "report",
+			deleted:  []string{"abcdef"},
+			leftover: []string{`"default_c1" (containers, filesystem)`, `"default_vol1" (custom, block)`},
+		},
+		{
+			name:    "force",
+			force:   true,
+			deleted: []string{"default_c1", "default_vol1", "abcdef"},
+		},
+		{
+			name:    "force with failure",
+			force:   true,
+			failOn:  "default_vol1",
+			deleted: []string{"default_c1"},
+			err:     `Failed deleting left over custom volume "default_vol1" (block): busy`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+
+			leftover, err := deleteLeftoverVolumes(vols, tt.force, func(vol drivers.Volume) error {
+				if vol.Name() == tt.failOn {
+					return errors.New("busy")
+				}
+
+				deleted = append(deleted, vol.Name())
+				return nil
+			})
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.deleted, deleted)
+			assert.Equal(t, tt.leftover, leftover)
+		})
+	}
+}
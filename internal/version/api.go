@@ -547,6 +547,35 @@ var APIExtensions = []string{
 	"network_bridge_bgp_instances",
 	"core_https_allowed_websocket_origin",
 	"storage_btrfs_compression",
+	"storage_operation_lock_timeout",
+	"instance_templates_ignore_errors",
+	"storage_snapshots_reserve_percent",
+	"storage_bucket_keys_path_prefix",
+	"storage_volume_templates",
+	"storage_volume_copy_verify",
+	"storage_volume_snapshots_retain",
+	"storage_pool_manifest",
+	"storage_images_optimized",
+	"storage_snapshots_delete_workers",
+	"backup_optimized_strict",
+	"storage_pool_update_preview",
+	"storage_transfers_max_concurrent",
+	"storage_volume_tags",
+	"migration_progress_metadata",
+	"storage_volume_snapshots_max",
+	"storage_volume_mount_options",
+	"storage_pool_vm_state_size",
+	"storage_pool_snapshots_layout",
+	"storage_images_shrink_fallback",
+	"instance_migration_verify_only",
+	"snapshots_reserve_force",
+	"snapshot_restore_safety_snapshot",
+	"instance_debug_snapshots",
+	"instance_snapshot_volume_config",
+	"instance_snapshot_nowait",
+	"instance_refresh_config_only",
+	"storage_volume_image_delete_force",
+	"storage_volume_snapshot_quiesce",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
@@ -127,6 +127,12 @@ type InstancePost struct {
 	//
 	// API extension: instance_move_config
 	Profiles []string
+
+	// Only check that the target can accept the instance, without transferring it (migration only)
+	// Example: false
+	//
+	// API extension: instance_migration_verify_only
+	VerifyOnly bool `json:"verify_only" yaml:"verify_only"`
 }
 
 // InstancePostTarget represents the migration target host and operation.
@@ -182,6 +188,12 @@ type InstancePut struct {
 	// Example: false
 	DiskOnly bool `json:"disk_only,omitempty" yaml:"disk_only,omitempty"`
 
+	// Whether to snapshot the instance's current state before restoring
+	// Example: false
+	//
+	// API extension: snapshot_restore_safety_snapshot
+	RestoreSafetySnapshot bool `json:"restore_safety_snapshot,omitempty" yaml:"restore_safety_snapshot,omitempty"`
+
 	// Whether the instance currently has saved state on disk
 	// Example: false
 	Stateful bool `json:"stateful" yaml:"stateful"`
@@ -372,6 +384,12 @@ type InstanceSource struct {
 	// API extension: custom_volume_refresh_exclude_older_snapshots
 	RefreshExcludeOlder bool `json:"refresh_exclude_older,omitempty" yaml:"refresh_exclude_older,omitempty"`
 
+	// Whether to only refresh the config volume of a virtual machine, leaving its disk untouched (for copy)
+	// Example: false
+	//
+	// API extension: instance_refresh_config_only
+	RefreshConfigOnly bool `json:"refresh_config_only,omitempty" yaml:"refresh_config_only,omitempty"`
+
 	// Source project name (for copy and local image)
 	// Example: blah
 	Project string `json:"project,omitempty" yaml:"project,omitempty"`
@@ -381,4 +399,10 @@ type InstanceSource struct {
 	//
 	// API extension: instance_allow_inconsistent_copy
 	AllowInconsistent bool `json:"allow_inconsistent" yaml:"allow_inconsistent"`
+
+	// Only check that the instance can be accepted, without creating it (for migration)
+	// Example: false
+	//
+	// API extension: instance_migration_verify_only
+	VerifyOnly bool `json:"verify_only,omitempty" yaml:"verify_only,omitempty"`
 }
@@ -61,6 +61,12 @@ type InstanceBackupsPost struct {
 	// Example: true
 	OptimizedStorage bool `json:"optimized_storage" yaml:"optimized_storage"`
 
+	// Whether to fail rather than fall back to a plain tarball when an optimized backup isn't possible
+	// Example: true
+	//
+	// API extension: backup_optimized_strict
+	OptimizedStorageStrict bool `json:"optimized_storage_strict" yaml:"optimized_storage_strict"`
+
 	// What compression algorithm to use
 	// Example: gzip
 	//
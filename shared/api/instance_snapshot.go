@@ -23,6 +23,24 @@ type InstanceSnapshotsPost struct {
 	//
 	// API extension: snapshot_expiry_creation
 	ExpiresAt *time.Time `json:"expires_at" yaml:"expires_at"`
+
+	// Whether to skip the storage pool's snapshot space reserve check
+	// Example: false
+	//
+	// API extension: snapshots_reserve_force
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+
+	// Storage volume config keys to set on the snapshot's volume instead of the parent's values
+	// Example: {"user.purpose": "archive"}
+	//
+	// API extension: instance_snapshot_volume_config
+	VolumeConfig map[string]string `json:"volume_config,omitempty" yaml:"volume_config,omitempty"`
+
+	// Whether to fail rather than wait when another snapshot of the instance is in progress
+	// Example: false
+	//
+	// API extension: instance_snapshot_nowait
+	NoWait bool `json:"nowait,omitempty" yaml:"nowait,omitempty"`
 }
 
 // InstanceSnapshotPost represents the fields required to rename/move an instance snapshot.
@@ -128,3 +146,43 @@ type InstanceSnapshot struct {
 func (c *InstanceSnapshot) Writable() InstanceSnapshotPut {
 	return c.InstanceSnapshotPut
 }
+
+// SnapshotConsistencyReport represents the differences between the snapshot records of an instance, the
+// snapshot records of its storage volume and the snapshots found on storage.
+//
+// swagger:model
+//
+// API extension: instance_debug_snapshots.
+type SnapshotConsistencyReport struct {
+	// Names of the instance snapshot records
+	// Example: ["snap0", "snap1"]
+	InstanceSnapshots []string `json:"instance_snapshots" yaml:"instance_snapshots"`
+
+	// Names of the storage volume snapshot records
+	// Example: ["snap0", "snap1"]
+	VolumeSnapshots []string `json:"volume_snapshots" yaml:"volume_snapshots"`
+
+	// Names of the snapshots found on storage (null if the storage driver can't list them)
+	// Example: ["snap0"]
+	StorageSnapshots []string `json:"storage_snapshots" yaml:"storage_snapshots"`
+
+	// Snapshots with a storage volume record but no instance record
+	// Example: []
+	MissingInstanceRecords []string `json:"missing_instance_records" yaml:"missing_instance_records"`
+
+	// Snapshots with an instance record but no storage volume record
+	// Example: []
+	MissingVolumeRecords []string `json:"missing_volume_records" yaml:"missing_volume_records"`
+
+	// Snapshots with an instance or storage volume record that weren't found on storage
+	// Example: ["snap1"]
+	MissingOnStorage []string `json:"missing_on_storage" yaml:"missing_on_storage"`
+
+	// Snapshots found on storage that have no instance or storage volume record
+	// Example: []
+	UnknownOnStorage []string `json:"unknown_on_storage" yaml:"unknown_on_storage"`
+
+	// Whether the records and the storage all agree
+	// Example: false
+	Consistent bool `json:"consistent" yaml:"consistent"`
+}
@@ -98,3 +98,88 @@ func (storagePool *StoragePool) Writable() StoragePoolPut {
 type StoragePoolState struct {
 	ResourcesStoragePool `yaml:",inline"`
 }
+
+// StoragePoolUpdatePreview represents what a storage pool update would do, without applying it.
+//
+// swagger:model
+//
+// API extension: storage_pool_update_preview.
+type StoragePoolUpdatePreview struct {
+	// Configuration keys that would change, with their new values (empty if removed)
+	// Example: {"size": "50GiB"}
+	ChangedConfig map[string]string `json:"changed_config" yaml:"changed_config"`
+
+	// Whether only user configuration keys would change
+	// Example: false
+	UserOnly bool `json:"user_only" yaml:"user_only"`
+
+	// Whether the description would change
+	// Example: false
+	DescriptionChanged bool `json:"description_changed" yaml:"description_changed"`
+
+	// Whether the storage driver would be called to apply the change on this server
+	// Example: true
+	DriverUpdate bool `json:"driver_update" yaml:"driver_update"`
+
+	// Whether the update would be rejected
+	// Example: false
+	Rejected bool `json:"rejected" yaml:"rejected"`
+
+	// Reason for the update being rejected
+	// Example: Pool cannot be shrunk
+	RejectedReason string `json:"rejected_reason" yaml:"rejected_reason"`
+}
+
+// StoragePoolManifest represents the logical contents of a storage pool, without any volume data.
+//
+// swagger:model
+//
+// API extension: storage_pool_manifest.
+type StoragePoolManifest struct {
+	// Storage pool
+	Pool StoragePool `json:"pool" yaml:"pool"`
+
+	// Instances stored on the pool
+	Instances []StoragePoolManifestInstance `json:"instances" yaml:"instances"`
+
+	// Custom volumes stored on the pool
+	CustomVolumes []StoragePoolManifestVolume `json:"custom_volumes" yaml:"custom_volumes"`
+
+	// Image volumes stored on the pool
+	Images []StorageVolume `json:"images" yaml:"images"`
+
+	// Buckets stored on the pool
+	Buckets []StorageBucket `json:"buckets" yaml:"buckets"`
+}
+
+// StoragePoolManifestInstance represents an instance entry of a storage pool manifest.
+//
+// swagger:model
+//
+// API extension: storage_pool_manifest.
+type StoragePoolManifestInstance struct {
+	// Instance
+	Instance Instance `json:"instance" yaml:"instance"`
+
+	// Instance snapshots
+	Snapshots []InstanceSnapshot `json:"snapshots" yaml:"snapshots"`
+
+	// Instance volume
+	Volume StorageVolume `json:"volume" yaml:"volume"`
+
+	// Instance volume snapshots
+	VolumeSnapshots []StorageVolumeSnapshot `json:"volume_snapshots" yaml:"volume_snapshots"`
+}
+
+// StoragePoolManifestVolume represents a custom volume entry of a storage pool manifest.
+//
+// swagger:model
+//
+// API extension: storage_pool_manifest.
+type StoragePoolManifestVolume struct {
+	// Custom volume
+	Volume StorageVolume `json:"volume" yaml:"volume"`
+
+	// Custom volume snapshots
+	Snapshots []StorageVolumeSnapshot `json:"snapshots" yaml:"snapshots"`
+}
@@ -141,6 +141,12 @@ type StorageBucketKeyPut struct {
 	//
 	// API extension: storage_buckets
 	SecretKey string `json:"secret-key" yaml:"secret-key"`
+
+	// Restrict the key to objects under this prefix (local buckets only)
+	// Example: tenant-a/
+	//
+	// API extension: storage_bucket_keys_path_prefix
+	PathPrefix string `json:"path-prefix" yaml:"path-prefix"`
 }
 
 // StorageBucketKey represents the fields of a storage pool bucket key
@@ -165,7 +171,7 @@ func (b *StorageBucketKey) URL(apiVersion string, poolName string, projectName s
 
 // Etag returns the values used for etag generation.
 func (b *StorageBucketKey) Etag() []any {
-	return []any{b.Name, b.Description, b.Role, b.AccessKey, b.SecretKey}
+	return []any{b.Name, b.Description, b.Role, b.AccessKey, b.SecretKey, b.PathPrefix}
 }
 
 // Writable converts a full StorageBucketKey struct into a StorageBucketKeyPut struct (filters read-only fields).
@@ -30,6 +30,12 @@ type StorageVolumesPost struct {
 	//
 	// API extension: custom_block_volumes
 	ContentType string `json:"content_type" yaml:"content_type"`
+
+	// Name of a storage pool volume template to use as the base config
+	// Example: database
+	//
+	// API extension: storage_volume_templates
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
 }
 
 // StorageVolumePost represents the fields required to rename a storage pool volume
@@ -193,6 +199,12 @@ type StorageVolumePut struct {
 	//
 	// API extension: storage_api_volume_snapshots
 	Restore string `json:"restore,omitempty" yaml:"restore,omitempty"`
+
+	// Whether to snapshot the volume's current state before restoring
+	// Example: false
+	//
+	// API extension: snapshot_restore_safety_snapshot
+	RestoreSafetySnapshot bool `json:"restore_safety_snapshot,omitempty" yaml:"restore_safety_snapshot,omitempty"`
 }
 
 // StorageVolumeSource represents the creation source for a new storage volume
@@ -266,6 +278,12 @@ type StorageVolumeSource struct {
 	//
 	// API extension: cluster_internal_custom_volume_copy
 	Location string `json:"location" yaml:"location"`
+
+	// Whether to verify the content of the new volume against the source (for copy between pools)
+	// Example: false
+	//
+	// API extension: storage_volume_copy_verify
+	Verify bool `json:"verify,omitempty" yaml:"verify,omitempty"`
 }
 
 // Writable converts a full StorageVolume struct into a StorageVolumePut struct (filters read-only fields).
@@ -19,6 +19,18 @@ type StorageVolumeSnapshotsPost struct {
 	//
 	// API extension: custom_volume_snapshot_expiry
 	ExpiresAt *time.Time `json:"expires_at" yaml:"expires_at"`
+
+	// Whether to skip the storage pool's snapshot space reserve check
+	// Example: false
+	//
+	// API extension: snapshots_reserve_force
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+
+	// Whether to sync a filesystem volume used by a running instance, freezing the instance if needed, before the snapshot
+	// Example: false
+	//
+	// API extension: storage_volume_snapshot_quiesce
+	Quiesce bool `json:"quiesce,omitempty" yaml:"quiesce,omitempty"`
 }
 
 // StorageVolumeSnapshotPost represents the fields required to rename/move a storage volume snapshot
@@ -39,13 +39,18 @@ func (nwc *nullWriteCloser) Close() error {
 //
 // This uses RunWrapper if set.
 func ExtractWithFds(cmdName string, args []string, allowedCmds []string, stdin io.ReadCloser, output *os.File) error {
+	return extractWithFds(context.Background(), cmdName, args, allowedCmds, stdin, output)
+}
+
+// extractWithFds runs the extractor process like ExtractWithFds, killing it once ctx is done.
+func extractWithFds(ctx context.Context, cmdName string, args []string, allowedCmds []string, stdin io.ReadCloser, output *os.File) error {
 	// Needed for RunWrapper.
 	outputPath := output.Name()
 	allowedCmds = append(allowedCmds, cmdName)
 
 	// Setup the command.
 	var buffer bytes.Buffer
-	cmd := exec.Command(cmdName, args...)
+	cmd := exec.CommandContext(ctx, cmdName, args...)
 	cmd.Stdin = stdin
 	cmd.Stdout = output
 	cmd.Stderr = &nullWriteCloser{&buffer}
@@ -144,6 +149,11 @@ func CompressedTarReader(ctx context.Context, r io.ReadSeeker, unpacker []string
 
 // Unpack extracts image from archive.
 func Unpack(file string, path string, blockBackend bool, maxMemory int64, tracker *ioprogress.ProgressTracker) error {
+	return UnpackContext(context.Background(), file, path, blockBackend, maxMemory, tracker)
+}
+
+// UnpackContext extracts image from archive like Unpack, stopping the extraction once ctx is done.
+func UnpackContext(ctx context.Context, file string, path string, blockBackend bool, maxMemory int64, tracker *ioprogress.ProgressTracker) error {
 	extractArgs, extension, unpacker, err := DetectCompression(file)
 	if err != nil {
 		return err
@@ -241,7 +251,7 @@ func Unpack(file string, path string, blockBackend bool, maxMemory int64, tracke
 		readCloser = io.NopCloser(reader)
 	}
 
-	err = ExtractWithFds(command, args, allowedCmds, readCloser, outputDir)
+	err = extractWithFds(ctx, command, args, allowedCmds, readCloser, outputDir)
 	if err != nil {
 		// We can't create char/block devices in unpriv containers so ignore related errors.
 		if command == "unsquashfs" {
@@ -339,6 +339,8 @@ run_standalone_container() {
     run_test test_cloud_init "cloud-init"
     run_test test_concurrent "concurrent startup"
     run_test test_concurrent_exec "concurrent exec"
+    run_test test_concurrent_image_create "concurrent instance creation from image"
+    run_test test_concurrent_image_regenerate "concurrent instance creation during image volume regeneration"
     run_test test_config_edit "container configuration edit"
     run_test test_config_edit_container_snapshot_pool_config "container and snapshot volume configuration edit"
     run_test test_console "console"
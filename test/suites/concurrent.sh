@@ -31,3 +31,70 @@ test_concurrent() {
 
     ! incus list | grep -q concurrent || false
 }
+
+test_concurrent_image_create() {
+    ensure_import_testimage
+
+    fingerprint="$(incus image info testimage | awk '/^Fingerprint/ {print $2}')"
+    pool="$(incus profile device get default root pool)"
+
+    # Create instances from the image while its image volume is being deleted.
+    pids=""
+    for i in $(seq 4); do
+        incus init testimage "c${i}" -d root,size="$((i * 100))MiB" &
+        pids="${pids} $!"
+
+        # Deleting the image volume fails on pools without optimized images and once it's gone.
+        (incus storage volume delete "${pool}" "image/${fingerprint}" || true) &
+        pids="${pids} $!"
+    done
+
+    for pid in ${pids}; do
+        wait "${pid}"
+    done
+
+    # Every instance must have a usable root filesystem.
+    for i in $(seq 4); do
+        incus start "c${i}"
+        incus exec "c${i}" -- ls /bin/sh
+    done
+
+    incus delete -f c1 c2 c3 c4
+}
+
+test_concurrent_image_regenerate() {
+    incus_backend=$(storage_backend "$INCUS_DIR")
+    if [ "$incus_backend" != "lvm" ] && [ "$incus_backend" != "ceph" ]; then
+        echo "==> SKIP: image volume regeneration needs block backed image volumes"
+        return
+    fi
+
+    ensure_import_testimage
+
+    pool="$(incus profile device get default root pool)"
+
+    # Launch instances while the pool's block filesystem changes, which regenerates the image volume.
+    pids=""
+    for i in $(seq 4); do
+        incus launch testimage "c${i}" &
+        pids="${pids} $!"
+
+        if [ $((i % 2)) -eq 1 ]; then
+            incus storage set "${pool}" volume.block.filesystem=xfs
+        else
+            incus storage set "${pool}" volume.block.filesystem=ext4
+        fi
+    done
+
+    for pid in ${pids}; do
+        wait "${pid}"
+    done
+
+    # Every instance must have a usable root filesystem.
+    for i in $(seq 4); do
+        incus exec "c${i}" -- ls /bin/sh
+    done
+
+    incus delete -f c1 c2 c3 c4
+    incus storage unset "${pool}" volume.block.filesystem
+}
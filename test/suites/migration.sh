@@ -226,6 +226,18 @@ migration() {
     incus_remote storage volume get l2:"${remote_pool}" container/udssr/snap1 user.foo | grep -Fx "snap1"
     incus_remote delete l2:udssr
 
+    # Remote verify only copy.
+    incus_remote copy l1:cccp l2:udssr --verify-only
+    incus_remote copy l1:cccp l2:udssr --verify-only --mode=push
+    ! incus_remote info l2:udssr || false
+    ! incus_remote storage volume show l2:"${remote_pool}" container/udssr || false
+    ! incus copy cccp udssr --verify-only || false
+    incus_remote copy l1:cccp l2:udssr --instance-only
+    ! incus_remote copy l1:cccp l2:udssr --verify-only || false
+    incus_remote copy l1:cccp l2:udssr --verify-only --refresh
+    [ "$(incus_remote info l2:udssr | grep -c snap)" -eq 0 ]
+    incus_remote delete l2:udssr
+
     # Remote container only move.
     incus_remote move l1:cccp l2:udssr --instance-only --mode=relay
     ! incus_remote info l1:cccp || false
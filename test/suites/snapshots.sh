@@ -302,6 +302,12 @@ snap_restore() {
     incus snapshot restore a-b base
     incus snapshot create a-b c-d
     incus snapshot restore a-b c-d
+
+    # Test that a pre-restore snapshot is kept, even on drivers which can only restore their latest snapshot.
+    incus exec a-b -- touch /root/pre-restore
+    incus snapshot restore a-b c-d --safety-snapshot
+    incus info a-b | grep -q "pre-restore-"
+    ! incus exec a-b -- test -e /root/pre-restore || false
     incus delete -f a-b
 }
 